@@ -55,6 +55,24 @@ func GetAccount(ctx context.Context, cfg *config.Configuration, fetcher stored_r
 			}}
 		}
 
+		if currencyErrs := account.Currency.Validate(nil); len(currencyErrs) > 0 {
+			return nil, []error{&errortypes.MalformedAcct{
+				Message: fmt.Sprintf("The prebid-server account config currency for account id \"%s\" is invalid: %v. Please reach out to the prebid server host.", accountID, currencyErrs[0]),
+			}}
+		}
+
+		if analyticsErrs := account.Analytics.Validate(nil); len(analyticsErrs) > 0 {
+			return nil, []error{&errortypes.MalformedAcct{
+				Message: fmt.Sprintf("The prebid-server account config analytics for account id \"%s\" is invalid: %v. Please reach out to the prebid server host.", accountID, analyticsErrs[0]),
+			}}
+		}
+
+		if hooksErrs := account.Hooks.Validate(nil); len(hooksErrs) > 0 {
+			return nil, []error{&errortypes.MalformedAcct{
+				Message: fmt.Sprintf("The prebid-server account config hooks for account id \"%s\" is invalid: %v. Please reach out to the prebid server host.", accountID, hooksErrs[0]),
+			}}
+		}
+
 		// Fill in ID if needed, so it can be left out of account definition
 		if len(account.ID) == 0 {
 			account.ID = accountID
@@ -26,6 +26,9 @@ var mockAccountData = map[string]json.RawMessage{
 	"valid_acct":                json.RawMessage(`{"disabled":false}`),
 	"valid_acct_dsa":            json.RawMessage(`{"disabled":false, "privacy": {"dsa": {"default": "` + validDSA + `"}}}`),
 	"invalid_acct_dsa":          json.RawMessage(`{"disabled":false, "privacy": {"dsa": {"default": "` + invalidDSA + `"}}}`),
+	"invalid_acct_currency":     json.RawMessage(`{"disabled":false, "currency": {"usepbsrates": false}}`),
+	"invalid_acct_analytics":    json.RawMessage(`{"disabled":false, "analytics": {"modules": {"agma": {"sample_rate": 1.5}}}}`),
+	"invalid_acct_hooks":        json.RawMessage(`{"disabled":false, "hooks": {"execution_plan": {"endpoints": {"/openrtb2/auction": {"stages": {"entrypoint": {"groups": [{"timeout": 5, "merge_policy": "random"}]}}}}}}}`),
 	"invalid_acct_ipv6_ipv4":    json.RawMessage(`{"disabled":false, "privacy": {"ipv6": {"anon_keep_bits": -32}, "ipv4": {"anon_keep_bits": -16}}}`),
 	"disabled_acct":             json.RawMessage(`{"disabled":true}`),
 	"malformed_acct":            json.RawMessage(`{"disabled":"invalid type"}`),
@@ -92,6 +95,9 @@ func TestGetAccount(t *testing.T) {
 
 		{accountID: "invalid_acct_ipv6_ipv4", required: true, disabled: false, err: nil, wantDefaultIP: true},
 		{accountID: "invalid_acct_dsa", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
+		{accountID: "invalid_acct_currency", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
+		{accountID: "invalid_acct_analytics", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
+		{accountID: "invalid_acct_hooks", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
 
 		// pubID given and matches a host account explicitly disabled (Disabled: true on account json)
 		{accountID: "disabled_acct", required: false, disabled: false, err: &errortypes.AccountDisabled{}},
@@ -17,13 +17,16 @@ import (
 	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
 )
 
 type httpSender = func(payload []byte) error
 
 const (
-	agmaGVLID = 1122
-	p9        = 9
+	// moduleName is the name the module is registered with, used to look up its account options
+	moduleName = "agma"
+	agmaGVLID  = 1122
+	p9         = 9
 )
 
 type AgmaLogger struct {
@@ -177,20 +180,55 @@ func (l *AgmaLogger) extractPublisherAndSite(requestWrapper *openrtb_ext.Request
 	return publisherId, appSiteId
 }
 
-func (l *AgmaLogger) shouldTrackEvent(requestWrapper *openrtb_ext.RequestWrapper) (bool, string) {
+func hasConsent(requestWrapper *openrtb_ext.RequestWrapper) bool {
 	if requestWrapper.User == nil {
-		return false, ""
+		return false
 	}
 	consentStr := requestWrapper.User.Consent
 
 	parsedConsent, err := vendorconsent.ParseString(consentStr)
 	if err != nil {
-		return false, ""
+		return false
 	}
 
 	p9Allowed := parsedConsent.PurposeAllowed(p9)
 	agmaAllowed := parsedConsent.VendorConsent(agmaGVLID)
-	if !p9Allowed || !agmaAllowed {
+	return p9Allowed && agmaAllowed
+}
+
+// accountCode returns the agma code set in the account analytics options, if any
+func accountCode(account *config.Account) string {
+	if account == nil {
+		return ""
+	}
+	options := account.Analytics.ModuleOptions(moduleName)
+	if len(options) == 0 {
+		return ""
+	}
+
+	var opts accountOptions
+	if err := jsonutil.Unmarshal(options, &opts); err != nil {
+		glog.Errorf("[AgmaAnalytics] Invalid options for account %s: %v", account.ID, err)
+		return ""
+	}
+	return opts.Code
+}
+
+// shouldTrackAccountEvent returns whether the event is tracked and its agma code. A code set in the account
+// analytics options takes precedence over the accounts of the host configuration.
+func (l *AgmaLogger) shouldTrackAccountEvent(requestWrapper *openrtb_ext.RequestWrapper, account *config.Account) (bool, string) {
+	code := accountCode(account)
+	if code == "" {
+		return l.shouldTrackEvent(requestWrapper)
+	}
+	if !hasConsent(requestWrapper) {
+		return false, ""
+	}
+	return true, code
+}
+
+func (l *AgmaLogger) shouldTrackEvent(requestWrapper *openrtb_ext.RequestWrapper) (bool, string) {
+	if !hasConsent(requestWrapper) {
 		return false, ""
 	}
 
@@ -217,7 +255,7 @@ func (l *AgmaLogger) LogAuctionObject(event *analytics.AuctionObject) {
 	if event == nil || event.Status != http.StatusOK || event.RequestWrapper == nil {
 		return
 	}
-	shouldTrack, code := l.shouldTrackEvent(event.RequestWrapper)
+	shouldTrack, code := l.shouldTrackAccountEvent(event.RequestWrapper, event.Account)
 	if !shouldTrack {
 		return
 	}
@@ -233,7 +271,7 @@ func (l *AgmaLogger) LogAmpObject(event *analytics.AmpObject) {
 	if event == nil || event.Status != http.StatusOK || event.RequestWrapper == nil {
 		return
 	}
-	shouldTrack, code := l.shouldTrackEvent(event.RequestWrapper)
+	shouldTrack, code := l.shouldTrackAccountEvent(event.RequestWrapper, event.Account)
 	if !shouldTrack {
 		return
 	}
@@ -249,7 +287,7 @@ func (l *AgmaLogger) LogVideoObject(event *analytics.VideoObject) {
 	if event == nil || event.Status != http.StatusOK || event.RequestWrapper == nil {
 		return
 	}
-	shouldTrack, code := l.shouldTrackEvent(event.RequestWrapper)
+	shouldTrack, code := l.shouldTrackAccountEvent(event.RequestWrapper, event.Account)
 	if !shouldTrack {
 		return
 	}
@@ -1,6 +1,7 @@
 package agma
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -385,6 +386,106 @@ func TestShouldTrackMultipleAccounts(t *testing.T) {
 	assert.Equal(t, "123", code)
 }
 
+func TestShouldTrackAccountEvent(t *testing.T) {
+	cfg := config.AgmaAnalytics{
+		Enabled: true,
+		Endpoint: config.AgmaAnalyticsHttpEndpoint{
+			Url:     "http://localhost:8000/event",
+			Timeout: "5s",
+		},
+		Buffers: config.AgmaAnalyticsBuffer{
+			EventCount: 1,
+			BufferSize: "1Kb",
+			Timeout:    "1s",
+		},
+		Accounts: []config.AgmaAnalyticsAccount{
+			{
+				PublisherId: "track-me",
+				Code:        "abc",
+			},
+		},
+	}
+	mockedSender := new(MockedSender)
+	mockedSender.On("Send", mock.Anything).Return(nil)
+	logger, err := newAgmaLogger(cfg, mockedSender.Send, clock.NewMock())
+	assert.NoError(t, err)
+
+	accountWithCode := func(options string) *config.Account {
+		return &config.Account{
+			ID: "1001",
+			Analytics: config.AccountAnalytics{
+				Modules: map[string]config.AccountAnalyticsModule{
+					"agma": {Options: json.RawMessage(options)},
+				},
+			},
+		}
+	}
+	requestWrapper := func(publisherID, consent string) *openrtb_ext.RequestWrapper {
+		return &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{
+				Site: &openrtb2.Site{ID: "site", Publisher: &openrtb2.Publisher{ID: publisherID}},
+				User: &openrtb2.User{Consent: consent},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		request       *openrtb_ext.RequestWrapper
+		account       *config.Account
+		expectedTrack bool
+		expectedCode  string
+	}{
+		{
+			name:          "no-account-uses-host-accounts",
+			request:       requestWrapper("track-me", agmaConsent),
+			expectedTrack: true,
+			expectedCode:  "abc",
+		},
+		{
+			name:          "account-without-options-uses-host-accounts",
+			request:       requestWrapper("track-me", agmaConsent),
+			account:       &config.Account{ID: "1001"},
+			expectedTrack: true,
+			expectedCode:  "abc",
+		},
+		{
+			name:          "account-code-for-unknown-publisher",
+			request:       requestWrapper("not-in-host-accounts", agmaConsent),
+			account:       accountWithCode(`{"code":"publisher-code"}`),
+			expectedTrack: true,
+			expectedCode:  "publisher-code",
+		},
+		{
+			name:          "account-code-overrides-host-accounts",
+			request:       requestWrapper("track-me", agmaConsent),
+			account:       accountWithCode(`{"code":"publisher-code"}`),
+			expectedTrack: true,
+			expectedCode:  "publisher-code",
+		},
+		{
+			name:          "account-code-without-consent",
+			request:       requestWrapper("track-me", ""),
+			account:       accountWithCode(`{"code":"publisher-code"}`),
+			expectedTrack: false,
+		},
+		{
+			name:          "malformed-account-options",
+			request:       requestWrapper("not-in-host-accounts", agmaConsent),
+			account:       accountWithCode(`{"code":1}`),
+			expectedTrack: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shouldTrack, code := logger.shouldTrackAccountEvent(tc.request, tc.account)
+			assert.Equal(t, tc.expectedTrack, shouldTrack)
+			assert.Equal(t, tc.expectedCode, code)
+		})
+	}
+}
+
 func TestShouldNotTrackLog(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -17,6 +17,11 @@ const (
 	EventTypeVideo   EventType = "video"
 )
 
+// accountOptions are the agma settings of an account, see config.AccountAnalyticsModule
+type accountOptions struct {
+	Code string `json:"code"`
+}
+
 type logObject struct {
 	EventType   EventType        `json:"type"`
 	RequestId   string           `json:"id"`
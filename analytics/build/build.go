@@ -2,6 +2,7 @@ package build
 
 import (
 	"encoding/json"
+	"math/rand"
 
 	"github.com/benbjohnson/clock"
 	"github.com/golang/glog"
@@ -9,17 +10,25 @@ import (
 	"github.com/prebid/prebid-server/v3/analytics/agma"
 	"github.com/prebid/prebid-server/v3/analytics/clients"
 	"github.com/prebid/prebid-server/v3/analytics/filesystem"
+	"github.com/prebid/prebid-server/v3/analytics/httpbatch"
 	"github.com/prebid/prebid-server/v3/analytics/pubstack"
 	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/metrics"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/ortb"
 	"github.com/prebid/prebid-server/v3/privacy"
 )
 
 // Modules that need to be logged to need to be initialized here
-func New(analytics *config.Analytics) analytics.Runner {
+func New(analytics *config.Analytics, metricsEngine metrics.MetricsEngine) analytics.Runner {
 	modules := make(enabledAnalytics, 0)
-	if len(analytics.File.Filename) > 0 {
+	if len(analytics.File.Directory) > 0 {
+		if mod, err := filesystem.NewRotatingFileLogger(analytics.File, clock.New(), metricsEngine); err == nil {
+			modules["filelogger"] = mod
+		} else {
+			glog.Fatalf("Could not initialize FileLogger for directory %v :%v", analytics.File.Directory, err)
+		}
+	} else if len(analytics.File.Filename) > 0 {
 		if mod, err := filesystem.NewFileLogger(analytics.File.Filename); err == nil {
 			modules["filelogger"] = mod
 		} else {
@@ -56,6 +65,19 @@ func New(analytics *config.Analytics) analytics.Runner {
 		}
 	}
 
+	if analytics.HTTPBatch.Enabled {
+		httpBatchModule, err := httpbatch.NewModule(
+			clients.GetDefaultHttpInstance(),
+			analytics.HTTPBatch,
+			clock.New(),
+			metricsEngine)
+		if err == nil {
+			modules["httpbatch"] = httpBatchModule
+		} else {
+			glog.Errorf("Could not initialize HTTP Batch Analytics: %v", err)
+		}
+	}
+
 	return modules
 }
 
@@ -63,7 +85,11 @@ func New(analytics *config.Analytics) analytics.Runner {
 type enabledAnalytics map[string]analytics.Module
 
 func (ea enabledAnalytics) LogAuctionObject(ao *analytics.AuctionObject, ac privacy.ActivityControl) {
+	ao.ModuleResults = analytics.NewModuleResults(ao.HookExecutionOutcome)
 	for name, module := range ea {
+		if !isAccountModule(ao.Account, name) {
+			continue
+		}
 		if isAllowed, cloneBidderReq := evaluateActivities(ao.RequestWrapper, ac, name); isAllowed {
 			if cloneBidderReq != nil {
 				ao.RequestWrapper = cloneBidderReq
@@ -78,7 +104,11 @@ func (ea enabledAnalytics) LogAuctionObject(ao *analytics.AuctionObject, ac priv
 }
 
 func (ea enabledAnalytics) LogVideoObject(vo *analytics.VideoObject, ac privacy.ActivityControl) {
+	vo.ModuleResults = analytics.NewModuleResults(vo.HookExecutionOutcome)
 	for name, module := range ea {
+		if !isAccountModule(vo.Account, name) {
+			continue
+		}
 		if isAllowed, cloneBidderReq := evaluateActivities(vo.RequestWrapper, ac, name); isAllowed {
 			if cloneBidderReq != nil {
 				vo.RequestWrapper = cloneBidderReq
@@ -106,7 +136,11 @@ func (ea enabledAnalytics) LogSetUIDObject(so *analytics.SetUIDObject) {
 }
 
 func (ea enabledAnalytics) LogAmpObject(ao *analytics.AmpObject, ac privacy.ActivityControl) {
+	ao.ModuleResults = analytics.NewModuleResults(ao.HookExecutionOutcome)
 	for name, module := range ea {
+		if !isAccountModule(ao.Account, name) {
+			continue
+		}
 		if isAllowed, cloneBidderReq := evaluateActivities(ao.RequestWrapper, ac, name); isAllowed {
 			if cloneBidderReq != nil {
 				ao.RequestWrapper = cloneBidderReq
@@ -122,6 +156,9 @@ func (ea enabledAnalytics) LogAmpObject(ao *analytics.AmpObject, ac privacy.Acti
 
 func (ea enabledAnalytics) LogNotificationEventObject(ne *analytics.NotificationEvent, ac privacy.ActivityControl) {
 	for name, module := range ea {
+		if !isAccountModule(ne.Account, name) {
+			continue
+		}
 		component := privacy.Component{Type: privacy.ComponentTypeAnalytics, Name: name}
 		if ac.Allow(privacy.ActivityReportAnalytics, component, privacy.ActivityRequest{}) {
 			module.LogNotificationEventObject(ne)
@@ -136,6 +173,22 @@ func (ea enabledAnalytics) Shutdown() {
 	}
 }
 
+// randFloat64 draws the numbers used to sample the account events, tests replace it to get deterministic results
+var randFloat64 = rand.Float64
+
+// isAccountModule returns whether the account routes its events to the named module, taking the module sample
+// rate into account. Events not tied to an account are logged by every module.
+func isAccountModule(account *config.Account, name string) bool {
+	if account == nil {
+		return true
+	}
+	if !account.Analytics.ModuleEnabled(name) {
+		return false
+	}
+	rate := account.Analytics.ModuleSampleRate(name)
+	return rate >= 1 || randFloat64() < rate
+}
+
 func evaluateActivities(rw *openrtb_ext.RequestWrapper, ac privacy.ActivityControl, componentName string) (bool, *openrtb_ext.RequestWrapper) {
 	// returned nil request wrapper means that request wrapper was not modified by activities and doesn't have to be changed in analytics object
 	// it is needed in order to use one function for all analytics objects with RequestWrapper
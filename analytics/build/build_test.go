@@ -11,6 +11,9 @@ import (
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
 	"github.com/prebid/prebid-server/v3/privacy"
 	"github.com/prebid/prebid-server/v3/util/ptrutil"
 	"github.com/stretchr/testify/assert"
@@ -88,7 +91,7 @@ func initAnalytics(count *int) analytics.Runner {
 }
 
 func TestNewPBSAnalytics(t *testing.T) {
-	pbsAnalytics := New(&config.Analytics{})
+	pbsAnalytics := New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{})
 	instance := pbsAnalytics.(enabledAnalytics)
 
 	assert.Equal(t, len(instance), 0)
@@ -114,7 +117,7 @@ func TestNewPBSAnalytics_FileLogger(t *testing.T) {
 		}
 	}
 	defer os.RemoveAll(TEST_DIR)
-	mod := New(&config.Analytics{File: config.FileLogs{Filename: TEST_DIR + "/test"}})
+	mod := New(&config.Analytics{File: config.FileLogs{Filename: TEST_DIR + "/test"}}, &metricsConfig.NilMetricsEngine{})
 	switch modType := mod.(type) {
 	case enabledAnalytics:
 		if len(enabledAnalytics(modType)) != 1 {
@@ -124,7 +127,7 @@ func TestNewPBSAnalytics_FileLogger(t *testing.T) {
 		t.Fatalf("Failed to initialize analytics module")
 	}
 
-	pbsAnalytics := New(&config.Analytics{File: config.FileLogs{Filename: TEST_DIR + "/test"}})
+	pbsAnalytics := New(&config.Analytics{File: config.FileLogs{Filename: TEST_DIR + "/test"}}, &metricsConfig.NilMetricsEngine{})
 	instance := pbsAnalytics.(enabledAnalytics)
 
 	assert.Equal(t, len(instance), 1)
@@ -143,7 +146,7 @@ func TestNewPBSAnalytics_Pubstack(t *testing.T) {
 			},
 			ConfRefresh: "2h",
 		},
-	})
+	}, &metricsConfig.NilMetricsEngine{})
 	instanceWithoutError := pbsAnalyticsWithoutError.(enabledAnalytics)
 
 	assert.Equal(t, len(instanceWithoutError), 1)
@@ -152,7 +155,7 @@ func TestNewPBSAnalytics_Pubstack(t *testing.T) {
 		Pubstack: config.Pubstack{
 			Enabled: true,
 		},
-	})
+	}, &metricsConfig.NilMetricsEngine{})
 	instanceWithError := pbsAnalyticsWithError.(enabledAnalytics)
 	assert.Equal(t, len(instanceWithError), 0)
 }
@@ -177,7 +180,7 @@ func TestNewModuleHttp(t *testing.T) {
 				},
 			},
 		},
-	})
+	}, &metricsConfig.NilMetricsEngine{})
 	instanceWithoutError := agmaAnalyticsWithoutError.(enabledAnalytics)
 
 	assert.Equal(t, len(instanceWithoutError), 1)
@@ -186,7 +189,7 @@ func TestNewModuleHttp(t *testing.T) {
 		Agma: config.AgmaAnalytics{
 			Enabled: true,
 		},
-	})
+	}, &metricsConfig.NilMetricsEngine{})
 	instanceWithError := agmaAnalyticsWithError.(enabledAnalytics)
 	assert.Equal(t, len(instanceWithError), 0)
 }
@@ -293,6 +296,83 @@ func TestSampleModuleActivitiesDenied(t *testing.T) {
 	}
 }
 
+func TestAccountModuleRouting(t *testing.T) {
+	defer func(original func() float64) { randFloat64 = original }(randFloat64)
+	randFloat64 = func() float64 { return 0.5 }
+
+	testCases := []struct {
+		description   string
+		account       *config.Account
+		expectedCount int
+	}{
+		{
+			description:   "no account",
+			account:       nil,
+			expectedCount: 1,
+		},
+		{
+			description:   "account without analytics modules",
+			account:       &config.Account{ID: "1001"},
+			expectedCount: 1,
+		},
+		{
+			description: "module selected by account",
+			account: &config.Account{Analytics: config.AccountAnalytics{Modules: map[string]config.AccountAnalyticsModule{
+				"sampleModule": {},
+			}}},
+			expectedCount: 1,
+		},
+		{
+			description: "module not selected by account",
+			account: &config.Account{Analytics: config.AccountAnalytics{Modules: map[string]config.AccountAnalyticsModule{
+				"otherModule": {},
+			}}},
+			expectedCount: 0,
+		},
+		{
+			description: "module disabled by account",
+			account: &config.Account{Analytics: config.AccountAnalytics{Modules: map[string]config.AccountAnalyticsModule{
+				"sampleModule": {Enabled: ptrutil.ToPtr(false)},
+			}}},
+			expectedCount: 0,
+		},
+		{
+			description: "event sampled in",
+			account: &config.Account{Analytics: config.AccountAnalytics{Modules: map[string]config.AccountAnalyticsModule{
+				"sampleModule": {SampleRate: ptrutil.ToPtr(0.75)},
+			}}},
+			expectedCount: 1,
+		},
+		{
+			description: "event sampled out",
+			account: &config.Account{Analytics: config.AccountAnalytics{Modules: map[string]config.AccountAnalyticsModule{
+				"sampleModule": {SampleRate: ptrutil.ToPtr(0.25)},
+			}}},
+			expectedCount: 0,
+		},
+	}
+
+	acAllowed := privacy.NewActivityControl(getActivityConfig("sampleModule", true, true, true))
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var count int
+			am := initAnalytics(&count)
+
+			am.LogAuctionObject(&analytics.AuctionObject{RequestWrapper: &openrtb_ext.RequestWrapper{}, Account: tc.account}, acAllowed)
+			assert.Equal(t, tc.expectedCount, count, "auction")
+
+			am.LogAmpObject(&analytics.AmpObject{RequestWrapper: &openrtb_ext.RequestWrapper{}, Account: tc.account}, acAllowed)
+			assert.Equal(t, 2*tc.expectedCount, count, "amp")
+
+			am.LogVideoObject(&analytics.VideoObject{RequestWrapper: &openrtb_ext.RequestWrapper{}, Account: tc.account}, acAllowed)
+			assert.Equal(t, 3*tc.expectedCount, count, "video")
+
+			am.LogNotificationEventObject(&analytics.NotificationEvent{Account: tc.account}, acAllowed)
+			assert.Equal(t, 4*tc.expectedCount, count, "notification")
+		})
+	}
+}
+
 func TestEvaluateActivities(t *testing.T) {
 	testCases := []struct {
 		description             string
@@ -556,6 +636,39 @@ func TestLogObject(t *testing.T) {
 	}
 }
 
+func TestLogObjectModuleResults(t *testing.T) {
+	outcomes := []hookexecution.StageOutcome{
+		{
+			Stage: "raw_bidder_response",
+			Groups: []hookexecution.GroupOutcome{
+				{
+					InvocationResults: []hookexecution.HookOutcome{
+						{
+							HookID:        hookexecution.HookID{ModuleCode: "prebid.blocklist", HookImplCode: "blocklist"},
+							Status:        hookexecution.StatusSuccess,
+							AnalyticsTags: hookanalytics.Analytics{Activities: []hookanalytics.Activity{{Name: "enforce_blocklists", Status: hookanalytics.ActivityStatusSuccess}}},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := analytics.NewModuleResults(outcomes)
+	ea := enabledAnalytics{}
+
+	ao := &analytics.AuctionObject{HookExecutionOutcome: outcomes}
+	ea.LogAuctionObject(ao, privacy.ActivityControl{})
+	assert.Equal(t, expected, ao.ModuleResults)
+
+	amp := &analytics.AmpObject{HookExecutionOutcome: outcomes}
+	ea.LogAmpObject(amp, privacy.ActivityControl{})
+	assert.Equal(t, expected, amp.ModuleResults)
+
+	vo := &analytics.VideoObject{HookExecutionOutcome: outcomes}
+	ea.LogVideoObject(vo, privacy.ActivityControl{})
+	assert.Equal(t, expected, vo.ModuleResults)
+}
+
 func TestUpdateReqWrapperForAnalytics(t *testing.T) {
 	tests := []struct {
 		description               string
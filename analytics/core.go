@@ -5,6 +5,7 @@ import (
 
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/currency"
 	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
@@ -30,8 +31,16 @@ type AuctionObject struct {
 	Account              *config.Account
 	StartTime            time.Time
 	HookExecutionOutcome []hookexecution.StageOutcome
-	SeatNonBid           []openrtb_ext.SeatNonBid
-	RequestWrapper       *openrtb_ext.RequestWrapper
+	// ModuleResults are the analytics tags of the hook modules, filled from HookExecutionOutcome before
+	// the object is logged
+	ModuleResults  []*ModuleResult
+	SeatNonBid     []openrtb_ext.SeatNonBid
+	RequestWrapper *openrtb_ext.RequestWrapper
+	// CurrencyConversions are the rates applied to the auction, to normalize revenue to a reporting currency
+	CurrencyConversions currency.Conversions
+	// BidderCalls are the outcome of every bidder called during the auction, including the bids which didn't
+	// make it into the response
+	BidderCalls []*BidderCall
 }
 
 // Loggable object of a transaction at /openrtb2/amp endpoint
@@ -41,22 +50,67 @@ type AmpObject struct {
 	AuctionResponse      *openrtb2.BidResponse
 	AmpTargetingValues   map[string]string
 	Origin               string
+	Account              *config.Account
 	StartTime            time.Time
 	HookExecutionOutcome []hookexecution.StageOutcome
-	SeatNonBid           []openrtb_ext.SeatNonBid
-	RequestWrapper       *openrtb_ext.RequestWrapper
+	// ModuleResults are the analytics tags of the hook modules, filled from HookExecutionOutcome before
+	// the object is logged
+	ModuleResults       []*ModuleResult
+	SeatNonBid          []openrtb_ext.SeatNonBid
+	RequestWrapper      *openrtb_ext.RequestWrapper
+	CurrencyConversions currency.Conversions
+	BidderCalls         []*BidderCall
 }
 
 // Loggable object of a transaction at /openrtb2/video endpoint
 type VideoObject struct {
-	Status         int
-	Errors         []error
-	Response       *openrtb2.BidResponse
-	VideoRequest   *openrtb_ext.BidRequestVideo
-	VideoResponse  *openrtb_ext.BidResponseVideo
-	StartTime      time.Time
-	SeatNonBid     []openrtb_ext.SeatNonBid
-	RequestWrapper *openrtb_ext.RequestWrapper
+	Status        int
+	Errors        []error
+	Response      *openrtb2.BidResponse
+	VideoRequest  *openrtb_ext.BidRequestVideo
+	VideoResponse *openrtb_ext.BidResponseVideo
+	Account       *config.Account
+	StartTime     time.Time
+	// HookExecutionOutcome is the outcome of the entrypoint and exitpoint stages, the only ones run for video
+	HookExecutionOutcome []hookexecution.StageOutcome
+	// ModuleResults are the analytics tags of the hook modules, filled from HookExecutionOutcome before
+	// the object is logged
+	ModuleResults       []*ModuleResult
+	SeatNonBid          []openrtb_ext.SeatNonBid
+	RequestWrapper      *openrtb_ext.RequestWrapper
+	CurrencyConversions currency.Conversions
+	BidderCalls         []*BidderCall
+}
+
+// BidderCall is the outcome of the request made to a bidder during an auction
+type BidderCall struct {
+	Bidder             string `json:"bidder"`
+	Adapter            string `json:"adapter"`
+	ResponseTimeMillis int    `json:"response_time_ms"`
+	// HttpStatuses has the status code of every HTTP call made to the bidder, 0 when no response was received
+	HttpStatuses []int                          `json:"http_statuses,omitempty"`
+	Errors       []openrtb_ext.ExtBidderMessage `json:"errors,omitempty"`
+	Bids         []*BidderCallBid               `json:"bids,omitempty"`
+	// NonBids are all the non bids reported for the seats of the bidder, whether the imp was rejected before
+	// bidding or the bid after being received
+	NonBids []openrtb_ext.NonBid `json:"non_bids,omitempty"`
+}
+
+// BidderCallBid is a bid returned by a bidder, whether it won, lost or was rejected by the exchange
+type BidderCallBid struct {
+	Seat    string              `json:"seat"`
+	ImpID   string              `json:"imp_id"`
+	BidID   string              `json:"bid_id"`
+	DealID  string              `json:"deal_id,omitempty"`
+	BidType openrtb_ext.BidType `json:"bid_type,omitempty"`
+	// OriginalPrice and OriginalCurrency are the price as returned by the bidder
+	OriginalPrice    float64 `json:"original_price"`
+	OriginalCurrency string  `json:"original_currency,omitempty"`
+	// Price and Currency are the price after the bid adjustments and the conversion to the auction currency
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency,omitempty"`
+	// NonBidReason is the reason the bid was rejected by the exchange after being received, 0 when it wasn't
+	NonBidReason int `json:"non_bid_reason,omitempty"`
 }
 
 // Loggable object of a transaction at /setuid
@@ -91,4 +145,7 @@ type UsersyncInfo struct {
 type NotificationEvent struct {
 	Request *EventRequest   `json:"request"`
 	Account *config.Account `json:"account"`
+	// Auction is the winning bid the event was reconciled with, nil when reconciliation is disabled or the bid
+	// isn't known anymore
+	Auction *AuctionBid `json:"auction,omitempty"`
 }
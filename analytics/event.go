@@ -51,3 +51,15 @@ type EventRequest struct {
 	Integration string         `json:"integration,omitempty"`
 	VType       VastType       `json:"vtype,omitempty"`
 }
+
+// AuctionBid is the winning bid of the auction an event notification was reconciled with
+type AuctionBid struct {
+	AuctionID string `json:"auction_id"`
+	// AuctionTimestamp is the start of the auction in milliseconds since the epoch
+	AuctionTimestamp int64   `json:"auction_timestamp"`
+	ImpID            string  `json:"imp_id"`
+	BidID            string  `json:"bid_id"`
+	Bidder           string  `json:"bidder"`
+	Price            float64 `json:"price"`
+	Currency         string  `json:"currency,omitempty"`
+}
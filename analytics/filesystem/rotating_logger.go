@@ -0,0 +1,207 @@
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/benbjohnson/clock"
+	"github.com/golang/glog"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/fileutil"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// moduleName is the name the dropped events are reported with
+const moduleName = "filelogger"
+
+// RotatingFileLogger is an analytics module writing each event type to its own file asynchronously. Events are
+// queued per event type and dropped when the queue is full, so logging never slows down the requests.
+type RotatingFileLogger struct {
+	streams       map[EventType]*eventStream
+	clock         clock.Clock
+	metricsEngine metrics.MetricsEngine
+	wg            sync.WaitGroup
+	// closedMutex guards the queues against being written to after they're closed by Shutdown
+	closedMutex sync.RWMutex
+	closed      bool
+}
+
+// eventStream writes the events of a single type from its own goroutine
+type eventStream struct {
+	queue  chan []byte
+	writer *fileutil.RotatingWriter
+}
+
+// NewRotatingFileLogger returns a RotatingFileLogger writing to the directory configured in cfg
+func NewRotatingFileLogger(cfg config.FileLogs, clock clock.Clock, metricsEngine metrics.MetricsEngine) (analytics.Module, error) {
+	maxSize, maxAge, err := fileutil.ParseRotationLimits(cfg.Rotation.MaxSize, cfg.Rotation.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.QueueSize <= 0 {
+		return nil, errors.New("the file logger queue size must be positive")
+	}
+
+	if err := os.MkdirAll(cfg.Directory, 0755); err != nil {
+		return nil, err
+	}
+
+	l := &RotatingFileLogger{
+		streams:       make(map[EventType]*eventStream, len(eventTypes)),
+		clock:         clock,
+		metricsEngine: metricsEngine,
+	}
+	for _, eventType := range eventTypes {
+		writer, err := fileutil.NewRotatingWriter(filepath.Join(cfg.Directory, string(eventType)+".log"), maxSize, maxAge, cfg.Rotation.Compress, cfg.Rotation.MaxBackups, clock)
+		if err != nil {
+			l.Shutdown()
+			return nil, err
+		}
+		stream := &eventStream{
+			queue:  make(chan []byte, cfg.QueueSize),
+			writer: writer,
+		}
+		l.streams[eventType] = stream
+
+		l.wg.Add(1)
+		go l.run(eventType, stream)
+	}
+
+	return l, nil
+}
+
+func (l *RotatingFileLogger) run(eventType EventType, stream *eventStream) {
+	defer l.wg.Done()
+
+	for line := range stream.queue {
+		if err := stream.writer.WriteLine(line); err != nil {
+			glog.Errorf("[FileLogger] Failed to write %s event: %v", eventType, err)
+		}
+		// flush once the queue is drained to batch the writes under load
+		if len(stream.queue) == 0 {
+			if err := stream.writer.Flush(); err != nil {
+				glog.Errorf("[FileLogger] Failed to flush %s events: %v", eventType, err)
+			}
+		}
+	}
+
+	if err := stream.writer.Close(); err != nil {
+		glog.Errorf("[FileLogger] Failed to close %s events file: %v", eventType, err)
+	}
+}
+
+func (l *RotatingFileLogger) log(eventType EventType, data interface{}) {
+	line, err := jsonutil.Marshal(&Record{
+		SchemaVersion: SchemaVersion,
+		Type:          eventType,
+		Timestamp:     l.clock.Now().UTC(),
+		Data:          data,
+	})
+	if err != nil {
+		glog.Errorf("[FileLogger] Failed to serialize %s event: %v", eventType, err)
+		return
+	}
+
+	l.closedMutex.RLock()
+	defer l.closedMutex.RUnlock()
+	if l.closed {
+		return
+	}
+
+	select {
+	case l.streams[eventType].queue <- line:
+	default:
+		l.metricsEngine.RecordAnalyticsEventDropped(moduleName, string(eventType))
+	}
+}
+
+func (l *RotatingFileLogger) LogAuctionObject(ao *analytics.AuctionObject) {
+	if ao == nil {
+		return
+	}
+	l.log(EventTypeAuction, newAuctionRecord(ao))
+}
+
+func (l *RotatingFileLogger) LogVideoObject(vo *analytics.VideoObject) {
+	if vo == nil {
+		return
+	}
+	l.log(EventTypeVideo, newVideoRecord(vo))
+}
+
+func (l *RotatingFileLogger) LogSetUIDObject(so *analytics.SetUIDObject) {
+	if so == nil {
+		return
+	}
+	l.log(EventTypeSetUID, newSetUIDRecord(so))
+}
+
+func (l *RotatingFileLogger) LogCookieSyncObject(cso *analytics.CookieSyncObject) {
+	if cso == nil {
+		return
+	}
+	l.log(EventTypeCookieSync, newCookieSyncRecord(cso))
+}
+
+func (l *RotatingFileLogger) LogAmpObject(ao *analytics.AmpObject) {
+	if ao == nil {
+		return
+	}
+	l.log(EventTypeAmp, newAmpRecord(ao))
+}
+
+func (l *RotatingFileLogger) LogNotificationEventObject(ne *analytics.NotificationEvent) {
+	if ne == nil {
+		return
+	}
+	l.log(EventTypeNotification, newNotificationRecord(ne))
+}
+
+// Shutdown writes the queued events and closes the files. Events logged afterwards are lost.
+func (l *RotatingFileLogger) Shutdown() {
+	l.closedMutex.Lock()
+	if l.closed {
+		l.closedMutex.Unlock()
+		return
+	}
+	l.closed = true
+	for _, stream := range l.streams {
+		close(stream.queue)
+	}
+	l.closedMutex.Unlock()
+
+	glog.Info("[FileLogger] Shutdown, writing the queued events")
+	l.wg.Wait()
+}
+
+func bidRequest(rw *openrtb_ext.RequestWrapper) *openrtb2.BidRequest {
+	if rw == nil {
+		return nil
+	}
+	return rw.BidRequest
+}
+
+func accountID(account *config.Account) string {
+	if account == nil {
+		return ""
+	}
+	return account.ID
+}
+
+func errorStrings(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
@@ -0,0 +1,109 @@
+package filesystem
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/metrics"
+	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRotatingFileLoggerErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  config.FileLogs
+	}{
+		{
+			name: "invalid-max-size",
+			cfg:  config.FileLogs{Directory: t.TempDir(), QueueSize: 1, Rotation: config.FileLogsRotation{MaxSize: "huge"}},
+		},
+		{
+			name: "invalid-max-age",
+			cfg:  config.FileLogs{Directory: t.TempDir(), QueueSize: 1, Rotation: config.FileLogsRotation{MaxAge: "daily"}},
+		},
+		{
+			name: "no-queue",
+			cfg:  config.FileLogs{Directory: t.TempDir()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRotatingFileLogger(tc.cfg, clock.NewMock(), &metricsConfig.NilMetricsEngine{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRotatingFileLoggerStreams(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "analytics")
+	clockMock := clock.NewMock()
+	clockMock.Set(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	cfg := config.FileLogs{Directory: dir, QueueSize: 10}
+	logger, err := NewRotatingFileLogger(cfg, clockMock, &metricsConfig.NilMetricsEngine{})
+	require.NoError(t, err)
+
+	logger.LogAuctionObject(&analytics.AuctionObject{Status: http.StatusOK, Account: &config.Account{ID: "1001"}, Errors: []error{errors.New("warning")}})
+	logger.LogAmpObject(&analytics.AmpObject{Status: http.StatusOK, Origin: "https://example.com", ModuleResults: []*analytics.ModuleResult{
+		{Module: "prebid.rules", Hook: "rules", Stage: "processed_auction_request", Status: "success", Action: "update", Activity: "apply_rules", ActivityStatus: "success"},
+	}})
+	logger.LogVideoObject(&analytics.VideoObject{Status: http.StatusOK})
+	logger.LogCookieSyncObject(&analytics.CookieSyncObject{Status: http.StatusOK})
+	logger.LogSetUIDObject(&analytics.SetUIDObject{Status: http.StatusOK, Bidder: "appnexus", UID: "uid", Success: true})
+	logger.LogNotificationEventObject(&analytics.NotificationEvent{Request: &analytics.EventRequest{BidID: "bid"}, Account: &config.Account{ID: "1001"}})
+	logger.Shutdown()
+
+	expected := map[string]string{
+		"auction.log":     `{"schema_version":1,"type":"auction","timestamp":"2024-03-01T10:00:00Z","data":{"status":200,"errors":["warning"],"account_id":"1001","start_time":"0001-01-01T00:00:00Z"}}`,
+		"amp.log":         `{"schema_version":1,"type":"amp","timestamp":"2024-03-01T10:00:00Z","data":{"status":200,"origin":"https://example.com","start_time":"0001-01-01T00:00:00Z","module_results":[{"module":"prebid.rules","hook":"rules","stage":"processed_auction_request","status":"success","action":"update","activity":"apply_rules","activity_status":"success"}]}}`,
+		"video.log":       `{"schema_version":1,"type":"video","timestamp":"2024-03-01T10:00:00Z","data":{"status":200,"start_time":"0001-01-01T00:00:00Z"}}`,
+		"cookie_sync.log": `{"schema_version":1,"type":"cookie_sync","timestamp":"2024-03-01T10:00:00Z","data":{"status":200}}`,
+		"setuid.log":      `{"schema_version":1,"type":"setuid","timestamp":"2024-03-01T10:00:00Z","data":{"status":200,"bidder":"appnexus","uid":"uid","success":true}}`,
+		"event.log":       `{"schema_version":1,"type":"event","timestamp":"2024-03-01T10:00:00Z","data":{"account_id":"1001","request":{"bidid":"bid"}}}`,
+	}
+	for file, line := range expected {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err, file)
+		assert.JSONEq(t, line, strings.TrimSpace(string(content)), file)
+	}
+}
+
+func TestRotatingFileLoggerDropsWhenQueueIsFull(t *testing.T) {
+	metricsEngine := &metrics.MetricsEngineMock{}
+	metricsEngine.On("RecordAnalyticsEventDropped", "filelogger", "auction").Return()
+
+	stream := &eventStream{queue: make(chan []byte, 1)}
+	logger := &RotatingFileLogger{
+		streams:       map[EventType]*eventStream{EventTypeAuction: stream},
+		clock:         clock.NewMock(),
+		metricsEngine: metricsEngine,
+	}
+
+	logger.LogAuctionObject(&analytics.AuctionObject{Status: http.StatusOK})
+	logger.LogAuctionObject(&analytics.AuctionObject{Status: http.StatusOK})
+
+	assert.Len(t, stream.queue, 1)
+	metricsEngine.AssertNumberOfCalls(t, "RecordAnalyticsEventDropped", 1)
+}
+
+func TestRotatingFileLoggerIgnoresEventsAfterShutdown(t *testing.T) {
+	logger, err := NewRotatingFileLogger(config.FileLogs{Directory: t.TempDir(), QueueSize: 1}, clock.NewMock(), &metricsConfig.NilMetricsEngine{})
+	require.NoError(t, err)
+
+	logger.Shutdown()
+	assert.NotPanics(t, func() {
+		logger.LogAuctionObject(&analytics.AuctionObject{Status: http.StatusOK})
+		logger.Shutdown()
+	})
+}
@@ -0,0 +1,187 @@
+package filesystem
+
+import (
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+)
+
+// SchemaVersion is the version of the records written by the rotating file logger. It's incremented on every
+// change breaking the consumers of the files, adding a field isn't one.
+const SchemaVersion = 1
+
+// EventType names the stream, and therefore the file, an event is written to
+type EventType string
+
+const (
+	EventTypeAuction      EventType = "auction"
+	EventTypeAmp          EventType = "amp"
+	EventTypeVideo        EventType = "video"
+	EventTypeCookieSync   EventType = "cookie_sync"
+	EventTypeSetUID       EventType = "setuid"
+	EventTypeNotification EventType = "event"
+)
+
+var eventTypes = []EventType{
+	EventTypeAuction,
+	EventTypeAmp,
+	EventTypeVideo,
+	EventTypeCookieSync,
+	EventTypeSetUID,
+	EventTypeNotification,
+}
+
+// Record is a line of the rotating file logs, see README.md for the documented schema
+type Record struct {
+	SchemaVersion int         `json:"schema_version"`
+	Type          EventType   `json:"type"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Data          interface{} `json:"data"`
+}
+
+// AuctionRecord is the data of the auction records
+type AuctionRecord struct {
+	Status               int                          `json:"status"`
+	Errors               []string                     `json:"errors,omitempty"`
+	AccountID            string                       `json:"account_id,omitempty"`
+	Request              *openrtb2.BidRequest         `json:"request,omitempty"`
+	Response             *openrtb2.BidResponse        `json:"response,omitempty"`
+	SeatNonBid           []openrtb_ext.SeatNonBid     `json:"seat_non_bid,omitempty"`
+	BidderCalls          []*analytics.BidderCall      `json:"bidder_calls,omitempty"`
+	StartTime            time.Time                    `json:"start_time"`
+	HookExecutionOutcome []hookexecution.StageOutcome `json:"hook_execution_outcome,omitempty"`
+	ModuleResults        []*analytics.ModuleResult    `json:"module_results,omitempty"`
+}
+
+// AmpRecord is the data of the amp records
+type AmpRecord struct {
+	Status               int                          `json:"status"`
+	Errors               []string                     `json:"errors,omitempty"`
+	AccountID            string                       `json:"account_id,omitempty"`
+	Request              *openrtb2.BidRequest         `json:"request,omitempty"`
+	Response             *openrtb2.BidResponse        `json:"response,omitempty"`
+	SeatNonBid           []openrtb_ext.SeatNonBid     `json:"seat_non_bid,omitempty"`
+	BidderCalls          []*analytics.BidderCall      `json:"bidder_calls,omitempty"`
+	Targeting            map[string]string            `json:"targeting,omitempty"`
+	Origin               string                       `json:"origin,omitempty"`
+	StartTime            time.Time                    `json:"start_time"`
+	HookExecutionOutcome []hookexecution.StageOutcome `json:"hook_execution_outcome,omitempty"`
+	ModuleResults        []*analytics.ModuleResult    `json:"module_results,omitempty"`
+}
+
+// VideoRecord is the data of the video records
+type VideoRecord struct {
+	Status               int                           `json:"status"`
+	Errors               []string                      `json:"errors,omitempty"`
+	AccountID            string                        `json:"account_id,omitempty"`
+	Request              *openrtb2.BidRequest          `json:"request,omitempty"`
+	Response             *openrtb2.BidResponse         `json:"response,omitempty"`
+	SeatNonBid           []openrtb_ext.SeatNonBid      `json:"seat_non_bid,omitempty"`
+	BidderCalls          []*analytics.BidderCall       `json:"bidder_calls,omitempty"`
+	VideoRequest         *openrtb_ext.BidRequestVideo  `json:"video_request,omitempty"`
+	VideoResponse        *openrtb_ext.BidResponseVideo `json:"video_response,omitempty"`
+	StartTime            time.Time                     `json:"start_time"`
+	HookExecutionOutcome []hookexecution.StageOutcome  `json:"hook_execution_outcome,omitempty"`
+	ModuleResults        []*analytics.ModuleResult     `json:"module_results,omitempty"`
+}
+
+// CookieSyncRecord is the data of the cookie_sync records
+type CookieSyncRecord struct {
+	Status       int                           `json:"status"`
+	Errors       []string                      `json:"errors,omitempty"`
+	BidderStatus []*analytics.CookieSyncBidder `json:"bidder_status,omitempty"`
+}
+
+// SetUIDRecord is the data of the setuid records
+type SetUIDRecord struct {
+	Status  int      `json:"status"`
+	Errors  []string `json:"errors,omitempty"`
+	Bidder  string   `json:"bidder"`
+	UID     string   `json:"uid"`
+	Success bool     `json:"success"`
+}
+
+// NotificationRecord is the data of the event records
+type NotificationRecord struct {
+	AccountID string                  `json:"account_id,omitempty"`
+	Request   *analytics.EventRequest `json:"request"`
+	Auction   *analytics.AuctionBid   `json:"auction,omitempty"`
+}
+
+func newAuctionRecord(ao *analytics.AuctionObject) *AuctionRecord {
+	return &AuctionRecord{
+		Status:               ao.Status,
+		Errors:               errorStrings(ao.Errors),
+		AccountID:            accountID(ao.Account),
+		Request:              bidRequest(ao.RequestWrapper),
+		Response:             ao.Response,
+		SeatNonBid:           ao.SeatNonBid,
+		BidderCalls:          ao.BidderCalls,
+		StartTime:            ao.StartTime,
+		HookExecutionOutcome: ao.HookExecutionOutcome,
+		ModuleResults:        ao.ModuleResults,
+	}
+}
+
+func newAmpRecord(ao *analytics.AmpObject) *AmpRecord {
+	return &AmpRecord{
+		Status:               ao.Status,
+		Errors:               errorStrings(ao.Errors),
+		AccountID:            accountID(ao.Account),
+		Request:              bidRequest(ao.RequestWrapper),
+		Response:             ao.AuctionResponse,
+		SeatNonBid:           ao.SeatNonBid,
+		BidderCalls:          ao.BidderCalls,
+		Targeting:            ao.AmpTargetingValues,
+		Origin:               ao.Origin,
+		StartTime:            ao.StartTime,
+		HookExecutionOutcome: ao.HookExecutionOutcome,
+		ModuleResults:        ao.ModuleResults,
+	}
+}
+
+func newVideoRecord(vo *analytics.VideoObject) *VideoRecord {
+	return &VideoRecord{
+		Status:               vo.Status,
+		Errors:               errorStrings(vo.Errors),
+		AccountID:            accountID(vo.Account),
+		Request:              bidRequest(vo.RequestWrapper),
+		Response:             vo.Response,
+		SeatNonBid:           vo.SeatNonBid,
+		BidderCalls:          vo.BidderCalls,
+		VideoRequest:         vo.VideoRequest,
+		VideoResponse:        vo.VideoResponse,
+		StartTime:            vo.StartTime,
+		HookExecutionOutcome: vo.HookExecutionOutcome,
+		ModuleResults:        vo.ModuleResults,
+	}
+}
+
+func newCookieSyncRecord(cso *analytics.CookieSyncObject) *CookieSyncRecord {
+	return &CookieSyncRecord{
+		Status:       cso.Status,
+		Errors:       errorStrings(cso.Errors),
+		BidderStatus: cso.BidderStatus,
+	}
+}
+
+func newSetUIDRecord(so *analytics.SetUIDObject) *SetUIDRecord {
+	return &SetUIDRecord{
+		Status:  so.Status,
+		Errors:  errorStrings(so.Errors),
+		Bidder:  so.Bidder,
+		UID:     so.UID,
+		Success: so.Success,
+	}
+}
+
+func newNotificationRecord(ne *analytics.NotificationEvent) *NotificationRecord {
+	return &NotificationRecord{
+		AccountID: accountID(ne.Account),
+		Request:   ne.Request,
+		Auction:   ne.Auction,
+	}
+}
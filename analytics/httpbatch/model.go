@@ -0,0 +1,221 @@
+package httpbatch
+
+import (
+	"strings"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+type EventType string
+
+const (
+	EventTypeAuction      EventType = "auction"
+	EventTypeAmp          EventType = "amp"
+	EventTypeVideo        EventType = "video"
+	EventTypeCookieSync   EventType = "cookie_sync"
+	EventTypeSetUID       EventType = "setuid"
+	EventTypeNotification EventType = "event"
+)
+
+var eventTypes = map[EventType]struct{}{
+	EventTypeAuction:      {},
+	EventTypeAmp:          {},
+	EventTypeVideo:        {},
+	EventTypeCookieSync:   {},
+	EventTypeSetUID:       {},
+	EventTypeNotification: {},
+}
+
+// logEvent is the envelope of every event posted to the endpoint
+type logEvent struct {
+	Type      EventType   `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+type logAuction struct {
+	Status               int                          `json:"status"`
+	Errors               []string                     `json:"errors,omitempty"`
+	AccountID            string                       `json:"accountId,omitempty"`
+	Request              *openrtb2.BidRequest         `json:"request,omitempty"`
+	Response             *openrtb2.BidResponse        `json:"response,omitempty"`
+	SeatNonBid           []openrtb_ext.SeatNonBid     `json:"seatNonBid,omitempty"`
+	BidderCalls          []*analytics.BidderCall      `json:"bidderCalls,omitempty"`
+	StartTime            time.Time                    `json:"startTime"`
+	HookExecutionOutcome []hookexecution.StageOutcome `json:"hookExecutionOutcome,omitempty"`
+}
+
+type logAmp struct {
+	Status               int                          `json:"status"`
+	Errors               []string                     `json:"errors,omitempty"`
+	Request              *openrtb2.BidRequest         `json:"request,omitempty"`
+	Response             *openrtb2.BidResponse        `json:"response,omitempty"`
+	SeatNonBid           []openrtb_ext.SeatNonBid     `json:"seatNonBid,omitempty"`
+	BidderCalls          []*analytics.BidderCall      `json:"bidderCalls,omitempty"`
+	AmpTargetingValues   map[string]string            `json:"targeting,omitempty"`
+	Origin               string                       `json:"origin,omitempty"`
+	StartTime            time.Time                    `json:"startTime"`
+	HookExecutionOutcome []hookexecution.StageOutcome `json:"hookExecutionOutcome,omitempty"`
+}
+
+type logVideo struct {
+	Status               int                           `json:"status"`
+	Errors               []string                      `json:"errors,omitempty"`
+	Request              *openrtb2.BidRequest          `json:"request,omitempty"`
+	Response             *openrtb2.BidResponse         `json:"response,omitempty"`
+	SeatNonBid           []openrtb_ext.SeatNonBid      `json:"seatNonBid,omitempty"`
+	BidderCalls          []*analytics.BidderCall       `json:"bidderCalls,omitempty"`
+	VideoRequest         *openrtb_ext.BidRequestVideo  `json:"videoRequest,omitempty"`
+	VideoResponse        *openrtb_ext.BidResponseVideo `json:"videoResponse,omitempty"`
+	StartTime            time.Time                     `json:"startTime"`
+	HookExecutionOutcome []hookexecution.StageOutcome  `json:"hookExecutionOutcome,omitempty"`
+}
+
+type logCookieSync struct {
+	Status       int                           `json:"status"`
+	Errors       []string                      `json:"errors,omitempty"`
+	BidderStatus []*analytics.CookieSyncBidder `json:"bidderStatus,omitempty"`
+}
+
+type logSetUID struct {
+	Status  int      `json:"status"`
+	Errors  []string `json:"errors,omitempty"`
+	Bidder  string   `json:"bidder"`
+	UID     string   `json:"uid"`
+	Success bool     `json:"success"`
+}
+
+type logNotification struct {
+	Request   *analytics.EventRequest `json:"request"`
+	AccountID string                  `json:"accountId,omitempty"`
+	Auction   *analytics.AuctionBid   `json:"auction,omitempty"`
+}
+
+func newLogAuction(ao *analytics.AuctionObject) *logAuction {
+	l := &logAuction{
+		Status:               ao.Status,
+		Errors:               errorsToStrings(ao.Errors),
+		Request:              bidRequest(ao.RequestWrapper),
+		Response:             ao.Response,
+		SeatNonBid:           ao.SeatNonBid,
+		BidderCalls:          ao.BidderCalls,
+		StartTime:            ao.StartTime,
+		HookExecutionOutcome: ao.HookExecutionOutcome,
+	}
+	if ao.Account != nil {
+		l.AccountID = ao.Account.ID
+	}
+	return l
+}
+
+func newLogAmp(ao *analytics.AmpObject) *logAmp {
+	return &logAmp{
+		Status:               ao.Status,
+		Errors:               errorsToStrings(ao.Errors),
+		Request:              bidRequest(ao.RequestWrapper),
+		Response:             ao.AuctionResponse,
+		SeatNonBid:           ao.SeatNonBid,
+		BidderCalls:          ao.BidderCalls,
+		AmpTargetingValues:   ao.AmpTargetingValues,
+		Origin:               ao.Origin,
+		StartTime:            ao.StartTime,
+		HookExecutionOutcome: ao.HookExecutionOutcome,
+	}
+}
+
+func newLogVideo(vo *analytics.VideoObject) *logVideo {
+	return &logVideo{
+		Status:               vo.Status,
+		Errors:               errorsToStrings(vo.Errors),
+		Request:              bidRequest(vo.RequestWrapper),
+		Response:             vo.Response,
+		SeatNonBid:           vo.SeatNonBid,
+		BidderCalls:          vo.BidderCalls,
+		VideoRequest:         vo.VideoRequest,
+		VideoResponse:        vo.VideoResponse,
+		StartTime:            vo.StartTime,
+		HookExecutionOutcome: vo.HookExecutionOutcome,
+	}
+}
+
+func newLogCookieSync(cso *analytics.CookieSyncObject) *logCookieSync {
+	return &logCookieSync{
+		Status:       cso.Status,
+		Errors:       errorsToStrings(cso.Errors),
+		BidderStatus: cso.BidderStatus,
+	}
+}
+
+func newLogSetUID(so *analytics.SetUIDObject) *logSetUID {
+	return &logSetUID{
+		Status:  so.Status,
+		Errors:  errorsToStrings(so.Errors),
+		Bidder:  so.Bidder,
+		UID:     so.UID,
+		Success: so.Success,
+	}
+}
+
+func newLogNotification(ne *analytics.NotificationEvent) *logNotification {
+	l := &logNotification{
+		Request: ne.Request,
+		Auction: ne.Auction,
+	}
+	if ne.Account != nil {
+		l.AccountID = ne.Account.ID
+	}
+	return l
+}
+
+// serializeEvent marshals the event and removes the excluded fields from its data
+func serializeEvent(eventType EventType, timestamp time.Time, data interface{}, excludeFields [][]string) ([]byte, error) {
+	b, err := jsonutil.Marshal(&logEvent{
+		Type:      eventType,
+		Timestamp: timestamp,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range excludeFields {
+		b = jsonparser.Delete(b, path...)
+	}
+	return b, nil
+}
+
+// parseExcludeFields splits the dot separated paths and roots them to the event data
+func parseExcludeFields(fields []string) [][]string {
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		if field == "" {
+			continue
+		}
+		paths = append(paths, append([]string{"data"}, strings.Split(field, ".")...))
+	}
+	return paths
+}
+
+func bidRequest(rw *openrtb_ext.RequestWrapper) *openrtb2.BidRequest {
+	if rw == nil {
+		return nil
+	}
+	return rw.BidRequest
+}
+
+func errorsToStrings(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
@@ -0,0 +1,307 @@
+package httpbatch
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/docker/go-units"
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/metrics"
+)
+
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// moduleName is the name the dropped events are reported with
+const moduleName = "httpbatch"
+
+type HTTPBatchLogger struct {
+	sender            httpSender
+	clock             clock.Clock
+	randFloat         func() float64
+	format            string
+	sampleRates       map[EventType]float64
+	excludeFields     [][]string
+	eventCount        int64
+	maxEventCount     int64
+	maxBufferByteSize int64
+	maxDuration       time.Duration
+	mux               sync.RWMutex
+	sigTermCh         chan os.Signal
+	buffer            bytes.Buffer
+	bufferCh          chan bufferedEvent
+	// eventTypeCounts counts the buffered events per type, to report them when their batch is dropped
+	eventTypeCounts map[EventType]int
+	// batches queues the batches for the single goroutine sending them, they're dropped when it's full
+	batches       chan batch
+	metricsEngine metrics.MetricsEngine
+}
+
+type bufferedEvent struct {
+	eventType EventType
+	data      []byte
+}
+
+type batch struct {
+	payload         []byte
+	eventTypeCounts map[EventType]int
+}
+
+func newHTTPBatchLogger(cfg config.HTTPBatchAnalytics, sender httpSender, clock clock.Clock, randFloat func() float64, metricsEngine metrics.MetricsEngine) (*HTTPBatchLogger, error) {
+	pSize, err := units.FromHumanSize(cfg.Buffers.BufferSize)
+	if err != nil {
+		return nil, err
+	}
+	pDuration, err := time.ParseDuration(cfg.Buffers.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Buffers.MaxPendingBatches < 0 {
+		return nil, fmt.Errorf("max pending batches must be >= 0, got %d", cfg.Buffers.MaxPendingBatches)
+	}
+	if cfg.Format != FormatJSON && cfg.Format != FormatNDJSON {
+		return nil, fmt.Errorf("unsupported format %q, expected %q or %q", cfg.Format, FormatJSON, FormatNDJSON)
+	}
+
+	sampleRates := make(map[EventType]float64, len(cfg.SampleRates))
+	for eventType, rate := range cfg.SampleRates {
+		if _, ok := eventTypes[EventType(eventType)]; !ok {
+			return nil, fmt.Errorf("unknown event type %q in sample rates", eventType)
+		}
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("sample rate of %q must be in the range [0, 1], got %v", eventType, rate)
+		}
+		sampleRates[EventType(eventType)] = rate
+	}
+
+	l := &HTTPBatchLogger{
+		sender:            sender,
+		clock:             clock,
+		randFloat:         randFloat,
+		format:            cfg.Format,
+		sampleRates:       sampleRates,
+		excludeFields:     parseExcludeFields(cfg.ExcludeFields),
+		maxBufferByteSize: pSize,
+		maxEventCount:     int64(cfg.Buffers.EventCount),
+		maxDuration:       pDuration,
+		bufferCh:          make(chan bufferedEvent),
+		sigTermCh:         make(chan os.Signal, 1),
+		batches:           make(chan batch, cfg.Buffers.MaxPendingBatches),
+		metricsEngine:     metricsEngine,
+	}
+	l.reset()
+	return l, nil
+}
+
+func NewModule(httpClient *http.Client, cfg config.HTTPBatchAnalytics, clock clock.Clock, metricsEngine metrics.MetricsEngine) (analytics.Module, error) {
+	retry, err := newRetryPolicy(cfg.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := createHttpSender(httpClient, cfg.Endpoint, contentType(cfg.Format), retry, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := newHTTPBatchLogger(cfg, sender, clock, rand.Float64, metricsEngine)
+	if err != nil {
+		return nil, err
+	}
+
+	signal.Notify(m.sigTermCh, os.Interrupt, syscall.SIGTERM)
+
+	go m.start()
+
+	return m, nil
+}
+
+func contentType(format string) string {
+	if format == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "application/json"
+}
+
+func (l *HTTPBatchLogger) start() {
+	go l.sendBatches()
+
+	ticker := l.clock.Ticker(l.maxDuration)
+	for {
+		select {
+		case <-l.sigTermCh:
+			glog.Infof("[HTTPBatchAnalytics] Received Close, trying to flush buffer")
+			l.flush()
+			return
+		case event := <-l.bufferCh:
+			l.bufferEvent(event.eventType, event.data)
+			if l.isFull() {
+				l.flush()
+			}
+		case <-ticker.C:
+			l.flush()
+		}
+	}
+}
+
+// sendBatches sends the batches one at a time, retries included, so an unreachable endpoint holds at most the
+// pending batches in memory
+func (l *HTTPBatchLogger) sendBatches() {
+	for b := range l.batches {
+		l.sender(b.payload)
+	}
+}
+
+func (l *HTTPBatchLogger) bufferEvent(eventType EventType, data []byte) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.eventCount > 0 && l.format == FormatJSON {
+		l.buffer.WriteByte(',')
+	}
+	l.buffer.Write(data)
+	if l.format == FormatNDJSON {
+		l.buffer.WriteByte('\n')
+	}
+	l.eventCount++
+	l.eventTypeCounts[eventType]++
+}
+
+func (l *HTTPBatchLogger) isFull() bool {
+	l.mux.RLock()
+	defer l.mux.RUnlock()
+	return l.eventCount >= l.maxEventCount || int64(l.buffer.Len()) >= l.maxBufferByteSize
+}
+
+func (l *HTTPBatchLogger) flush() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	b, ok := l.batch()
+	if !ok {
+		return
+	}
+
+	select {
+	case l.batches <- b:
+	default:
+		glog.Warningf("[HTTPBatchAnalytics] Dropping a batch, %d batches are already waiting to be sent", len(l.batches))
+		for eventType, count := range b.eventTypeCounts {
+			for i := 0; i < count; i++ {
+				l.metricsEngine.RecordAnalyticsEventDropped(moduleName, string(eventType))
+			}
+		}
+	}
+}
+
+// batch returns a copy of the buffered events ready to be sent and resets the buffer, or false when
+// there's nothing to send. It must be called with the write lock held.
+func (l *HTTPBatchLogger) batch() (batch, bool) {
+	if l.eventCount == 0 {
+		return batch{}, false
+	}
+
+	if l.format == FormatJSON {
+		l.buffer.WriteByte(']')
+	}
+	b := batch{payload: bytes.Clone(l.buffer.Bytes()), eventTypeCounts: l.eventTypeCounts}
+	l.reset()
+	return b, true
+}
+
+func (l *HTTPBatchLogger) reset() {
+	l.buffer.Reset()
+	if l.format == FormatJSON {
+		l.buffer.WriteByte('[')
+	}
+	l.eventCount = 0
+	l.eventTypeCounts = make(map[EventType]int)
+}
+
+// shouldSample returns whether an event of the given type is kept according to its configured sample rate
+func (l *HTTPBatchLogger) shouldSample(eventType EventType) bool {
+	rate, ok := l.sampleRates[eventType]
+	if !ok {
+		return true
+	}
+	return l.randFloat() < rate
+}
+
+func (l *HTTPBatchLogger) logEvent(eventType EventType, data interface{}) {
+	if !l.shouldSample(eventType) {
+		return
+	}
+	serialized, err := serializeEvent(eventType, l.clock.Now().UTC(), data, l.excludeFields)
+	if err != nil {
+		glog.Errorf("[HTTPBatchAnalytics] Error serializing %s event: %v", eventType, err)
+		return
+	}
+	l.bufferCh <- bufferedEvent{eventType: eventType, data: serialized}
+}
+
+func (l *HTTPBatchLogger) LogAuctionObject(event *analytics.AuctionObject) {
+	if event == nil {
+		return
+	}
+	l.logEvent(EventTypeAuction, newLogAuction(event))
+}
+
+func (l *HTTPBatchLogger) LogAmpObject(event *analytics.AmpObject) {
+	if event == nil {
+		return
+	}
+	l.logEvent(EventTypeAmp, newLogAmp(event))
+}
+
+func (l *HTTPBatchLogger) LogVideoObject(event *analytics.VideoObject) {
+	if event == nil {
+		return
+	}
+	l.logEvent(EventTypeVideo, newLogVideo(event))
+}
+
+func (l *HTTPBatchLogger) LogCookieSyncObject(event *analytics.CookieSyncObject) {
+	if event == nil {
+		return
+	}
+	l.logEvent(EventTypeCookieSync, newLogCookieSync(event))
+}
+
+func (l *HTTPBatchLogger) LogSetUIDObject(event *analytics.SetUIDObject) {
+	if event == nil {
+		return
+	}
+	l.logEvent(EventTypeSetUID, newLogSetUID(event))
+}
+
+func (l *HTTPBatchLogger) LogNotificationEventObject(event *analytics.NotificationEvent) {
+	if event == nil {
+		return
+	}
+	l.logEvent(EventTypeNotification, newLogNotification(event))
+}
+
+// Shutdown sends the buffered events synchronously so they aren't lost when the process exits
+func (l *HTTPBatchLogger) Shutdown() {
+	glog.Info("[HTTPBatchAnalytics] Shutdown, trying to flush buffer")
+
+	l.mux.Lock()
+	b, ok := l.batch()
+	l.mux.Unlock()
+
+	if ok {
+		l.sender(b.payload)
+	}
+}
@@ -0,0 +1,280 @@
+package httpbatch
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/metrics"
+	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig(format string) config.HTTPBatchAnalytics {
+	return config.HTTPBatchAnalytics{
+		Enabled: true,
+		Endpoint: config.HTTPBatchAnalyticsEndpoint{
+			Url:     "http://localhost:8000/event",
+			Timeout: "1s",
+		},
+		Format: format,
+		Buffers: config.HTTPBatchAnalyticsBuffer{
+			EventCount:        2,
+			BufferSize:        "1MB",
+			Timeout:           "15m",
+			MaxPendingBatches: 10,
+		},
+	}
+}
+
+// channelSender returns a sender publishing the payloads to a channel so the tests can wait for them
+func channelSender() (httpSender, chan []byte) {
+	ch := make(chan []byte, 10)
+	return func(payload []byte) error {
+		ch <- payload
+		return nil
+	}, ch
+}
+
+func receive(t *testing.T, ch chan []byte) []byte {
+	select {
+	case payload := <-ch:
+		return payload
+	case <-time.After(time.Second):
+		t.Fatal("no payload was sent")
+		return nil
+	}
+}
+
+func alwaysSample() float64 {
+	return 0
+}
+
+func TestConfigParsingError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config func(cfg *config.HTTPBatchAnalytics)
+	}{
+		{
+			name:   "invalid-url",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.Endpoint.Url = "%%2815197306101420000%29" },
+		},
+		{
+			name:   "invalid-timeout",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.Endpoint.Timeout = "1x" },
+		},
+		{
+			name:   "invalid-buffer-size",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.Buffers.BufferSize = "big" },
+		},
+		{
+			name:   "invalid-format",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.Format = "xml" },
+		},
+		{
+			name:   "invalid-backoff",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.Retry.Backoff = "soon" },
+		},
+		{
+			name:   "negative-max-pending-batches",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.Buffers.MaxPendingBatches = -1 },
+		},
+		{
+			name:   "unknown-sampled-event-type",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.SampleRates = map[string]float64{"bid": 0.5} },
+		},
+		{
+			name:   "sample-rate-out-of-range",
+			config: func(cfg *config.HTTPBatchAnalytics) { cfg.SampleRates = map[string]float64{"auction": 1.5} },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := validConfig(FormatNDJSON)
+			tc.config(&cfg)
+			_, err := NewModule(&http.Client{}, cfg, clock.NewMock(), &metricsConfig.NilMetricsEngine{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPayloadFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, payload []byte)
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			check: func(t *testing.T, payload []byte) {
+				var events []logEvent
+				require.NoError(t, jsonutil.UnmarshalValid(payload, &events))
+				require.Len(t, events, 2)
+				assert.Equal(t, EventTypeAuction, events[0].Type)
+				assert.Equal(t, EventTypeSetUID, events[1].Type)
+			},
+		},
+		{
+			name:   "ndjson",
+			format: FormatNDJSON,
+			check: func(t *testing.T, payload []byte) {
+				var types []EventType
+				scanner := bufio.NewScanner(bytes.NewReader(payload))
+				for scanner.Scan() {
+					var event logEvent
+					require.NoError(t, jsonutil.UnmarshalValid(scanner.Bytes(), &event))
+					types = append(types, event.Type)
+				}
+				assert.Equal(t, []EventType{EventTypeAuction, EventTypeSetUID}, types)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sender, sent := channelSender()
+			logger, err := newHTTPBatchLogger(validConfig(tc.format), sender, clock.NewMock(), alwaysSample, &metricsConfig.NilMetricsEngine{})
+			require.NoError(t, err)
+			go logger.start()
+
+			logger.LogAuctionObject(&analytics.AuctionObject{Status: http.StatusOK})
+			logger.LogSetUIDObject(&analytics.SetUIDObject{Status: http.StatusOK, Bidder: "appnexus"})
+
+			tc.check(t, receive(t, sent))
+		})
+	}
+}
+
+func TestBufferTime(t *testing.T) {
+	cfg := validConfig(FormatJSON)
+	cfg.Buffers.EventCount = 100
+
+	sender, sent := channelSender()
+	clockMock := clock.NewMock()
+	logger, err := newHTTPBatchLogger(cfg, sender, clockMock, alwaysSample, &metricsConfig.NilMetricsEngine{})
+	require.NoError(t, err)
+	go logger.start()
+
+	logger.LogCookieSyncObject(&analytics.CookieSyncObject{Status: http.StatusOK})
+	logger.LogNotificationEventObject(&analytics.NotificationEvent{Request: &analytics.EventRequest{BidID: "bid"}})
+	clockMock.Add(16 * time.Minute)
+
+	var events []logEvent
+	require.NoError(t, jsonutil.UnmarshalValid(receive(t, sent), &events))
+	assert.Len(t, events, 2)
+}
+
+func TestSampling(t *testing.T) {
+	cfg := validConfig(FormatNDJSON)
+	cfg.Buffers.EventCount = 1
+	cfg.SampleRates = map[string]float64{"auction": 0.5, "amp": 0}
+
+	sender, sent := channelSender()
+	random := 0.7
+	logger, err := newHTTPBatchLogger(cfg, sender, clock.NewMock(), func() float64 { return random }, &metricsConfig.NilMetricsEngine{})
+	require.NoError(t, err)
+
+	assert.False(t, logger.shouldSample(EventTypeAuction), "auction above the sample rate")
+	assert.False(t, logger.shouldSample(EventTypeAmp), "amp is never sampled")
+	assert.True(t, logger.shouldSample(EventTypeVideo), "video has no sample rate")
+
+	random = 0.2
+	assert.True(t, logger.shouldSample(EventTypeAuction), "auction below the sample rate")
+	assert.False(t, logger.shouldSample(EventTypeAmp), "amp is never sampled")
+
+	go logger.start()
+	logger.LogAmpObject(&analytics.AmpObject{Status: http.StatusOK})
+	logger.LogVideoObject(&analytics.VideoObject{Status: http.StatusOK})
+
+	var event logEvent
+	require.NoError(t, jsonutil.UnmarshalValid(bytes.TrimSpace(receive(t, sent)), &event))
+	assert.Equal(t, EventTypeVideo, event.Type)
+}
+
+func TestExcludeFields(t *testing.T) {
+	ao := &analytics.AuctionObject{
+		Status:  http.StatusOK,
+		Account: &config.Account{ID: "1001"},
+		RequestWrapper: &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{
+				ID:     "req",
+				User:   &openrtb2.User{ID: "user"},
+				Device: &openrtb2.Device{IP: "1.2.3.4", UA: "ua"},
+			},
+		},
+	}
+	timestamp := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	data, err := serializeEvent(EventTypeAuction, timestamp, newLogAuction(ao), parseExcludeFields([]string{"request.user", "request.device.ip", "", "missing.field"}))
+	require.NoError(t, err)
+
+	expected := `{"type":"auction","timestamp":"2024-03-01T10:00:00Z","data":{"status":200,"accountId":"1001","request":{"id":"req","imp":null,"device":{"ua":"ua"}},"startTime":"0001-01-01T00:00:00Z"}}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestShutdownFlush(t *testing.T) {
+	cfg := validConfig(FormatJSON)
+	cfg.Buffers.EventCount = 100
+
+	var sent []byte
+	logger, err := newHTTPBatchLogger(cfg, func(payload []byte) error {
+		sent = payload
+		return nil
+	}, clock.NewMock(), alwaysSample, &metricsConfig.NilMetricsEngine{})
+	require.NoError(t, err)
+
+	logger.bufferEvent(EventTypeAuction, []byte(`{"type":"auction"}`))
+	logger.bufferEvent(EventTypeAmp, []byte(`{"type":"amp"}`))
+	logger.Shutdown()
+
+	assert.Equal(t, `[{"type":"auction"},{"type":"amp"}]`, string(sent))
+	assert.Zero(t, logger.eventCount)
+	assert.Equal(t, "[", logger.buffer.String())
+}
+
+func TestFlushDropsBatchesWhenSaturated(t *testing.T) {
+	cfg := validConfig(FormatNDJSON)
+	cfg.Buffers.MaxPendingBatches = 1
+
+	sending := make(chan []byte)
+	release := make(chan struct{})
+	metricsEngine := &metrics.MetricsEngineMock{}
+	metricsEngine.On("RecordAnalyticsEventDropped", moduleName, string(EventTypeAuction)).Return().Times(2)
+	metricsEngine.On("RecordAnalyticsEventDropped", moduleName, string(EventTypeAmp)).Return().Once()
+
+	logger, err := newHTTPBatchLogger(cfg, func(payload []byte) error {
+		sending <- payload
+		<-release
+		return nil
+	}, clock.NewMock(), alwaysSample, metricsEngine)
+	require.NoError(t, err)
+	go logger.sendBatches()
+
+	// the first batch is being sent, the second one waits and the third one is dropped
+	logger.bufferEvent(EventTypeSetUID, []byte(`{"type":"setuid"}`))
+	logger.flush()
+	assert.Equal(t, "{\"type\":\"setuid\"}\n", string(<-sending))
+
+	logger.bufferEvent(EventTypeVideo, []byte(`{"type":"video"}`))
+	logger.flush()
+
+	logger.bufferEvent(EventTypeAuction, []byte(`{"type":"auction"}`))
+	logger.bufferEvent(EventTypeAuction, []byte(`{"type":"auction"}`))
+	logger.bufferEvent(EventTypeAmp, []byte(`{"type":"amp"}`))
+	logger.flush()
+
+	close(release)
+	assert.Equal(t, "{\"type\":\"video\"}\n", string(<-sending))
+	metricsEngine.AssertExpectations(t)
+}
@@ -0,0 +1,159 @@
+package httpbatch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/version"
+)
+
+type httpSender = func(payload []byte) error
+
+// retryPolicy controls how many times a batch is sent and how long to wait between attempts
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+// nonRetryableError wraps failures which will fail again if the same payload is sent, such as 4xx responses
+type nonRetryableError struct {
+	err error
+}
+
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func newRetryPolicy(cfg config.HTTPBatchAnalyticsRetry) (retryPolicy, error) {
+	policy := retryPolicy{maxAttempts: cfg.MaxAttempts}
+	if policy.maxAttempts < 1 {
+		policy.maxAttempts = 1
+	}
+
+	var err error
+	if cfg.Backoff != "" {
+		if policy.backoff, err = time.ParseDuration(cfg.Backoff); err != nil {
+			return policy, err
+		}
+	}
+	if cfg.MaxBackoff != "" {
+		if policy.maxBackoff, err = time.ParseDuration(cfg.MaxBackoff); err != nil {
+			return policy, err
+		}
+	}
+	return policy, nil
+}
+
+// delay returns the wait before the given retry, starting at 1 for the first retry
+func (p retryPolicy) delay(retry int) time.Duration {
+	d := p.backoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if p.maxBackoff > 0 && d >= p.maxBackoff {
+			return p.maxBackoff
+		}
+	}
+	if p.maxBackoff > 0 && d > p.maxBackoff {
+		return p.maxBackoff
+	}
+	return d
+}
+
+func compressToGZIP(requestBody []byte) ([]byte, error) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write(requestBody); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func createHttpSender(httpClient *http.Client, endpoint config.HTTPBatchAnalyticsEndpoint, contentType string, retry retryPolicy, clock clock.Clock) (httpSender, error) {
+	if _, err := url.ParseRequestURI(endpoint.Url); err != nil {
+		return nil, err
+	}
+
+	httpTimeout, err := time.ParseDuration(endpoint.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	send := func(requestBody []byte) error {
+		ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(requestBody))
+		if err != nil {
+			return &nonRetryableError{err}
+		}
+
+		for name, value := range endpoint.Headers {
+			req.Header.Set(name, value)
+		}
+		req.Header.Set("X-Prebid", version.BuildXPrebidHeader(version.Ver))
+		req.Header.Set("Content-Type", contentType)
+		if endpoint.Gzip {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		err = fmt.Errorf("wrong code received %d", resp.StatusCode)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return err
+		}
+		return &nonRetryableError{err}
+	}
+
+	return func(payload []byte) error {
+		var err error
+		requestBody := payload
+		if endpoint.Gzip {
+			if requestBody, err = compressToGZIP(payload); err != nil {
+				glog.Errorf("[HTTPBatchAnalytics] Compressing request failed %v", err)
+				return err
+			}
+		}
+
+		for attempt := 1; attempt <= retry.maxAttempts; attempt++ {
+			if attempt > 1 {
+				clock.Sleep(retry.delay(attempt - 1))
+			}
+
+			if err = send(requestBody); err == nil {
+				return nil
+			}
+
+			var nonRetryable *nonRetryableError
+			if errors.As(err, &nonRetryable) {
+				break
+			}
+		}
+
+		glog.Errorf("[HTTPBatchAnalytics] Sending request failed, dropping the batch: %v", err)
+		return err
+	}, nil
+}
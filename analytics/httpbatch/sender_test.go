@@ -0,0 +1,115 @@
+package httpbatch
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHttpSender(t *testing.T) {
+	testCases := []struct {
+		name         string
+		gzip         bool
+		statusCodes  []int
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			statusCodes:  []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "success-gzip",
+			gzip:         true,
+			statusCodes:  []int{http.StatusNoContent},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retry-on-server-error",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			wantAttempts: 3,
+		},
+		{
+			name:         "retries-exhausted",
+			statusCodes:  []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "no-retry-on-client-error",
+			statusCodes:  []int{http.StatusBadRequest},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := []byte(`{"type":"auction"}`)
+			var attempts int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+
+				assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+				assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+				assert.NotEmpty(t, r.Header.Get("X-Prebid"))
+
+				reader := r.Body
+				if tc.gzip {
+					assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+					gz, err := gzip.NewReader(r.Body)
+					require.NoError(t, err)
+					reader = gz
+				}
+				body, err := io.ReadAll(reader)
+				assert.NoError(t, err)
+				assert.Equal(t, payload, body)
+
+				w.WriteHeader(tc.statusCodes[attempt-1])
+			}))
+			defer ts.Close()
+
+			endpoint := config.HTTPBatchAnalyticsEndpoint{
+				Url:     ts.URL,
+				Timeout: "1s",
+				Gzip:    tc.gzip,
+				Headers: map[string]string{"X-Api-Key": "secret"},
+			}
+			retry := retryPolicy{maxAttempts: 3, backoff: time.Millisecond}
+
+			sender, err := createHttpSender(ts.Client(), endpoint, contentType(FormatNDJSON), retry, clock.New())
+			require.NoError(t, err)
+
+			err = sender(payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy, err := newRetryPolicy(config.HTTPBatchAnalyticsRetry{MaxAttempts: 5, Backoff: "1s", MaxBackoff: "5s"})
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Second, policy.delay(1))
+	assert.Equal(t, 2*time.Second, policy.delay(2))
+	assert.Equal(t, 4*time.Second, policy.delay(3))
+	assert.Equal(t, 5*time.Second, policy.delay(4))
+	assert.Equal(t, 5*time.Second, policy.delay(10))
+}
+
+func TestRetryPolicyMinimumAttempts(t *testing.T) {
+	policy, err := newRetryPolicy(config.HTTPBatchAnalyticsRetry{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, policy.maxAttempts)
+}
@@ -0,0 +1,74 @@
+package analytics
+
+import (
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+)
+
+// ModuleResult is a result a hook module reported in the analytics tags of one of its activities, along
+// with the execution of the hook reporting it. Analytics modules should consume the module results rather
+// than the hook execution outcome, whose format follows the debug output of the auction response.
+type ModuleResult struct {
+	// Module is the code of the module, in the "vendor.module_name" format
+	Module string `json:"module"`
+	// Hook is the code of the hook implementation, as set in the execution plan
+	Hook  string `json:"hook"`
+	Stage string `json:"stage"`
+	// Status and Action are the outcome of the hook execution
+	Status hookexecution.Status `json:"status"`
+	Action hookexecution.Action `json:"action,omitempty"`
+	// Activity and ActivityStatus describe the activity the result belongs to, an activity reported without
+	// results has a single module result with an empty ResultStatus
+	Activity       string                       `json:"activity"`
+	ActivityStatus hookanalytics.ActivityStatus `json:"activity_status"`
+	ResultStatus   hookanalytics.ResultStatus   `json:"result_status,omitempty"`
+	Values         map[string]interface{}       `json:"values,omitempty"`
+	// Bidder, ImpIDs, BidIDs, Request and Response tell which part of the auction the result applies to
+	Bidder   string   `json:"bidder,omitempty"`
+	ImpIDs   []string `json:"imp_ids,omitempty"`
+	BidIDs   []string `json:"bid_ids,omitempty"`
+	Request  bool     `json:"request,omitempty"`
+	Response bool     `json:"response,omitempty"`
+}
+
+// NewModuleResults flattens the analytics tags of the hook execution outcome into module results,
+// in the order the hooks were executed.
+func NewModuleResults(outcomes []hookexecution.StageOutcome) []*ModuleResult {
+	var results []*ModuleResult
+	for _, stage := range outcomes {
+		for _, group := range stage.Groups {
+			for _, hook := range group.InvocationResults {
+				for _, activity := range hook.AnalyticsTags.Activities {
+					base := ModuleResult{
+						Module:         hook.HookID.ModuleCode,
+						Hook:           hook.HookID.HookImplCode,
+						Stage:          stage.Stage,
+						Status:         hook.Status,
+						Action:         hook.Action,
+						Activity:       activity.Name,
+						ActivityStatus: activity.Status,
+					}
+
+					if len(activity.Results) == 0 {
+						result := base
+						results = append(results, &result)
+						continue
+					}
+
+					for _, r := range activity.Results {
+						result := base
+						result.ResultStatus = r.Status
+						result.Values = r.Values
+						result.Bidder = r.AppliedTo.Bidder
+						result.ImpIDs = r.AppliedTo.ImpIds
+						result.BidIDs = r.AppliedTo.BidIds
+						result.Request = r.AppliedTo.Request
+						result.Response = r.AppliedTo.Response
+						results = append(results, &result)
+					}
+				}
+			}
+		}
+	}
+	return results
+}
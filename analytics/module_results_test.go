@@ -0,0 +1,108 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewModuleResults(t *testing.T) {
+	outcomes := []hookexecution.StageOutcome{
+		{
+			Stage: "processed_auction_request",
+			Groups: []hookexecution.GroupOutcome{
+				{
+					InvocationResults: []hookexecution.HookOutcome{
+						{
+							HookID: hookexecution.HookID{ModuleCode: "prebid.rules", HookImplCode: "rules-processed"},
+							Status: hookexecution.StatusSuccess,
+							Action: hookexecution.ActionUpdate,
+							AnalyticsTags: hookanalytics.Analytics{Activities: []hookanalytics.Activity{
+								{Name: "apply_rules", Status: hookanalytics.ActivityStatusSuccess},
+							}},
+						},
+						{
+							HookID: hookexecution.HookID{ModuleCode: "prebid.noop", HookImplCode: "noop"},
+							Status: hookexecution.StatusSuccess,
+							Action: hookexecution.ActionNone,
+						},
+					},
+				},
+			},
+		},
+		{
+			Stage: "raw_bidder_response",
+			Groups: []hookexecution.GroupOutcome{
+				{
+					InvocationResults: []hookexecution.HookOutcome{
+						{
+							HookID: hookexecution.HookID{ModuleCode: "prebid.blocklist", HookImplCode: "blocklist"},
+							Status: hookexecution.StatusSuccess,
+							Action: hookexecution.ActionUpdate,
+							AnalyticsTags: hookanalytics.Analytics{Activities: []hookanalytics.Activity{
+								{
+									Name:   "enforce_blocklists",
+									Status: hookanalytics.ActivityStatusSuccess,
+									Results: []hookanalytics.Result{
+										{
+											Status:    hookanalytics.ResultStatusBlock,
+											Values:    map[string]interface{}{"crid": "crid-1"},
+											AppliedTo: hookanalytics.AppliedTo{Bidder: "appnexus", ImpIds: []string{"imp-1"}, BidIds: []string{"bid-1"}},
+										},
+										{
+											Status:    hookanalytics.ResultStatusAllow,
+											AppliedTo: hookanalytics.AppliedTo{Request: true, Response: true},
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []*ModuleResult{
+		{
+			Module:         "prebid.rules",
+			Hook:           "rules-processed",
+			Stage:          "processed_auction_request",
+			Status:         hookexecution.StatusSuccess,
+			Action:         hookexecution.ActionUpdate,
+			Activity:       "apply_rules",
+			ActivityStatus: hookanalytics.ActivityStatusSuccess,
+		},
+		{
+			Module:         "prebid.blocklist",
+			Hook:           "blocklist",
+			Stage:          "raw_bidder_response",
+			Status:         hookexecution.StatusSuccess,
+			Action:         hookexecution.ActionUpdate,
+			Activity:       "enforce_blocklists",
+			ActivityStatus: hookanalytics.ActivityStatusSuccess,
+			ResultStatus:   hookanalytics.ResultStatusBlock,
+			Values:         map[string]interface{}{"crid": "crid-1"},
+			Bidder:         "appnexus",
+			ImpIDs:         []string{"imp-1"},
+			BidIDs:         []string{"bid-1"},
+		},
+		{
+			Module:         "prebid.blocklist",
+			Hook:           "blocklist",
+			Stage:          "raw_bidder_response",
+			Status:         hookexecution.StatusSuccess,
+			Action:         hookexecution.ActionUpdate,
+			Activity:       "enforce_blocklists",
+			ActivityStatus: hookanalytics.ActivityStatusSuccess,
+			ResultStatus:   hookanalytics.ResultStatusAllow,
+			Request:        true,
+			Response:       true,
+		},
+	}
+
+	assert.Equal(t, expected, NewModuleResults(outcomes))
+	assert.Nil(t, NewModuleResults(nil))
+}
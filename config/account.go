@@ -2,13 +2,17 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/prebid/go-gdpr/consentconstants"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/util/iputil"
+	"golang.org/x/text/currency"
 )
 
 // ChannelType enumerates the values of integrations Prebid Server can configure for an account
@@ -43,6 +47,9 @@ type Account struct {
 	BidAdjustments          *openrtb_ext.ExtRequestPrebidBidAdjustments `mapstructure:"bidadjustments" json:"bidadjustments"`
 	Privacy                 AccountPrivacy                              `mapstructure:"privacy" json:"privacy"`
 	PreferredMediaType      openrtb_ext.PreferredMediaType              `mapstructure:"preferredmediatype" json:"preferredmediatype"`
+	Currency                AccountCurrency                             `mapstructure:"currency" json:"currency"`
+	Analytics               AccountAnalytics                            `mapstructure:"analytics" json:"analytics"`
+	BidNotifications        AccountBidNotifications                     `mapstructure:"bid_notifications" json:"bid_notifications"`
 }
 
 // CookieSync represents the account-level defaults for the cookie sync endpoint.
@@ -82,6 +89,99 @@ type AccountFloorFetch struct {
 	AccountID     string `mapstructure:"accountID" json:"accountID"`
 }
 
+// AccountCurrency represents account-specific currency conversion configuration. The rates are applied to
+// every auction of the account, the same way as the rates defined in bidrequest.ext.prebid.currency.
+type AccountCurrency struct {
+	Rates map[string]map[string]float64 `mapstructure:"rates" json:"rates"`
+	// UsePBSRates is the default for bidrequest.ext.prebid.currency.usepbsrates when the request doesn't set it
+	UsePBSRates *bool `mapstructure:"usepbsrates" json:"usepbsrates"`
+	// Pinned gives the account rates priority over the rates defined in the request
+	Pinned bool `mapstructure:"pinned" json:"pinned"`
+}
+
+// Validate checks the currency codes of the rates and that rates are set when the PBS rates aren't used.
+// It runs on the account defaults at startup and on every account once fetched.
+func (ac *AccountCurrency) Validate(errs []error) []error {
+	for fromCurrency, rates := range ac.Rates {
+		if _, err := currency.ParseISO(fromCurrency); err != nil {
+			errs = append(errs, fmt.Errorf("currency.rates currency code %s is not recognized or malformed", fromCurrency))
+		}
+		for toCurrency := range rates {
+			if _, err := currency.ParseISO(toCurrency); err != nil {
+				errs = append(errs, fmt.Errorf("currency.rates currency code %s is not recognized or malformed", toCurrency))
+			}
+		}
+	}
+
+	if ac.UsePBSRates != nil && !*ac.UsePBSRates && len(ac.Rates) == 0 {
+		errs = append(errs, errors.New("currency.rates must be set when currency.usepbsrates is false"))
+	}
+	return errs
+}
+
+// AccountAnalytics selects the analytics modules receiving the account events
+type AccountAnalytics struct {
+	// Modules maps analytics module names to their account-level settings. When empty, the account events are
+	// logged by every analytics module enabled on the host. Otherwise only the listed modules receive them.
+	Modules map[string]AccountAnalyticsModule `mapstructure:"modules" json:"modules"`
+}
+
+// AccountAnalyticsModule represents the account-level settings of an analytics module
+type AccountAnalyticsModule struct {
+	// Enabled defaults to true, it allows to list a module only to turn it off for the account
+	Enabled *bool `mapstructure:"enabled" json:"enabled"`
+	// SampleRate is the fraction of the account events logged by the module, in the range [0, 1]. Defaults to 1.
+	SampleRate *float64 `mapstructure:"sample_rate" json:"sample_rate"`
+	// Options are module specific settings, such as a publisher specific code, interpreted by the module itself
+	Options json.RawMessage `mapstructure:"options" json:"options"`
+}
+
+// ModuleEnabled returns whether the events of the account must be logged by the named analytics module
+func (a *AccountAnalytics) ModuleEnabled(name string) bool {
+	if len(a.Modules) == 0 {
+		return true
+	}
+	module, ok := a.Modules[name]
+	if !ok {
+		return false
+	}
+	return module.Enabled == nil || *module.Enabled
+}
+
+// ModuleSampleRate returns the fraction of the account events logged by the named analytics module
+func (a *AccountAnalytics) ModuleSampleRate(name string) float64 {
+	if module, ok := a.Modules[name]; ok && module.SampleRate != nil {
+		return *module.SampleRate
+	}
+	return 1
+}
+
+// ModuleOptions returns the account-level options of the named analytics module, or nil if none are set
+func (a *AccountAnalytics) ModuleOptions(name string) json.RawMessage {
+	return a.Modules[name].Options
+}
+
+// Validate checks the sample rates of the modules. It runs on the account defaults at startup and on every
+// account once fetched.
+func (a *AccountAnalytics) Validate(errs []error) []error {
+	for _, name := range slices.Sorted(maps.Keys(a.Modules)) {
+		if sampleRate := a.Modules[name].SampleRate; sampleRate != nil && (*sampleRate < 0 || *sampleRate > 1) {
+			errs = append(errs, fmt.Errorf("analytics.modules.%s.sample_rate should be between 0 and 1", name))
+		}
+	}
+	return errs
+}
+
+// AccountBidNotifications selects the bid notifications Prebid Server fires to the bidders itself once it
+// determined the winning and losing bids of an auction. The bidders denied the reportAnalytics activity
+// aren't notified.
+type AccountBidNotifications struct {
+	// Win fires the nurl of the winning bids having a markup, the nurl of the others is what serves the markup
+	Win bool `mapstructure:"win" json:"win"`
+	// Loss fires the lurl of the losing bids
+	Loss bool `mapstructure:"loss" json:"loss"`
+}
+
 func (pf *AccountPriceFloors) validate(errs []error) []error {
 	if pf.EnforceFloorsRate < 0 || pf.EnforceFloorsRate > 100 {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.enforce_floors_rate should be between 0 and 100`))
@@ -317,6 +417,12 @@ type AccountHooks struct {
 	ExecutionPlan HookExecutionPlan `mapstructure:"execution_plan" json:"execution_plan"`
 }
 
+// Validate checks the merge policies of the execution plan. It runs on the account defaults at startup and on
+// every account once fetched.
+func (a *AccountHooks) Validate(errs []error) []error {
+	return a.ExecutionPlan.validate("hooks.execution_plan", errs)
+}
+
 // AccountModules mapping provides account-level module configuration
 // format: map[vendor_name]map[module_name]json.RawMessage
 type AccountModules map[string]map[string]json.RawMessage
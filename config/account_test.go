@@ -7,6 +7,7 @@ import (
 
 	"github.com/prebid/go-gdpr/consentconstants"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -969,6 +970,143 @@ func TestAccountPriceFloorsValidate(t *testing.T) {
 	}
 }
 
+func TestAccountCurrencyValidate(t *testing.T) {
+	tests := []struct {
+		description string
+		currency    *AccountCurrency
+		want        []error
+	}{
+		{
+			description: "empty configuration",
+			currency:    &AccountCurrency{},
+		},
+		{
+			description: "valid configuration",
+			currency: &AccountCurrency{
+				Rates:       map[string]map[string]float64{"EUR": {"PLN": 4.3}},
+				UsePBSRates: ptrutil.ToPtr(false),
+				Pinned:      true,
+			},
+		},
+		{
+			description: "Invalid configuration: malformed currency codes",
+			currency: &AccountCurrency{
+				Rates: map[string]map[string]float64{"EURO": {"PLN": 4.3}, "USD": {"ZLOTY": 3.9}},
+			},
+			want: []error{
+				errors.New("currency.rates currency code EURO is not recognized or malformed"),
+				errors.New("currency.rates currency code ZLOTY is not recognized or malformed"),
+			},
+		},
+		{
+			description: "Invalid configuration: usepbsrates false without rates",
+			currency: &AccountCurrency{
+				UsePBSRates: ptrutil.ToPtr(false),
+			},
+			want: []error{errors.New("currency.rates must be set when currency.usepbsrates is false")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var errs []error
+			got := tt.currency.Validate(errs)
+			assert.ElementsMatch(t, got, tt.want)
+		})
+	}
+}
+
+func TestAccountAnalyticsValidate(t *testing.T) {
+	tests := []struct {
+		description string
+		analytics   *AccountAnalytics
+		want        []error
+	}{
+		{
+			description: "empty configuration",
+			analytics:   &AccountAnalytics{},
+		},
+		{
+			description: "valid configuration",
+			analytics: &AccountAnalytics{
+				Modules: map[string]AccountAnalyticsModule{
+					"agma":     {SampleRate: ptrutil.ToPtr(0.5), Options: json.RawMessage(`{"code":"abc"}`)},
+					"pubstack": {Enabled: ptrutil.ToPtr(false)},
+				},
+			},
+		},
+		{
+			description: "Invalid configuration: sample rate out of range",
+			analytics: &AccountAnalytics{
+				Modules: map[string]AccountAnalyticsModule{
+					"agma": {SampleRate: ptrutil.ToPtr(1.5)},
+				},
+			},
+			want: []error{errors.New("analytics.modules.agma.sample_rate should be between 0 and 1")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var errs []error
+			got := tt.analytics.Validate(errs)
+			assert.ElementsMatch(t, got, tt.want)
+		})
+	}
+}
+
+func TestAccountHooksValidate(t *testing.T) {
+	tests := []struct {
+		description string
+		plan        string
+		want        []error
+	}{
+		{
+			description: "empty configuration",
+			plan:        `{}`,
+		},
+		{
+			description: "valid configuration",
+			plan:        `{"endpoints": {"/openrtb2/auction": {"stages": {"entrypoint": {"groups": [{"timeout": 5}, {"timeout": 5, "merge_policy": "last_wins"}, {"timeout": 5, "merge_policy": "first_wins"}]}}}}}`,
+		},
+		{
+			description: "Invalid configuration: unknown merge policy",
+			plan:        `{"endpoints": {"/openrtb2/auction": {"stages": {"entrypoint": {"groups": [{"timeout": 5}, {"timeout": 5, "merge_policy": "frist_wins"}]}}}}}`,
+			want:        []error{errors.New(`hooks.execution_plan.endpoints./openrtb2/auction.stages.entrypoint.groups[1].merge_policy must be one of "last_wins" or "first_wins". Got "frist_wins"`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var hooks AccountHooks
+			assert.NoError(t, json.Unmarshal([]byte(tt.plan), &hooks.ExecutionPlan))
+			var errs []error
+			got := hooks.Validate(errs)
+			assert.ElementsMatch(t, got, tt.want)
+		})
+	}
+}
+
+func TestAccountAnalyticsModules(t *testing.T) {
+	analytics := AccountAnalytics{
+		Modules: map[string]AccountAnalyticsModule{
+			"agma":      {SampleRate: ptrutil.ToPtr(0.25), Options: json.RawMessage(`{"code":"abc"}`)},
+			"pubstack":  {Enabled: ptrutil.ToPtr(false)},
+			"httpbatch": {Enabled: ptrutil.ToPtr(true)},
+		},
+	}
+
+	assert.True(t, analytics.ModuleEnabled("agma"))
+	assert.True(t, analytics.ModuleEnabled("httpbatch"))
+	assert.False(t, analytics.ModuleEnabled("pubstack"))
+	assert.False(t, analytics.ModuleEnabled("filelogger"), "modules not listed are disabled")
+	assert.True(t, (&AccountAnalytics{}).ModuleEnabled("filelogger"), "all modules are enabled when none is listed")
+
+	assert.Equal(t, 0.25, analytics.ModuleSampleRate("agma"))
+	assert.Equal(t, 1.0, analytics.ModuleSampleRate("httpbatch"))
+	assert.Equal(t, 1.0, analytics.ModuleSampleRate("filelogger"))
+
+	assert.JSONEq(t, `{"code":"abc"}`, string(analytics.ModuleOptions("agma")))
+	assert.Nil(t, analytics.ModuleOptions("filelogger"))
+}
+
 func TestIPMaskingValidate(t *testing.T) {
 	tests := []struct {
 		name    string
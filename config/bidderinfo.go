@@ -3,12 +3,12 @@ package config
 import (
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/macros"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 
@@ -233,7 +233,7 @@ const (
 func (r InfoReaderFromDisk) Read() (map[string][]byte, error) {
 	bidderConfigs, err := os.ReadDir(r.Path)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	bidderInfos := make(map[string][]byte)
@@ -258,7 +258,21 @@ func LoadBidderInfoFromDisk(path string) (BidderInfos, error) {
 }
 
 func LoadBidderInfo(reader InfoReader) (BidderInfos, error) {
-	return processBidderInfos(reader, openrtb_ext.NormalizeBidderName)
+	bidderInfos, err := processBidderInfos(reader, openrtb_ext.NormalizeBidderName)
+	if err != nil {
+		return nil, err
+	}
+	//required for CoreBidderNames function to also return aliasBiddernames
+	if err := openrtb_ext.SetAliasBidderNames(bidderInfos.AliasBidderNames()); err != nil {
+		return nil, err
+	}
+	return bidderInfos, nil
+}
+
+// ParseBidderInfoFromDisk reads the bidder infos like LoadBidderInfoFromDisk without setting the
+// aliases, which is left to the caller once the bidder infos are validated.
+func ParseBidderInfoFromDisk(path string) (BidderInfos, error) {
+	return processBidderInfos(InfoReaderFromDisk{Path: path}, openrtb_ext.NormalizeBidderName)
 }
 
 func processBidderInfos(reader InfoReader, normalizeBidderName openrtb_ext.BidderNameNormalizer) (BidderInfos, error) {
@@ -285,18 +299,13 @@ func processBidderInfos(reader InfoReader, normalizeBidderName openrtb_ext.Bidde
 					return nil, fmt.Errorf("error parsing config for aliased bidder %s: %v", fileName, err)
 				}
 
-				//required for CoreBidderNames function to also return aliasBiddernames
-				if err := openrtb_ext.SetAliasBidderName(bidderName[0], openrtb_ext.BidderName(info.AliasOf)); err != nil {
-					return nil, err
-				}
-
-				normalizedBidderName, bidderNameExists := normalizeBidderName(bidderName[0])
-				if !bidderNameExists {
-					return nil, fmt.Errorf("error parsing config for an alias %s: unknown bidder", fileName)
+				// the aliases are named after their file, they're set once the bidder infos are processed
+				if openrtb_ext.IsBidderNameReserved(bidderName[0]) {
+					return nil, fmt.Errorf("alias %s is a reserved bidder name and cannot be used", bidderName[0])
 				}
 
-				aliasNillableFieldsByBidder[string(normalizedBidderName)] = aliasFields
-				bidderInfos[string(normalizedBidderName)] = info
+				aliasNillableFieldsByBidder[bidderName[0]] = aliasFields
+				bidderInfos[bidderName[0]] = info
 			} else {
 				normalizedBidderName, bidderNameExists := normalizeBidderName(bidderName[0])
 				if !bidderNameExists {
@@ -388,6 +397,25 @@ func (infos BidderInfos) ToGVLVendorIDMap() map[openrtb_ext.BidderName]uint16 {
 	return gvlVendorIds
 }
 
+// AliasBidderNames returns the parent of every alias of the bidder infos
+func (infos BidderInfos) AliasBidderNames() map[string]openrtb_ext.BidderName {
+	aliases := make(map[string]openrtb_ext.BidderName)
+	for bidderName, info := range infos {
+		if len(info.AliasOf) > 0 {
+			aliases[bidderName] = openrtb_ext.BidderName(info.AliasOf)
+		}
+	}
+	return aliases
+}
+
+// Validate validates the bidder infos changed at runtime the same way they're validated at startup
+func (infos BidderInfos) Validate() error {
+	if errs := infos.validate(nil); len(errs) > 0 {
+		return errortypes.NewAggregateError("invalid bidder infos", errs)
+	}
+	return nil
+}
+
 // validateBidderInfos validates bidder endpoint, info and syncer data
 func (infos BidderInfos) validate(errs []error) []error {
 	for bidderName, bidder := range infos {
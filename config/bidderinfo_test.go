@@ -1895,6 +1895,54 @@ func TestApplyBidderInfoConfigOverridesInvalid(t *testing.T) {
 	}
 }
 
+func TestMergeBidderInfos(t *testing.T) {
+	validInfo := BidderInfo{
+		Endpoint:     "http://original.com",
+		Maintainer:   &MaintainerInfo{Email: "maintainer@bidder.com"},
+		Capabilities: &CapabilitiesInfo{Site: &PlatformInfo{MediaTypes: []openrtb_ext.BidType{openrtb_ext.BidTypeBanner}}},
+	}
+	overriddenInfo := validInfo
+	overriddenInfo.Endpoint = "http://override.com"
+
+	cfg := Configuration{
+		bidderInfoOverrides: nillableFieldBidderInfos{"appnexus": nillableFieldBidderInfo{
+			bidderInfo: BidderInfo{Endpoint: "http://override.com"},
+		}},
+	}
+
+	var testCases = []struct {
+		description         string
+		givenFsBidderInfos  BidderInfos
+		expectedBidderInfos BidderInfos
+		expectedError       string
+	}{
+		{
+			description:         "Host overrides applied",
+			givenFsBidderInfos:  BidderInfos{"appnexus": validInfo, "rubicon": validInfo},
+			expectedBidderInfos: BidderInfos{"appnexus": overriddenInfo, "rubicon": validInfo},
+		},
+		{
+			description:        "Overridden bidder missing",
+			givenFsBidderInfos: BidderInfos{"rubicon": validInfo},
+			expectedError:      "error finding configuration for bidder appnexus: unknown bidder",
+		},
+		{
+			description:        "Invalid bidder info",
+			givenFsBidderInfos: BidderInfos{"appnexus": validInfo, "rubicon": {Endpoint: "http://original.com"}},
+			expectedError:      "missing required field: maintainer.email for adapter: rubicon",
+		},
+	}
+	for _, test := range testCases {
+		infos, err := cfg.MergeBidderInfos(test.givenFsBidderInfos)
+		if test.expectedError != "" {
+			assert.ErrorContains(t, err, test.expectedError, test.description+":err")
+		} else {
+			assert.NoError(t, err, test.description+":err")
+			assert.Equal(t, test.expectedBidderInfos, infos, test.description+":infos")
+		}
+	}
+}
+
 func TestReadFullYamlBidderConfig(t *testing.T) {
 	bidder := "bidderA"
 	bidderInf := BidderInfo{}
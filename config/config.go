@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/prebid/go-gdpr/consentconstants"
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/health"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/spf13/viper"
@@ -99,10 +102,33 @@ type Configuration struct {
 	// BidderInfos supports adapter overrides in extra configs like pbs.json, pbs.yaml, etc.
 	// Refers to main.go `configFileName` constant
 	BidderInfos BidderInfos `mapstructure:"adapters"`
+	// bidderInfoOverrides are the host adapter overrides, kept to merge bidder infos reloaded at runtime
+	bidderInfoOverrides nillableFieldBidderInfos
 	// Hooks provides a way to specify hook execution plan for specific endpoints and stages
 	Hooks       Hooks       `mapstructure:"hooks"`
 	Validations Validations `mapstructure:"validations"`
 	PriceFloors PriceFloors `mapstructure:"price_floors"`
+	// Health configures the checks reported by the /healthz and /readyz endpoints
+	Health Health `mapstructure:"health"`
+}
+
+type Health struct {
+	// CheckTimeoutMS is the time a check of a dependency is given before it's considered failed
+	CheckTimeoutMS int `mapstructure:"check_timeout_ms"`
+	// ReadinessChecks are the names of the checks which fail /readyz when they fail
+	ReadinessChecks []string `mapstructure:"readiness_checks"`
+}
+
+func (cfg *Health) validate(errs []error) []error {
+	if cfg.CheckTimeoutMS <= 0 {
+		errs = append(errs, fmt.Errorf("health.check_timeout_ms must be > 0. Got %d", cfg.CheckTimeoutMS))
+	}
+	for _, name := range cfg.ReadinessChecks {
+		if !slices.Contains(health.CheckNames(), name) {
+			errs = append(errs, fmt.Errorf("health.readiness_checks contains the unknown check %s. Valid checks are %v", name, health.CheckNames()))
+		}
+	}
+	return errs
 }
 
 type Admin struct {
@@ -141,6 +167,7 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 	errs = cfg.Accounts.validate(errs)
 	errs = cfg.CategoryMapping.validate(errs)
 	errs = cfg.StoredVideo.validate(errs)
+	errs = cfg.Event.validate(errs)
 	errs = cfg.Metrics.validate(errs)
 	if cfg.MaxRequestSize < 0 {
 		errs = append(errs, fmt.Errorf("cfg.max_request_size must be >= 0. Got %d", cfg.MaxRequestSize))
@@ -150,6 +177,9 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 	errs = cfg.Debug.validate(errs)
 	errs = cfg.ExtCacheURL.validate(errs)
 	errs = cfg.AccountDefaults.PriceFloors.validate(errs)
+	errs = cfg.AccountDefaults.Currency.Validate(errs)
+	errs = cfg.AccountDefaults.Analytics.Validate(errs)
+	errs = cfg.AccountDefaults.Hooks.Validate(errs)
 	if cfg.AccountDefaults.Disabled {
 		glog.Warning(`With account_defaults.disabled=true, host-defined accounts must exist and have "disabled":false. All other requests will be rejected.`)
 	}
@@ -160,6 +190,8 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 
 	errs = cfg.Experiment.validate(errs)
 	errs = cfg.BidderInfos.validate(errs)
+	errs = cfg.Health.validate(errs)
+	errs = cfg.Hooks.validate(errs)
 	errs = cfg.AccountDefaults.Privacy.IPv6Config.Validate(errs)
 	errs = cfg.AccountDefaults.Privacy.IPv4Config.Validate(errs)
 
@@ -451,21 +483,93 @@ type LMT struct {
 }
 
 type Analytics struct {
-	File     FileLogs      `mapstructure:"file"`
-	Agma     AgmaAnalytics `mapstructure:"agma"`
-	Pubstack Pubstack      `mapstructure:"pubstack"`
+	File      FileLogs           `mapstructure:"file"`
+	Agma      AgmaAnalytics      `mapstructure:"agma"`
+	Pubstack  Pubstack           `mapstructure:"pubstack"`
+	HTTPBatch HTTPBatchAnalytics `mapstructure:"http_batch"`
 }
 
 type CurrencyConverter struct {
 	FetchURL             string `mapstructure:"fetch_url"`
 	FetchIntervalSeconds int    `mapstructure:"fetch_interval_seconds"`
 	StaleRatesSeconds    int    `mapstructure:"stale_rates_seconds"`
+	// HardExpirySeconds is the age after which the last known good rates are discarded when every source fails.
+	// When 0, rates are discarded as soon as they are older than StaleRatesSeconds, otherwise it must be at least StaleRatesSeconds.
+	HardExpirySeconds int `mapstructure:"hard_expiry_seconds"`
+	// Sources lists the rates sources queried in order until one succeeds. When empty, FetchURL is used.
+	Sources []CurrencyConverterSource `mapstructure:"sources"`
+	History CurrencyConverterHistory  `mapstructure:"history"`
+}
+
+// CurrencyConverterHistory configures the history of fetched rates tables used for reporting and replay
+type CurrencyConverterHistory struct {
+	// MaxEntries is the number of rates tables kept, the oldest ones are evicted first. 0 disables the history.
+	MaxEntries int `mapstructure:"max_entries"`
+	// File is an optional path the history is persisted to so it survives restarts
+	File string `mapstructure:"file"`
 }
 
+// CurrencyConverterSource configures a single currency rates source
+type CurrencyConverterSource struct {
+	// Name identifies the source in logs, metrics and the /currency/rates admin endpoint. It must be unique
+	// and only made of letters, digits, underscores and dashes since it's part of the metric names.
+	Name string                      `mapstructure:"name"`
+	Type CurrencyConverterSourceType `mapstructure:"type"`
+	// URL is required by the json and ecb source types
+	URL string `mapstructure:"url"`
+	// Path is required by the file source type
+	Path string `mapstructure:"path"`
+}
+
+// CurrencyConverterSourceType is the format and transport of a currency rates source
+type CurrencyConverterSourceType string
+
+const (
+	// CurrencyConverterSourceJSON fetches the prebid currency file JSON format over HTTP
+	CurrencyConverterSourceJSON CurrencyConverterSourceType = "json"
+	// CurrencyConverterSourceECB fetches the European Central Bank reference rates XML over HTTP
+	CurrencyConverterSourceECB CurrencyConverterSourceType = "ecb"
+	// CurrencyConverterSourceFile reads the prebid currency file JSON format from disk
+	CurrencyConverterSourceFile CurrencyConverterSourceType = "file"
+)
+
+var currencyConverterSourceNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 func (cfg *CurrencyConverter) validate(errs []error) []error {
 	if cfg.FetchIntervalSeconds < 0 {
 		errs = append(errs, fmt.Errorf("currency_converter.fetch_interval_seconds must be in the range [0, %d]. Got %d", 0xffff, cfg.FetchIntervalSeconds))
 	}
+	if cfg.HardExpirySeconds < 0 {
+		errs = append(errs, fmt.Errorf("currency_converter.hard_expiry_seconds must be >= 0. Got %d", cfg.HardExpirySeconds))
+	}
+	if cfg.HardExpirySeconds > 0 && cfg.HardExpirySeconds < cfg.StaleRatesSeconds {
+		errs = append(errs, fmt.Errorf("currency_converter.hard_expiry_seconds must be 0 or >= currency_converter.stale_rates_seconds (%d). Got %d", cfg.StaleRatesSeconds, cfg.HardExpirySeconds))
+	}
+	if cfg.History.MaxEntries < 0 {
+		errs = append(errs, fmt.Errorf("currency_converter.history.max_entries must be >= 0 (0 disables the history). Got %d", cfg.History.MaxEntries))
+	}
+	sourceNames := make(map[string]struct{}, len(cfg.Sources))
+	for i, source := range cfg.Sources {
+		if !currencyConverterSourceNameRegex.MatchString(source.Name) {
+			errs = append(errs, fmt.Errorf("currency_converter.sources[%d].name must only contain letters, digits, underscores and dashes. Got %q", i, source.Name))
+		} else if _, found := sourceNames[source.Name]; found {
+			errs = append(errs, fmt.Errorf("currency_converter.sources[%d].name %s is used by another source", i, source.Name))
+		}
+		sourceNames[source.Name] = struct{}{}
+
+		switch source.Type {
+		case CurrencyConverterSourceJSON, CurrencyConverterSourceECB:
+			if source.URL == "" {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].url must be set for source type %s", i, source.Type))
+			}
+		case CurrencyConverterSourceFile:
+			if source.Path == "" {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].path must be set for source type %s", i, source.Type))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("currency_converter.sources[%d].type must be one of [%s, %s, %s]. Got %s", i, CurrencyConverterSourceJSON, CurrencyConverterSourceECB, CurrencyConverterSourceFile, source.Type))
+		}
+	}
 	return errs
 }
 
@@ -494,9 +598,66 @@ type AgmaAnalyticsAccount struct {
 	SiteAppId   string `mapstructure:"site_app_id"`
 }
 
+// HTTPBatchAnalytics configures the generic analytics module posting batches of events to an HTTP endpoint
+type HTTPBatchAnalytics struct {
+	Enabled  bool                       `mapstructure:"enabled"`
+	Endpoint HTTPBatchAnalyticsEndpoint `mapstructure:"endpoint"`
+	// Format is the payload format, either "json" for a JSON array of events or "ndjson" for one event per line
+	Format  string                   `mapstructure:"format"`
+	Buffers HTTPBatchAnalyticsBuffer `mapstructure:"buffers"`
+	Retry   HTTPBatchAnalyticsRetry  `mapstructure:"retry"`
+	// SampleRates maps event types (auction, amp, video, cookie_sync, setuid, event) to the fraction of events
+	// logged, in the range [0, 1]. Event types not listed are always logged.
+	SampleRates map[string]float64 `mapstructure:"sample_rates"`
+	// ExcludeFields lists dot separated paths of fields removed from the events data, e.g. "request.user"
+	ExcludeFields []string `mapstructure:"exclude_fields"`
+}
+
+type HTTPBatchAnalyticsEndpoint struct {
+	Url     string            `mapstructure:"url"`
+	Timeout string            `mapstructure:"timeout"`
+	Gzip    bool              `mapstructure:"gzip"`
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+type HTTPBatchAnalyticsBuffer struct {
+	BufferSize string `mapstructure:"size"`
+	EventCount int    `mapstructure:"count"`
+	Timeout    string `mapstructure:"timeout"`
+	// MaxPendingBatches is the number of batches waiting while another one is sent, the batches flushed once
+	// it's reached are dropped
+	MaxPendingBatches int `mapstructure:"max_pending_batches"`
+}
+
+type HTTPBatchAnalyticsRetry struct {
+	// MaxAttempts is the number of times a batch is sent before being dropped, including the first attempt
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// Backoff is the delay before the first retry, doubled on every subsequent retry up to MaxBackoff
+	Backoff    string `mapstructure:"backoff"`
+	MaxBackoff string `mapstructure:"max_backoff"`
+}
+
 // FileLogs Corresponding config for FileLogger as a PBS Analytics Module
 type FileLogs struct {
 	Filename string `mapstructure:"filename"`
+	// Directory enables the asynchronous logger writing each event type to its own rotated file in the directory.
+	// It takes precedence over Filename.
+	Directory string           `mapstructure:"directory"`
+	Rotation  FileLogsRotation `mapstructure:"rotation"`
+	// QueueSize is the number of events buffered per event type, events are dropped when the queue is full
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// FileLogsRotation configures when the file logs are rotated and how many rotated files are kept
+type FileLogsRotation struct {
+	// MaxSize is the size a file is rotated at, using SI standard eg. "100MB". Empty disables size based rotation.
+	MaxSize string `mapstructure:"max_size"`
+	// MaxAge is the time after which a file is rotated, eg. "1h". Empty disables time based rotation.
+	MaxAge string `mapstructure:"max_age"`
+	// Compress gzips the rotated files
+	Compress bool `mapstructure:"compress"`
+	// MaxBackups is the number of rotated files kept per event type, 0 keeps all of them
+	MaxBackups int `mapstructure:"max_backups"`
 }
 
 type Pubstack struct {
@@ -521,6 +682,33 @@ type VTrack struct {
 
 type Event struct {
 	TimeoutMS int64 `mapstructure:"timeout_ms"`
+	// NotificationTimeoutMS is the timeout of the win and loss notifications fired to the bidders, 0 for none
+	NotificationTimeoutMS int64 `mapstructure:"notification_timeout_ms"`
+	// Reconciliation keeps the winning bids of the recent auctions to attach them to the /event notifications
+	Reconciliation EventReconciliation `mapstructure:"reconciliation"`
+}
+
+type EventReconciliation struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTLSeconds is how long a winning bid is kept after its auction, events received later aren't reconciled
+	TTLSeconds int `mapstructure:"ttl_seconds"`
+	// MaxEntries caps the number of bids kept, the oldest ones are evicted first
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+func (cfg *Event) validate(errs []error) []error {
+	if cfg.NotificationTimeoutMS < 0 {
+		errs = append(errs, fmt.Errorf("event.notification_timeout_ms must be >= 0. Got %d", cfg.NotificationTimeoutMS))
+	}
+	if cfg.Reconciliation.Enabled {
+		if cfg.Reconciliation.TTLSeconds <= 0 {
+			errs = append(errs, fmt.Errorf("event.reconciliation.ttl_seconds must be > 0. Got %d", cfg.Reconciliation.TTLSeconds))
+		}
+		if cfg.Reconciliation.MaxEntries <= 0 {
+			errs = append(errs, fmt.Errorf("event.reconciliation.max_entries must be > 0. Got %d", cfg.Reconciliation.MaxEntries))
+		}
+	}
+	return errs
 }
 
 type HostCookie struct {
@@ -594,12 +782,65 @@ type PrometheusMetrics struct {
 	Namespace        string `mapstructure:"namespace"`
 	Subsystem        string `mapstructure:"subsystem"`
 	TimeoutMillisRaw int    `mapstructure:"timeout_ms"`
+	// Buckets overrides the histogram buckets of the metric families, the built-in buckets are used when empty
+	Buckets PrometheusBuckets `mapstructure:"buckets"`
+	// Exemplars attaches the id of the auction request to the observations of the request, adapter and price
+	// histograms. They are only exposed with the OpenMetrics format.
+	Exemplars         bool                        `mapstructure:"exemplars"`
+	AccountHistograms PrometheusAccountHistograms `mapstructure:"account_histograms"`
+}
+
+// PrometheusBuckets defines the upper bounds of the histogram buckets per metric family. Times are in seconds.
+type PrometheusBuckets struct {
+	RequestTime       []float64 `mapstructure:"request_time"`
+	QueuedRequestTime []float64 `mapstructure:"queued_request_time"`
+	AdapterTime       []float64 `mapstructure:"adapter_time"`
+	AdapterPrice      []float64 `mapstructure:"adapter_price"`
+	OverheadTime      []float64 `mapstructure:"overhead_time"`
+	CacheWriteTime    []float64 `mapstructure:"cache_write_time"`
+	ModuleTime        []float64 `mapstructure:"module_time"`
+}
+
+// PrometheusAccountHistograms enables the per account request time, and per account and bidder request time and
+// bid price histograms. Only the first MaxAccounts accounts seen get their own label, the others are collapsed into
+// the "other" account to bound the number of series.
+type PrometheusAccountHistograms struct {
+	Enabled     bool `mapstructure:"enabled"`
+	MaxAccounts int  `mapstructure:"max_accounts"`
 }
 
 func (cfg *PrometheusMetrics) validate(errs []error) []error {
 	if cfg.Port > 0 && cfg.TimeoutMillisRaw <= 0 {
 		errs = append(errs, fmt.Errorf("metrics.prometheus.timeout_ms must be positive if metrics.prometheus.port is defined. Got timeout=%d and port=%d", cfg.TimeoutMillisRaw, cfg.Port))
 	}
+	errs = cfg.Buckets.validate(errs)
+	if cfg.AccountHistograms.Enabled && cfg.AccountHistograms.MaxAccounts <= 0 {
+		errs = append(errs, fmt.Errorf("metrics.prometheus.account_histograms.max_accounts must be positive if the account histograms are enabled. Got %d", cfg.AccountHistograms.MaxAccounts))
+	}
+	return errs
+}
+
+func (cfg *PrometheusBuckets) validate(errs []error) []error {
+	buckets := []struct {
+		name   string
+		bounds []float64
+	}{
+		{"request_time", cfg.RequestTime},
+		{"queued_request_time", cfg.QueuedRequestTime},
+		{"adapter_time", cfg.AdapterTime},
+		{"adapter_price", cfg.AdapterPrice},
+		{"overhead_time", cfg.OverheadTime},
+		{"cache_write_time", cfg.CacheWriteTime},
+		{"module_time", cfg.ModuleTime},
+	}
+	for _, bucket := range buckets {
+		for i := 1; i < len(bucket.bounds); i++ {
+			if bucket.bounds[i] <= bucket.bounds[i-1] {
+				errs = append(errs, fmt.Errorf("metrics.prometheus.buckets.%s must be in strictly increasing order. Got %v", bucket.name, bucket.bounds))
+				break
+			}
+		}
+	}
 	return errs
 }
 
@@ -662,6 +903,51 @@ type DefReqFiles struct {
 type Debug struct {
 	TimeoutNotification TimeoutNotification `mapstructure:"timeout_notification"`
 	OverrideToken       string              `mapstructure:"override_token"`
+	// Capture of the debug data of the account auctions sampled from the admin endpoints
+	Capture DebugCapture `mapstructure:"capture"`
+}
+
+const (
+	DebugCaptureSinkMemory = "memory"
+	DebugCaptureSinkFile   = "file"
+)
+
+type DebugCapture struct {
+	// Sink is either "memory", keeping the latest captures readable from the admin endpoints, or "file", also
+	// appending them to a rotated file
+	Sink string `mapstructure:"sink"`
+	// Number of captures kept in memory by both sinks
+	BufferSize int `mapstructure:"buffer_size"`
+	// File the captures are appended to by the file sink, as JSON lines
+	FilePath string `mapstructure:"file_path"`
+	// Number of captures waiting to be written by the file sink, captures are dropped when the queue is full
+	QueueSize int `mapstructure:"queue_size"`
+	// Rotation of the file of the file sink, its max_size and max_backups bound the disk space it takes
+	Rotation FileLogsRotation `mapstructure:"rotation"`
+}
+
+func (cfg *DebugCapture) validate(errs []error) []error {
+	if cfg.Sink != DebugCaptureSinkMemory && cfg.Sink != DebugCaptureSinkFile {
+		return append(errs, fmt.Errorf("debug.capture.sink must be %s or %s. Got %s", DebugCaptureSinkMemory, DebugCaptureSinkFile, cfg.Sink))
+	}
+	if cfg.BufferSize <= 0 {
+		errs = append(errs, fmt.Errorf("debug.capture.buffer_size must be > 0. Got %d", cfg.BufferSize))
+	}
+	if cfg.Sink == DebugCaptureSinkFile {
+		if cfg.FilePath == "" {
+			errs = append(errs, errors.New("debug.capture.file_path must be set when debug.capture.sink is file"))
+		}
+		if cfg.QueueSize <= 0 {
+			errs = append(errs, fmt.Errorf("debug.capture.queue_size must be > 0. Got %d", cfg.QueueSize))
+		}
+		if cfg.Rotation.MaxSize == "" {
+			errs = append(errs, errors.New("debug.capture.rotation.max_size must be set when debug.capture.sink is file"))
+		}
+		if cfg.Rotation.MaxBackups <= 0 {
+			errs = append(errs, fmt.Errorf("debug.capture.rotation.max_backups must be > 0. Got %d", cfg.Rotation.MaxBackups))
+		}
+	}
+	return errs
 }
 
 type Server struct {
@@ -675,7 +961,8 @@ func (server *Server) Empty() bool {
 }
 
 func (cfg *Debug) validate(errs []error) []error {
-	return cfg.TimeoutNotification.validate(errs)
+	errs = cfg.TimeoutNotification.validate(errs)
+	return cfg.Capture.validate(errs)
 }
 
 type TimeoutNotification struct {
@@ -817,6 +1104,8 @@ func New(v *viper.Viper, bidderInfos BidderInfos, normalizeBidderName openrtb_ex
 
 	glog.Info("Logging the resolved configuration:")
 	logGeneral(reflect.ValueOf(c), "  \t")
+	// set once logged, the struct logger can't read the unexported fields of the overrides
+	c.bidderInfoOverrides = configBidderInfosWithNillableFields
 	if errs := c.validate(v); len(errs) > 0 {
 		return &c, errortypes.NewAggregateError("validation errors", errs)
 	}
@@ -854,6 +1143,19 @@ func setConfigBidderInfoNillableFields(v *viper.Viper, bidderInfos BidderInfos)
 	return infos, nil
 }
 
+// MergeBidderInfos applies the host adapter overrides to bidder infos reloaded from disk, the same way
+// they were applied at startup, and validates the result
+func (cfg *Configuration) MergeBidderInfos(fsBidderInfos BidderInfos) (BidderInfos, error) {
+	mergedBidderInfos, err := applyBidderInfoConfigOverrides(cfg.bidderInfoOverrides, fsBidderInfos, openrtb_ext.NormalizeBidderName)
+	if err != nil {
+		return nil, err
+	}
+	if err := mergedBidderInfos.Validate(); err != nil {
+		return nil, err
+	}
+	return mergedBidderInfos, nil
+}
+
 // MarshalAccountDefaults compiles AccountDefaults into the JSON format used for merge patch
 func (cfg *Configuration) MarshalAccountDefaults() error {
 	var err error
@@ -966,6 +1268,16 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("metrics.prometheus.namespace", "")
 	v.SetDefault("metrics.prometheus.subsystem", "")
 	v.SetDefault("metrics.prometheus.timeout_ms", 10000)
+	v.SetDefault("metrics.prometheus.buckets.request_time", []float64{})
+	v.SetDefault("metrics.prometheus.buckets.queued_request_time", []float64{})
+	v.SetDefault("metrics.prometheus.buckets.adapter_time", []float64{})
+	v.SetDefault("metrics.prometheus.buckets.adapter_price", []float64{})
+	v.SetDefault("metrics.prometheus.buckets.overhead_time", []float64{})
+	v.SetDefault("metrics.prometheus.buckets.cache_write_time", []float64{})
+	v.SetDefault("metrics.prometheus.buckets.module_time", []float64{})
+	v.SetDefault("metrics.prometheus.exemplars", false)
+	v.SetDefault("metrics.prometheus.account_histograms.enabled", false)
+	v.SetDefault("metrics.prometheus.account_histograms.max_accounts", 100)
 	v.SetDefault("category_mapping.filesystem.enabled", true)
 	v.SetDefault("category_mapping.filesystem.directorypath", "./static/category-mapping")
 	v.SetDefault("category_mapping.http.endpoint", "")
@@ -1076,6 +1388,10 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("vtrack.enabled", true)
 
 	v.SetDefault("event.timeout_ms", 1000)
+	v.SetDefault("event.notification_timeout_ms", 1000)
+	v.SetDefault("event.reconciliation.enabled", false)
+	v.SetDefault("event.reconciliation.ttl_seconds", 3600)
+	v.SetDefault("event.reconciliation.max_entries", 100000)
 
 	v.SetDefault("user_sync.priority_groups", [][]string{})
 
@@ -1092,6 +1408,12 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 
 	v.SetDefault("max_request_size", 1024*256)
 	v.SetDefault("analytics.file.filename", "")
+	v.SetDefault("analytics.file.directory", "")
+	v.SetDefault("analytics.file.rotation.max_size", "100MB")
+	v.SetDefault("analytics.file.rotation.max_age", "24h")
+	v.SetDefault("analytics.file.rotation.compress", true)
+	v.SetDefault("analytics.file.rotation.max_backups", 0)
+	v.SetDefault("analytics.file.queue_size", 10000)
 	v.SetDefault("analytics.pubstack.endpoint", "https://s2s.pbstck.com/v1")
 	v.SetDefault("analytics.pubstack.scopeid", "change-me")
 	v.SetDefault("analytics.pubstack.enabled", false)
@@ -1107,6 +1429,18 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("analytics.agma.buffers.count", 100)
 	v.SetDefault("analytics.agma.buffers.timeout", "15m")
 	v.SetDefault("analytics.agma.accounts", []AgmaAnalyticsAccount{})
+	v.SetDefault("analytics.http_batch.enabled", false)
+	v.SetDefault("analytics.http_batch.endpoint.url", "")
+	v.SetDefault("analytics.http_batch.endpoint.timeout", "2s")
+	v.SetDefault("analytics.http_batch.endpoint.gzip", true)
+	v.SetDefault("analytics.http_batch.format", "ndjson")
+	v.SetDefault("analytics.http_batch.buffers.size", "2MB")
+	v.SetDefault("analytics.http_batch.buffers.count", 100)
+	v.SetDefault("analytics.http_batch.buffers.timeout", "15m")
+	v.SetDefault("analytics.http_batch.buffers.max_pending_batches", 10)
+	v.SetDefault("analytics.http_batch.retry.max_attempts", 3)
+	v.SetDefault("analytics.http_batch.retry.backoff", "1s")
+	v.SetDefault("analytics.http_batch.retry.max_backoff", "30s")
 	v.SetDefault("amp_timeout_adjustment_ms", 0)
 	v.BindEnv("gdpr.default_value")
 	v.SetDefault("gdpr.enabled", true)
@@ -1145,6 +1479,9 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("currency_converter.fetch_url", "https://cdn.jsdelivr.net/gh/prebid/currency-file@1/latest.json")
 	v.SetDefault("currency_converter.fetch_interval_seconds", 1800) // fetch currency rates every 30 minutes
 	v.SetDefault("currency_converter.stale_rates_seconds", 0)
+	v.SetDefault("currency_converter.hard_expiry_seconds", 0)
+	v.SetDefault("currency_converter.history.max_entries", 0)
+	v.SetDefault("currency_converter.history.file", "")
 	v.SetDefault("default_request.type", "")
 	v.SetDefault("default_request.file.name", "")
 	v.SetDefault("default_request.alias_info", false)
@@ -1176,6 +1513,8 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.BindEnv("account_defaults.privacy.dsa.gdpr_only")
 	v.SetDefault("account_defaults.privacy.ipv6.anon_keep_bits", 56)
 	v.SetDefault("account_defaults.privacy.ipv4.anon_keep_bits", 24)
+	v.SetDefault("account_defaults.bid_notifications.win", false)
+	v.SetDefault("account_defaults.bid_notifications.loss", false)
 
 	//Defaults for Price floor fetcher
 	v.SetDefault("price_floors.fetcher.worker", 20)
@@ -1203,6 +1542,14 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("debug.timeout_notification.sampling_rate", 0.0)
 	v.SetDefault("debug.timeout_notification.fail_only", false)
 	v.SetDefault("debug.override_token", "")
+	v.SetDefault("debug.capture.sink", DebugCaptureSinkMemory)
+	v.SetDefault("debug.capture.buffer_size", 100)
+	v.SetDefault("debug.capture.file_path", "")
+	v.SetDefault("debug.capture.queue_size", 1000)
+	v.SetDefault("debug.capture.rotation.max_size", "100MB")
+	v.SetDefault("debug.capture.rotation.max_age", "")
+	v.SetDefault("debug.capture.rotation.compress", false)
+	v.SetDefault("debug.capture.rotation.max_backups", 5)
 
 	v.SetDefault("tmax_adjustments.enabled", false)
 	v.SetDefault("tmax_adjustments.bidder_response_duration_min_ms", 0)
@@ -1273,6 +1620,10 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("experiment.adscert.remote.signing_timeout_ms", 5)
 
 	v.SetDefault("hooks.enabled", false)
+	v.SetDefault("hooks.module_cache_size_mb", 10)
+
+	v.SetDefault("health.check_timeout_ms", 1000)
+	v.SetDefault("health.readiness_checks", []string{health.CheckStoredRequests, health.CheckModules})
 
 	for bidderName := range bidderInfos {
 		setBidderDefaults(v, strings.ToLower(bidderName))
@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net"
 	"os"
@@ -141,6 +142,42 @@ func TestExternalCacheURLValidate(t *testing.T) {
 	}
 }
 
+func TestEventValidate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		data      Event
+		expErrors []error
+	}{
+		{
+			desc: "valid",
+			data: Event{NotificationTimeoutMS: 1000, Reconciliation: EventReconciliation{Enabled: true, TTLSeconds: 60, MaxEntries: 10}},
+		},
+		{
+			desc:      "negative_notification_timeout",
+			data:      Event{NotificationTimeoutMS: -1},
+			expErrors: []error{errors.New("event.notification_timeout_ms must be >= 0. Got -1")},
+		},
+		{
+			desc: "reconciliation_disabled_ignores_limits",
+			data: Event{Reconciliation: EventReconciliation{Enabled: false}},
+		},
+		{
+			desc: "reconciliation_without_limits",
+			data: Event{Reconciliation: EventReconciliation{Enabled: true}},
+			expErrors: []error{
+				errors.New("event.reconciliation.ttl_seconds must be > 0. Got 0"),
+				errors.New("event.reconciliation.max_entries must be > 0. Got 0"),
+			},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			errs := test.data.validate(nil)
+			assert.Equal(t, test.expErrors, errs)
+		})
+	}
+}
+
 func TestDefaults(t *testing.T) {
 	cfg, _ := newDefaultConfig(t)
 
@@ -162,6 +199,13 @@ func TestDefaults(t *testing.T) {
 	cmpBools(t, "adapter_gdpr_request_blocked", false, cfg.Metrics.Disabled.AdapterGDPRRequestBlocked)
 	cmpStrings(t, "certificates_file", "", cfg.PemCertsFile)
 	cmpInts(t, "stored_requests_timeout_ms", 50, cfg.StoredRequestsTimeout)
+	cmpInts(t, "health.check_timeout_ms", 1000, cfg.Health.CheckTimeoutMS)
+	assert.Equal(t, []string{"stored_requests", "modules"}, cfg.Health.ReadinessChecks, "health.readiness_checks")
+	cmpStrings(t, "debug.capture.sink", "memory", cfg.Debug.Capture.Sink)
+	cmpInts(t, "debug.capture.buffer_size", 100, cfg.Debug.Capture.BufferSize)
+	cmpInts(t, "debug.capture.queue_size", 1000, cfg.Debug.Capture.QueueSize)
+	cmpStrings(t, "debug.capture.rotation.max_size", "100MB", cfg.Debug.Capture.Rotation.MaxSize)
+	cmpInts(t, "debug.capture.rotation.max_backups", 5, cfg.Debug.Capture.Rotation.MaxBackups)
 	cmpBools(t, "stored_requests.filesystem.enabled", false, cfg.StoredRequests.Files.Enabled)
 	cmpStrings(t, "stored_requests.filesystem.directorypath", "./stored_requests/data/by_id", cfg.StoredRequests.Files.Path)
 	cmpBools(t, "auto_gen_source_tid", true, cfg.AutoGenSourceTID)
@@ -213,6 +257,7 @@ func TestDefaults(t *testing.T) {
 	cmpBools(t, "account_defaults.events.enabled", false, cfg.AccountDefaults.Events.Enabled)
 
 	cmpBools(t, "hooks.enabled", false, cfg.Hooks.Enabled)
+	cmpInts(t, "hooks.module_cache_size_mb", 10, cfg.Hooks.ModuleCacheSizeMB)
 	cmpStrings(t, "validations.banner_creative_max_size", "skip", cfg.Validations.BannerCreativeMaxSize)
 	cmpStrings(t, "validations.secure_markup", "skip", cfg.Validations.SecureMarkup)
 	cmpInts(t, "validations.max_creative_width", 0, int(cfg.Validations.MaxCreativeWidth))
@@ -938,6 +983,15 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		Health: Health{
+			CheckTimeoutMS: 1000,
+		},
+		Debug: Debug{
+			Capture: DebugCapture{
+				Sink:       DebugCaptureSinkMemory,
+				BufferSize: 100,
+			},
+		},
 	}
 
 	v := viper.New()
@@ -1200,6 +1254,32 @@ func TestNegativePrometheusTimeout(t *testing.T) {
 	assertOneError(t, cfg.validate(v), "metrics.prometheus.timeout_ms must be positive if metrics.prometheus.port is defined. Got timeout=0 and port=8001")
 }
 
+func TestPrometheusBucketsValidate(t *testing.T) {
+	cfg, v := newDefaultConfig(t)
+	cfg.Metrics.Prometheus.Buckets.RequestTime = []float64{0.1, 0.5, 1}
+	cfg.Metrics.Prometheus.Buckets.AdapterPrice = []float64{1, 5, 5}
+	assertOneError(t, cfg.validate(v), "metrics.prometheus.buckets.adapter_price must be in strictly increasing order. Got [1 5 5]")
+}
+
+func TestPrometheusAccountHistogramsMaxAccounts(t *testing.T) {
+	cfg, v := newDefaultConfig(t)
+	cfg.Metrics.Prometheus.AccountHistograms.Enabled = true
+	cfg.Metrics.Prometheus.AccountHistograms.MaxAccounts = 0
+	assertOneError(t, cfg.validate(v), "metrics.prometheus.account_histograms.max_accounts must be positive if the account histograms are enabled. Got 0")
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	cfg, v := newDefaultConfig(t)
+	cfg.Health.CheckTimeoutMS = 0
+	assertOneError(t, cfg.validate(v), "health.check_timeout_ms must be > 0. Got 0")
+}
+
+func TestHealthReadinessChecks(t *testing.T) {
+	cfg, v := newDefaultConfig(t)
+	cfg.Health.ReadinessChecks = []string{"stored_requests", "database"}
+	assertOneError(t, cfg.validate(v), "health.readiness_checks contains the unknown check database. Valid checks are [stored_requests currency_rates gvl floors_fetcher prebid_cache modules]")
+}
+
 func TestInvalidHostVendorID(t *testing.T) {
 	tests := []struct {
 		description  string
@@ -1376,6 +1456,98 @@ func TestValidateDebug(t *testing.T) {
 	assert.NotNil(t, err, "cfg.debug.timeout_notification.sampling_rate should not be allowed to be greater than 1.0, but it was allowed")
 }
 
+func TestValidateDebugCapture(t *testing.T) {
+	testCases := []struct {
+		name          string
+		capture       DebugCapture
+		expectedError string
+	}{
+		{
+			name:          "unknown-sink",
+			capture:       DebugCapture{Sink: "kafka", BufferSize: 100},
+			expectedError: "debug.capture.sink must be memory or file. Got kafka",
+		},
+		{
+			name:          "memory-sink-without-buffer",
+			capture:       DebugCapture{Sink: DebugCaptureSinkMemory},
+			expectedError: "debug.capture.buffer_size must be > 0. Got 0",
+		},
+		{
+			name:          "file-sink-without-path",
+			capture:       DebugCapture{Sink: DebugCaptureSinkFile, BufferSize: 100, QueueSize: 1000, Rotation: FileLogsRotation{MaxSize: "100MB", MaxBackups: 5}},
+			expectedError: "debug.capture.file_path must be set when debug.capture.sink is file",
+		},
+		{
+			name:          "file-sink-without-buffer",
+			capture:       DebugCapture{Sink: DebugCaptureSinkFile, FilePath: "captures.jsonl", QueueSize: 1000, Rotation: FileLogsRotation{MaxSize: "100MB", MaxBackups: 5}},
+			expectedError: "debug.capture.buffer_size must be > 0. Got 0",
+		},
+		{
+			name:          "file-sink-without-queue",
+			capture:       DebugCapture{Sink: DebugCaptureSinkFile, BufferSize: 100, FilePath: "captures.jsonl", Rotation: FileLogsRotation{MaxSize: "100MB", MaxBackups: 5}},
+			expectedError: "debug.capture.queue_size must be > 0. Got 0",
+		},
+		{
+			name:          "file-sink-without-max-size",
+			capture:       DebugCapture{Sink: DebugCaptureSinkFile, BufferSize: 100, FilePath: "captures.jsonl", QueueSize: 1000, Rotation: FileLogsRotation{MaxBackups: 5}},
+			expectedError: "debug.capture.rotation.max_size must be set when debug.capture.sink is file",
+		},
+		{
+			name:          "file-sink-keeping-all-backups",
+			capture:       DebugCapture{Sink: DebugCaptureSinkFile, BufferSize: 100, FilePath: "captures.jsonl", QueueSize: 1000, Rotation: FileLogsRotation{MaxSize: "100MB"}},
+			expectedError: "debug.capture.rotation.max_backups must be > 0. Got 0",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, v := newDefaultConfig(t)
+			cfg.Debug.Capture = test.capture
+			assertOneError(t, cfg.validate(v), test.expectedError)
+		})
+	}
+}
+
+func TestValidateHooksModuleCacheSize(t *testing.T) {
+	cfg, v := newDefaultConfig(t)
+	cfg.Hooks.ModuleCacheSizeMB = -1
+	assertOneError(t, cfg.validate(v), "hooks.module_cache_size_mb must be >= 0. Got -1")
+}
+
+func TestValidateHooksExecutionPlans(t *testing.T) {
+	const plan = `{"endpoints": {"/openrtb2/amp": {"stages": {"processed_auction_request": {"groups": [{"timeout": 5, "merge_policy": "random"}]}}}}}`
+
+	tests := []struct {
+		description   string
+		plan          func(cfg *Configuration) *HookExecutionPlan
+		expectedError string
+	}{
+		{
+			description:   "host execution plan",
+			plan:          func(cfg *Configuration) *HookExecutionPlan { return &cfg.Hooks.HostExecutionPlan },
+			expectedError: `hooks.host_execution_plan.endpoints./openrtb2/amp.stages.processed_auction_request.groups[0].merge_policy must be one of "last_wins" or "first_wins". Got "random"`,
+		},
+		{
+			description:   "default account execution plan",
+			plan:          func(cfg *Configuration) *HookExecutionPlan { return &cfg.Hooks.DefaultAccountExecutionPlan },
+			expectedError: `hooks.default_account_execution_plan.endpoints./openrtb2/amp.stages.processed_auction_request.groups[0].merge_policy must be one of "last_wins" or "first_wins". Got "random"`,
+		},
+		{
+			description:   "account defaults execution plan",
+			plan:          func(cfg *Configuration) *HookExecutionPlan { return &cfg.AccountDefaults.Hooks.ExecutionPlan },
+			expectedError: `hooks.execution_plan.endpoints./openrtb2/amp.stages.processed_auction_request.groups[0].merge_policy must be one of "last_wins" or "first_wins". Got "random"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, v := newDefaultConfig(t)
+			assert.NoError(t, json.Unmarshal([]byte(plan), test.plan(cfg)))
+			assertOneError(t, cfg.validate(v), test.expectedError)
+		})
+	}
+}
+
 func TestValidateAccountsConfigRestrictions(t *testing.T) {
 	cfg, v := newDefaultConfig(t)
 	cfg.Accounts.Files.Enabled = true
@@ -1962,3 +2134,128 @@ func TestUnpackDSADefault(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrencyConverterValidation(t *testing.T) {
+	testCases := []struct {
+		description           string
+		giveStaleRatesSeconds int
+		giveHardExpirySeconds int
+		giveHistoryMaxEntries int
+		expectedErrs          []error
+	}{
+		{
+			description:           "disabled",
+			giveStaleRatesSeconds: 3600,
+			giveHardExpirySeconds: 0,
+		},
+		{
+			description:           "after_stale_rates",
+			giveStaleRatesSeconds: 3600,
+			giveHardExpirySeconds: 7200,
+		},
+		{
+			description:           "negative",
+			giveHardExpirySeconds: -1,
+			expectedErrs: []error{
+				errors.New("currency_converter.hard_expiry_seconds must be >= 0. Got -1"),
+			},
+		},
+		{
+			description:           "negative_history_max_entries",
+			giveHistoryMaxEntries: -1,
+			expectedErrs: []error{
+				errors.New("currency_converter.history.max_entries must be >= 0 (0 disables the history). Got -1"),
+			},
+		},
+		{
+			description:           "before_stale_rates",
+			giveStaleRatesSeconds: 3600,
+			giveHardExpirySeconds: 60,
+			expectedErrs: []error{
+				errors.New("currency_converter.hard_expiry_seconds must be 0 or >= currency_converter.stale_rates_seconds (3600). Got 60"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := CurrencyConverter{
+				StaleRatesSeconds: tc.giveStaleRatesSeconds,
+				HardExpirySeconds: tc.giveHardExpirySeconds,
+				History:           CurrencyConverterHistory{MaxEntries: tc.giveHistoryMaxEntries},
+			}
+			errs := cfg.validate(nil)
+			assert.Equal(t, tc.expectedErrs, errs)
+		})
+	}
+}
+
+func TestCurrencyConverterSourcesValidation(t *testing.T) {
+	testCases := []struct {
+		description  string
+		giveSources  []CurrencyConverterSource
+		expectedErrs []error
+	}{
+		{
+			description: "valid_sources",
+			giveSources: []CurrencyConverterSource{
+				{Name: "prebid", Type: CurrencyConverterSourceJSON, URL: "https://currency.prebid.org"},
+				{Name: "ecb", Type: CurrencyConverterSourceECB, URL: "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"},
+				{Name: "static_file-1", Type: CurrencyConverterSourceFile, Path: "/etc/pbs/rates.json"},
+			},
+		},
+		{
+			description: "missing_name",
+			giveSources: []CurrencyConverterSource{{Type: CurrencyConverterSourceJSON, URL: "https://currency.prebid.org"}},
+			expectedErrs: []error{
+				errors.New(`currency_converter.sources[0].name must only contain letters, digits, underscores and dashes. Got ""`),
+			},
+		},
+		{
+			description: "name_not_metric_safe",
+			giveSources: []CurrencyConverterSource{{Name: "currency.prebid.org", Type: CurrencyConverterSourceJSON, URL: "https://currency.prebid.org"}},
+			expectedErrs: []error{
+				errors.New(`currency_converter.sources[0].name must only contain letters, digits, underscores and dashes. Got "currency.prebid.org"`),
+			},
+		},
+		{
+			description: "duplicate_name",
+			giveSources: []CurrencyConverterSource{
+				{Name: "prebid", Type: CurrencyConverterSourceJSON, URL: "https://currency.prebid.org"},
+				{Name: "prebid", Type: CurrencyConverterSourceFile, Path: "/etc/pbs/rates.json"},
+			},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[1].name prebid is used by another source"),
+			},
+		},
+		{
+			description: "missing_url",
+			giveSources: []CurrencyConverterSource{{Name: "ecb", Type: CurrencyConverterSourceECB}},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[0].url must be set for source type ecb"),
+			},
+		},
+		{
+			description: "missing_path",
+			giveSources: []CurrencyConverterSource{{Name: "file", Type: CurrencyConverterSourceFile, URL: "https://currency.prebid.org"}},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[0].path must be set for source type file"),
+			},
+		},
+		{
+			description: "unknown_type",
+			giveSources: []CurrencyConverterSource{{Name: "ftp", Type: "ftp", URL: "ftp://currency.prebid.org"}},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[0].type must be one of [json, ecb, file]. Got ftp"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := CurrencyConverter{Sources: tc.giveSources}
+			errs := cfg.validate(nil)
+			assert.Equal(t, tc.expectedErrs, errs)
+		})
+	}
+}
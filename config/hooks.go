@@ -1,5 +1,17 @@
 package config
 
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Merge policies of the hook execution groups, the empty policy standing for MergePolicyLastWins.
+const (
+	MergePolicyLastWins  = "last_wins"
+	MergePolicyFirstWins = "first_wins"
+)
+
 type Hooks struct {
 	Enabled bool    `mapstructure:"enabled"`
 	Modules Modules `mapstructure:"modules"`
@@ -7,6 +19,17 @@ type Hooks struct {
 	HostExecutionPlan HookExecutionPlan `mapstructure:"host_execution_plan"`
 	// DefaultAccountExecutionPlan can be replaced by the account-specific hook execution plan
 	DefaultAccountExecutionPlan HookExecutionPlan `mapstructure:"default_account_execution_plan"`
+	// ModuleCacheSizeMB is the size of the key/value cache shared by modules, 0 disables it
+	ModuleCacheSizeMB int `mapstructure:"module_cache_size_mb"`
+}
+
+func (cfg *Hooks) validate(errs []error) []error {
+	if cfg.ModuleCacheSizeMB < 0 {
+		errs = append(errs, fmt.Errorf("hooks.module_cache_size_mb must be >= 0. Got %d", cfg.ModuleCacheSizeMB))
+	}
+	errs = cfg.HostExecutionPlan.validate("hooks.host_execution_plan", errs)
+	errs = cfg.DefaultAccountExecutionPlan.validate("hooks.default_account_execution_plan", errs)
+	return errs
 }
 
 // Modules mapping provides module specific configuration, format: map[vendor_name]map[module_name]interface{}
@@ -21,10 +44,29 @@ type HookExecutionPlan struct {
 	} `mapstructure:"endpoints" json:"endpoints"`
 }
 
+func (plan *HookExecutionPlan) validate(prefix string, errs []error) []error {
+	for _, endpoint := range slices.Sorted(maps.Keys(plan.Endpoints)) {
+		stages := plan.Endpoints[endpoint].Stages
+		for _, stage := range slices.Sorted(maps.Keys(stages)) {
+			for i, group := range stages[stage].Groups {
+				switch group.MergePolicy {
+				case "", MergePolicyLastWins, MergePolicyFirstWins:
+				default:
+					errs = append(errs, fmt.Errorf(`%s.endpoints.%s.stages.%s.groups[%d].merge_policy must be one of "%s" or "%s". Got "%s"`, prefix, endpoint, stage, i, MergePolicyLastWins, MergePolicyFirstWins, group.MergePolicy))
+				}
+			}
+		}
+	}
+	return errs
+}
+
 type HookExecutionGroup struct {
 	// Timeout specified in milliseconds.
 	// Zero value marks the hook execution status with the "timeout" value.
-	Timeout      int `mapstructure:"timeout" json:"timeout"`
+	Timeout int `mapstructure:"timeout" json:"timeout"`
+	// MergePolicy tells how the conflicting mutations of the hooks of the group are merged,
+	// "last_wins" or "first_wins". Empty value falls back to "last_wins".
+	MergePolicy  string `mapstructure:"merge_policy" json:"merge_policy"`
 	HookSequence []struct {
 		// ModuleCode is a composite value in the format: {vendor_name}.{module_name}
 		ModuleCode string `mapstructure:"module_code" json:"module_code"`
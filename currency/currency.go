@@ -1,42 +1,82 @@
 package currency
 
 import (
+	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
 
-func GetAuctionCurrencyRates(currencyConverter *RateConverter, requestRates *openrtb_ext.ExtRequestCurrency) Conversions {
-	if currencyConverter == nil && requestRates == nil {
+// GetAuctionCurrencyRates returns the conversions to use for an auction. Custom rates defined in
+// bidRequest.ext.prebid.currency and in the account configuration are prioritized over PBS rates.
+// Request rates are prioritized over account rates unless the account rates are pinned.
+func GetAuctionCurrencyRates(currencyConverter *RateConverter, requestRates *openrtb_ext.ExtRequestCurrency, accountRates *config.AccountCurrency) Conversions {
+	if currencyConverter == nil && requestRates == nil && accountRates == nil {
 		return nil
 	}
 
-	if requestRates == nil {
-		// No bidRequest.ext.currency field was found, use PBS rates as usual
+	customRates := getCustomRates(requestRates, accountRates)
+
+	// currencyConverter will never be nil, refer main.serve(), adding this check for future usecases
+	if currencyConverter == nil || !usePBSRates(requestRates, accountRates) {
+		// The custom rates are empty only when the request sets usepbsrates to false without rates, which
+		// disables the currency conversion. Accounts doing so are rejected when they're fetched.
+		return aggregateConversions(customRates)
+	}
+
+	// Both PBS and custom rates can be used, check if custom rates are not empty
+	if len(customRates) == 0 {
+		// Custom rates maps are empty, use PBS rates only
 		return currencyConverter.Rates()
 	}
 
-	// currencyConverter will never be nil, refer main.serve(), adding this check for future usecases
-	if currencyConverter == nil {
-		return NewRates(requestRates.ConversionRates)
+	// Return an AggregateConversions object that includes both custom and PBS currency rates but will
+	// prioritize custom rates over PBS rates whenever a currency rate is found in both
+	return aggregateConversions(append(customRates, currencyConverter.Rates()))
+}
+
+// getCustomRates returns the non empty custom rates ordered by priority
+func getCustomRates(requestRates *openrtb_ext.ExtRequestCurrency, accountRates *config.AccountCurrency) []Conversions {
+	var request, account map[string]map[string]float64
+	if requestRates != nil {
+		request = requestRates.ConversionRates
+	}
+	if accountRates != nil {
+		account = accountRates.Rates
 	}
 
-	// If bidRequest.ext.currency.usepbsrates is nil, we understand its value as true. It will be false
-	// only if it's explicitly set to false
-	usePbsRates := requestRates.UsePBSRates == nil || *requestRates.UsePBSRates
+	first, second := request, account
+	if accountRates != nil && accountRates.Pinned {
+		first, second = account, request
+	}
 
-	if !usePbsRates {
-		// At this point, we can safely assume the ConversionRates map is not empty because
-		// validateCustomRates(bidReqCurrencyRates *openrtb_ext.ExtRequestCurrency) would have
-		// thrown an error under such conditions.
-		return NewRates(requestRates.ConversionRates)
+	customRates := make([]Conversions, 0, 2)
+	for _, rates := range []map[string]map[string]float64{first, second} {
+		if len(rates) > 0 {
+			customRates = append(customRates, NewRates(rates))
+		}
 	}
+	return customRates
+}
 
-	// Both PBS and custom rates can be used, check if ConversionRates is not empty
-	if len(requestRates.ConversionRates) == 0 {
-		// Custom rates map is empty, use PBS rates only
-		return currencyConverter.Rates()
+// usePBSRates resolves whether PBS rates can be used. The request value takes precedence over the account
+// default. When neither is set, it's understood as true.
+func usePBSRates(requestRates *openrtb_ext.ExtRequestCurrency, accountRates *config.AccountCurrency) bool {
+	if requestRates != nil && requestRates.UsePBSRates != nil {
+		return *requestRates.UsePBSRates
+	}
+	if accountRates != nil && accountRates.UsePBSRates != nil {
+		return *accountRates.UsePBSRates
 	}
+	return true
+}
 
-	// Return an AggregateConversions object that includes both custom and PBS currency rates but will
-	// prioritize custom rates over PBS rates whenever a currency rate is found in both
-	return NewAggregateConversions(NewRates(requestRates.ConversionRates), currencyConverter.Rates())
+// aggregateConversions chains conversions so each one falls back to the next when a rate isn't found
+func aggregateConversions(conversions []Conversions) Conversions {
+	switch len(conversions) {
+	case 0:
+		return NewRates(nil)
+	case 1:
+		return conversions[0]
+	default:
+		return NewAggregateConversions(conversions[0], aggregateConversions(conversions[1:]))
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/prebid/prebid-server/v3/util/ptrutil"
@@ -178,7 +179,7 @@ func TestGetAuctionCurrencyRates(t *testing.T) {
 			if tt.args.currencyConverter != nil {
 				tt.args.currencyConverter.Run()
 			}
-			auctionRates := GetAuctionCurrencyRates(tt.args.currencyConverter, tt.args.requestRates)
+			auctionRates := GetAuctionCurrencyRates(tt.args.currencyConverter, tt.args.requestRates, nil)
 			if tt.args.currencyConverter == nil && tt.args.requestRates == nil && tt.assertRates == nil {
 				assert.Nil(t, auctionRates)
 			} else if tt.assertRates == nil {
@@ -197,3 +198,113 @@ func TestGetAuctionCurrencyRates(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAuctionCurrencyRatesWithAccountRates(t *testing.T) {
+	pbsRates := map[string]map[string]float64{
+		"EUR": {
+			"PLN": 4.32,
+			"USD": 1.08,
+		},
+	}
+	accountRates := map[string]map[string]float64{
+		"EUR": {
+			"PLN": 4.30,
+		},
+	}
+	requestRates := map[string]map[string]float64{
+		"EUR": {
+			"PLN": 4.25,
+		},
+	}
+
+	currencyConverter := NewRateConverter(
+		&MockCurrencyRatesHttpClient{ResponseBody: `{"conversions":{"EUR":{"PLN":4.32,"USD":1.08}}}`},
+		"currency.fake.com",
+		24*time.Hour,
+	)
+	currencyConverter.Run()
+
+	tests := []struct {
+		name         string
+		requestRates *openrtb_ext.ExtRequestCurrency
+		accountRates *config.AccountCurrency
+		expectedRate float64
+		expectedErr  bool
+		from, to     string
+	}{
+		{
+			name:         "empty_account_rates_use_pbs_rates",
+			accountRates: &config.AccountCurrency{},
+			from:         "EUR",
+			to:           "PLN",
+			expectedRate: pbsRates["EUR"]["PLN"],
+		},
+		{
+			name:         "account_rates_prioritized_over_pbs_rates",
+			accountRates: &config.AccountCurrency{Rates: accountRates},
+			from:         "EUR",
+			to:           "PLN",
+			expectedRate: 4.30,
+		},
+		{
+			name:         "account_rates_fall_back_to_pbs_rates",
+			accountRates: &config.AccountCurrency{Rates: accountRates},
+			from:         "EUR",
+			to:           "USD",
+			expectedRate: pbsRates["EUR"]["USD"],
+		},
+		{
+			name:         "account_usepbsrates_false_disables_pbs_rates",
+			accountRates: &config.AccountCurrency{Rates: accountRates, UsePBSRates: ptrutil.ToPtr(false)},
+			from:         "EUR",
+			to:           "USD",
+			expectedErr:  true,
+		},
+		{
+			name:         "request_usepbsrates_overrides_account_default",
+			requestRates: &openrtb_ext.ExtRequestCurrency{UsePBSRates: ptrutil.ToPtr(true)},
+			accountRates: &config.AccountCurrency{Rates: accountRates, UsePBSRates: ptrutil.ToPtr(false)},
+			from:         "EUR",
+			to:           "USD",
+			expectedRate: pbsRates["EUR"]["USD"],
+		},
+		{
+			name:         "request_rates_prioritized_over_account_rates",
+			requestRates: &openrtb_ext.ExtRequestCurrency{ConversionRates: requestRates},
+			accountRates: &config.AccountCurrency{Rates: accountRates},
+			from:         "EUR",
+			to:           "PLN",
+			expectedRate: 4.25,
+		},
+		{
+			name:         "pinned_account_rates_prioritized_over_request_rates",
+			requestRates: &openrtb_ext.ExtRequestCurrency{ConversionRates: requestRates},
+			accountRates: &config.AccountCurrency{Rates: accountRates, Pinned: true},
+			from:         "EUR",
+			to:           "PLN",
+			expectedRate: 4.30,
+		},
+		{
+			name:         "pinned_account_rates_fall_back_to_request_rates",
+			requestRates: &openrtb_ext.ExtRequestCurrency{ConversionRates: map[string]map[string]float64{"USD": {"PLN": 3.95}}, UsePBSRates: ptrutil.ToPtr(false)},
+			accountRates: &config.AccountCurrency{Rates: accountRates, Pinned: true},
+			from:         "USD",
+			to:           "PLN",
+			expectedRate: 3.95,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auctionRates := GetAuctionCurrencyRates(currencyConverter, tt.requestRates, tt.accountRates)
+
+			rate, err := auctionRates.GetRate(tt.from, tt.to)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRate, rate)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package currency
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// RatesHistory keeps a bounded, time-indexed history of the rates tables fetched by a RateConverter.
+// When a file path is provided, the history is persisted after every change and reloaded on creation
+// so it survives restarts.
+type RatesHistory struct {
+	maxEntries int
+	filePath   string
+	entries    []RatesHistoryEntry // ordered by FetchedAt, oldest first
+	mutex      sync.RWMutex
+}
+
+// RatesHistoryEntry is a rates table along with the time it was fetched
+type RatesHistoryEntry struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Source    string    `json:"source"`
+	Rates     *Rates    `json:"rates"`
+}
+
+// NewRatesHistory returns a history keeping at most maxEntries rates tables. An empty filePath disables
+// persistence. An error is returned if an existing history file can't be read, in which case the returned
+// history is still usable but starts empty.
+func NewRatesHistory(maxEntries int, filePath string) (*RatesHistory, error) {
+	history := &RatesHistory{
+		maxEntries: maxEntries,
+		filePath:   filePath,
+	}
+	return history, history.load()
+}
+
+// Add records rates fetched at the given time, evicting the oldest entries when the history is full
+func (h *RatesHistory) Add(fetchedAt time.Time, source string, rates *Rates) error {
+	if h.maxEntries <= 0 {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	entry := RatesHistoryEntry{FetchedAt: fetchedAt, Source: source, Rates: rates}
+	i := sort.Search(len(h.entries), func(i int) bool { return h.entries[i].FetchedAt.After(fetchedAt) })
+	h.entries = append(h.entries, RatesHistoryEntry{})
+	copy(h.entries[i+1:], h.entries[i:])
+	h.entries[i] = entry
+
+	if overflow := len(h.entries) - h.maxEntries; overflow > 0 {
+		h.entries = append(h.entries[:0:0], h.entries[overflow:]...)
+	}
+
+	return h.persist()
+}
+
+// At returns the entry effective at the given time, which is the most recent one fetched at or before it.
+// The second return value is false if no rates had been fetched yet at that time.
+func (h *RatesHistory) At(at time.Time) (RatesHistoryEntry, bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	i := sort.Search(len(h.entries), func(i int) bool { return h.entries[i].FetchedAt.After(at) })
+	if i == 0 {
+		return RatesHistoryEntry{}, false
+	}
+	return h.entries[i-1], true
+}
+
+// Entries returns a snapshot of the history, oldest entry first
+func (h *RatesHistory) Entries() []RatesHistoryEntry {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	entries := make([]RatesHistoryEntry, len(h.entries))
+	copy(entries, h.entries)
+	return entries
+}
+
+// persist writes the history to its file. It must be called with the write lock held.
+func (h *RatesHistory) persist() error {
+	if h.filePath == "" {
+		return nil
+	}
+
+	data, err := jsonutil.Marshal(h.entries)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated history behind
+	tmpPath := h.filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, h.filePath)
+}
+
+func (h *RatesHistory) load() error {
+	if h.filePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(h.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var entries []RatesHistoryEntry
+	if err := jsonutil.UnmarshalValid(data, &entries); err != nil {
+		return err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].FetchedAt.Before(entries[j].FetchedAt) })
+	if overflow := len(entries) - h.maxEntries; overflow > 0 {
+		entries = entries[overflow:]
+	}
+	h.entries = entries
+	return nil
+}
@@ -0,0 +1,116 @@
+package currency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatesHistoryAt(t *testing.T) {
+	t0 := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	rates1 := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.31}})
+	rates2 := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.32}})
+	rates3 := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.33}})
+
+	history, err := NewRatesHistory(2, "")
+	assert.NoError(t, err)
+	assert.NoError(t, history.Add(t0, "source", rates1))
+	assert.NoError(t, history.Add(t0.Add(2*time.Hour), "source", rates3))
+	// Out of order entries are inserted at their position in time
+	assert.NoError(t, history.Add(t0.Add(time.Hour), "source", rates2))
+
+	testCases := []struct {
+		description   string
+		giveAt        time.Time
+		expectedFound bool
+		expectedEntry RatesHistoryEntry
+	}{
+		{
+			description:   "before_oldest_entry_evicted",
+			giveAt:        t0.Add(30 * time.Minute),
+			expectedFound: false,
+		},
+		{
+			description:   "exact_fetch_time",
+			giveAt:        t0.Add(time.Hour),
+			expectedFound: true,
+			expectedEntry: RatesHistoryEntry{FetchedAt: t0.Add(time.Hour), Source: "source", Rates: rates2},
+		},
+		{
+			description:   "between_fetches",
+			giveAt:        t0.Add(90 * time.Minute),
+			expectedFound: true,
+			expectedEntry: RatesHistoryEntry{FetchedAt: t0.Add(time.Hour), Source: "source", Rates: rates2},
+		},
+		{
+			description:   "after_latest_fetch",
+			giveAt:        t0.Add(24 * time.Hour),
+			expectedFound: true,
+			expectedEntry: RatesHistoryEntry{FetchedAt: t0.Add(2 * time.Hour), Source: "source", Rates: rates3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			entry, found := history.At(tc.giveAt)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expectedEntry, entry)
+		})
+	}
+}
+
+func TestRatesHistoryPersistence(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "history.json")
+	t0 := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	rates := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.31}})
+
+	history, err := NewRatesHistory(5, filePath)
+	assert.NoError(t, err)
+	assert.NoError(t, history.Add(t0, "source", rates))
+
+	// A new history reloads the persisted entries, trimmed to its own size
+	reloaded, err := NewRatesHistory(5, filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, history.Entries(), reloaded.Entries())
+
+	assert.NoError(t, os.WriteFile(filePath, []byte(`invalid`), 0644))
+	corrupted, err := NewRatesHistory(5, filePath)
+	assert.Error(t, err)
+	assert.Empty(t, corrupted.Entries())
+}
+
+func TestRatesHistoryDisabled(t *testing.T) {
+	history, err := NewRatesHistory(0, "")
+	assert.NoError(t, err)
+	assert.NoError(t, history.Add(time.Now(), "source", NewRates(nil)))
+	assert.Empty(t, history.Entries())
+}
+
+func TestRateConverterRecordsHistory(t *testing.T) {
+	rates := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.31}})
+	source := &mockRateSource{
+		name: "source",
+		results: []mockRateSourceResult{
+			{rates: rates},
+			{err: assert.AnError},
+		},
+	}
+	fakeTime := &FakeTime{time: time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)}
+
+	history, _ := NewRatesHistory(10, "")
+	currencyConverter := NewMultiSourceRateConverter([]RateSource{source}, 0, 0)
+	currencyConverter.time = fakeTime
+	currencyConverter.SetHistory(history)
+
+	assert.NoError(t, currencyConverter.Run())
+	fakeTime.time = fakeTime.time.Add(time.Hour)
+	assert.Error(t, currencyConverter.Run())
+
+	// Failed fetches aren't recorded
+	assert.Equal(t, []RatesHistoryEntry{
+		{FetchedAt: time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC), Source: "source", Rates: rates},
+	}, currencyConverter.History().Entries())
+}
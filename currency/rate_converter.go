@@ -1,89 +1,183 @@
 package currency
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/prebid/prebid-server/v3/errortypes"
-	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/prebid/prebid-server/v3/metrics"
 	"github.com/prebid/prebid-server/v3/util/timeutil"
 )
 
 // RateConverter holds the currencies conversion rates dictionary
 type RateConverter struct {
-	httpClient          httpClient
+	sources             []RateSource
 	staleRatesThreshold time.Duration
-	syncSourceURL       string
+	hardExpiry          time.Duration
 	rates               atomic.Value // Should only hold Rates struct
 	lastUpdated         atomic.Value // Should only hold time.Time
+	activeSource        atomic.Value // Should only hold string
+	health              []SourceHealth
+	healthMutex         sync.RWMutex
 	constantRates       Conversions
 	time                timeutil.Time
+	metricsEngine       metrics.MetricsEngine
+	history             *RatesHistory
+	syncSourceURL       string // reported as the source by the converter fetching from a single URL
 }
 
-// NewRateConverter returns a new RateConverter
+// SourceHealth describes the outcome of the most recent fetches from a RateSource
+type SourceHealth struct {
+	Name                string    `json:"name"`
+	LastAttempt         time.Time `json:"lastAttempt"`
+	LastSuccess         time.Time `json:"lastSuccess"`
+	LastError           string    `json:"lastError,omitempty"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+}
+
+// rateConverterInfo is exposed as the converter's additional info on the /currency/rates admin endpoint
+type rateConverterInfo struct {
+	Stale   bool           `json:"stale"`
+	Sources []SourceHealth `json:"sources"`
+}
+
+// DefaultRateSourceName is the name of the source of the RateConverter fetching from a single syncSourceURL
+const DefaultRateSourceName = "default"
+
+// NewRateConverter returns a new RateConverter fetching from a single syncSourceURL.
+// Rates are discarded as soon as they become older than staleRatesThreshold.
+// The converter reports syncSourceURL as its source while the metrics use DefaultRateSourceName.
 func NewRateConverter(
 	httpClient httpClient,
 	syncSourceURL string,
 	staleRatesThreshold time.Duration,
 ) *RateConverter {
+	rc := NewMultiSourceRateConverter(
+		[]RateSource{NewHTTPRateSource(DefaultRateSourceName, httpClient, syncSourceURL)},
+		staleRatesThreshold,
+		staleRatesThreshold,
+	)
+	rc.syncSourceURL = syncSourceURL
+	return rc
+}
+
+// NewMultiSourceRateConverter returns a new RateConverter querying sources in order until one succeeds.
+// When every source fails the last known good rates are kept and reported as stale once older than
+// staleRatesThreshold; they are discarded in favor of constant rates once older than hardExpiry.
+// A threshold of zero or less disables the corresponding check.
+func NewMultiSourceRateConverter(
+	sources []RateSource,
+	staleRatesThreshold time.Duration,
+	hardExpiry time.Duration,
+) *RateConverter {
+	health := make([]SourceHealth, len(sources))
+	for i, source := range sources {
+		health[i].Name = source.Name()
+	}
+
 	return &RateConverter{
-		httpClient:          httpClient,
+		sources:             sources,
 		staleRatesThreshold: staleRatesThreshold,
-		syncSourceURL:       syncSourceURL,
+		hardExpiry:          hardExpiry,
 		rates:               atomic.Value{},
 		lastUpdated:         atomic.Value{},
+		activeSource:        atomic.Value{},
+		health:              health,
 		constantRates:       NewConstantRates(),
 		time:                &timeutil.RealTime{},
 	}
 }
 
-// fetch allows to retrieve the currencies rates from the syncSourceURL provided
-func (rc *RateConverter) fetch() (*Rates, error) {
-	request, err := http.NewRequest("GET", rc.syncSourceURL, nil)
-	if err != nil {
-		return nil, err
-	}
+// SetMetricsEngine sets the engine recording the outcome of every source fetch.
+// It must be called before the converter starts running.
+func (rc *RateConverter) SetMetricsEngine(metricsEngine metrics.MetricsEngine) {
+	rc.metricsEngine = metricsEngine
+}
+
+// SetHistory sets the history recording every successfully fetched rates table.
+// It must be called before the converter starts running.
+func (rc *RateConverter) SetHistory(history *RatesHistory) {
+	rc.history = history
+}
 
-	response, err := rc.httpClient.Do(request)
-	if err != nil {
-		return nil, err
+// History returns the history of fetched rates tables, nil if the history is disabled
+func (rc *RateConverter) History() *RatesHistory {
+	return rc.history
+}
+
+// fetch queries the sources in order and returns the rates of the first one that succeeds
+func (rc *RateConverter) fetch() (*Rates, string, error) {
+	var errs []error
+	for i, source := range rc.sources {
+		rates, err := source.Fetch()
+		rc.recordFetch(i, err)
+		if err == nil {
+			return rates, source.Name(), nil
+		}
+		errs = append(errs, err)
 	}
 
-	if response.StatusCode >= 400 {
-		message := fmt.Sprintf("The currency rates request failed with status code %d", response.StatusCode)
-		return nil, &errortypes.BadServerResponse{Message: message}
+	switch len(errs) {
+	case 0:
+		return nil, "", errors.New("no currency rates source configured")
+	case 1:
+		return nil, "", errs[0]
+	default:
+		for i := range errs {
+			errs[i] = fmt.Errorf("%s: %v", rc.sources[i].Name(), errs[i])
+		}
+		return nil, "", errortypes.NewAggregateError("All currency rates sources failed", errs)
 	}
+}
 
-	defer response.Body.Close()
+// recordFetch updates the health of the source at index i and reports the fetch outcome to metrics
+func (rc *RateConverter) recordFetch(i int, err error) {
+	now := rc.time.Now()
 
-	bytesJSON, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
+	rc.healthMutex.Lock()
+	health := &rc.health[i]
+	name := health.Name
+	health.LastAttempt = now
+	if err == nil {
+		health.LastSuccess = now
+		health.LastError = ""
+		health.ConsecutiveFailures = 0
+	} else {
+		health.LastError = err.Error()
+		health.ConsecutiveFailures++
 	}
+	rc.healthMutex.Unlock()
 
-	updatedRates := &Rates{}
-	err = jsonutil.UnmarshalValid(bytesJSON, updatedRates)
-	if err != nil {
-		return nil, err
+	if rc.metricsEngine != nil {
+		rc.metricsEngine.RecordCurrencyRatesFetch(name, err == nil)
 	}
-
-	return updatedRates, err
 }
 
 // Update updates the internal currencies rates from remote sources
 func (rc *RateConverter) update() error {
-	rates, err := rc.fetch()
+	rates, source, err := rc.fetch()
 	if err == nil {
+		now := rc.time.Now()
 		rc.rates.Store(rates)
-		rc.lastUpdated.Store(rc.time.Now())
+		rc.lastUpdated.Store(now)
+		rc.activeSource.Store(source)
+		if rc.history != nil {
+			if historyErr := rc.history.Add(now, source, rates); historyErr != nil {
+				glog.Errorf("Error recording conversion rates history: %v", historyErr)
+			}
+		}
 	} else {
-		if rc.checkStaleRates() {
+		if rc.checkExpiredRates() {
 			rc.clearRates()
 			glog.Errorf("Error updating conversion rates, falling back to constant rates: %v", err)
+		} else if rc.checkStaleRates() {
+			glog.Errorf("Error updating conversion rates, using stale last known good rates: %v", err)
 		} else {
 			glog.Errorf("Error updating conversion rates: %v", err)
 		}
@@ -122,28 +216,78 @@ func (rc *RateConverter) clearRates() {
 
 // checkStaleRates checks if loaded third party conversion rates are stale
 func (rc *RateConverter) checkStaleRates() bool {
-	if rc.staleRatesThreshold <= 0 {
+	return rc.olderThan(rc.staleRatesThreshold)
+}
+
+// checkExpiredRates checks if loaded third party conversion rates are too old to be used at all
+func (rc *RateConverter) checkExpiredRates() bool {
+	return rc.olderThan(rc.hardExpiry)
+}
+
+func (rc *RateConverter) olderThan(threshold time.Duration) bool {
+	if threshold <= 0 {
 		return false
 	}
 
 	currentTime := rc.time.Now().UTC()
 	if lastUpdated := rc.lastUpdated.Load(); lastUpdated != nil {
 		delta := currentTime.Sub(lastUpdated.(time.Time).UTC())
-		if delta.Seconds() > rc.staleRatesThreshold.Seconds() {
+		if delta.Seconds() > threshold.Seconds() {
 			return true
 		}
 	}
 	return false
 }
 
+// SourcesHealth returns a snapshot of the health of every configured source, in query order
+func (rc *RateConverter) SourcesHealth() []SourceHealth {
+	rc.healthMutex.RLock()
+	defer rc.healthMutex.RUnlock()
+
+	health := make([]SourceHealth, len(rc.health))
+	copy(health, rc.health)
+	return health
+}
+
+// CheckHealth fails when no rates were fetched yet or when the rates are stale
+func (rc *RateConverter) CheckHealth(ctx context.Context) error {
+	lastUpdated := rc.LastUpdated()
+	if lastUpdated.IsZero() {
+		return errors.New("currency rates have not been fetched yet")
+	}
+	if rc.checkStaleRates() {
+		return fmt.Errorf("currency rates are stale, last updated at %s", lastUpdated.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
 // GetInfo returns setup information about the converter
 func (rc *RateConverter) GetInfo() ConverterInfo {
 	var rates *map[string]map[string]float64 = rc.Rates().GetRates()
 	return converterInfo{
-		source:      rc.syncSourceURL,
+		source:      rc.source(),
 		lastUpdated: rc.LastUpdated(),
 		rates:       rates,
+		additionalInfo: rateConverterInfo{
+			Stale:   rc.checkStaleRates(),
+			Sources: rc.SourcesHealth(),
+		},
+	}
+}
+
+// source returns the name of the source the current rates were fetched from, defaulting
+// to the first configured source when no rates have been fetched yet
+func (rc *RateConverter) source() string {
+	if rc.syncSourceURL != "" {
+		return rc.syncSourceURL
+	}
+	if source, ok := rc.activeSource.Load().(string); ok {
+		return source
+	}
+	if len(rc.sources) > 0 {
+		return rc.sources[0].Name()
 	}
+	return ""
 }
 
 type httpClient interface {
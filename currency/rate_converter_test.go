@@ -1,6 +1,8 @@
 package currency
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prebid/prebid-server/v3/metrics"
 	"github.com/prebid/prebid-server/v3/util/task"
 	"github.com/stretchr/testify/assert"
 )
@@ -377,3 +380,164 @@ func (m *mockHttpClient) Do(req *http.Request) (*http.Response, error) {
 		Body:       io.NopCloser(strings.NewReader(m.responseBody)),
 	}, nil
 }
+
+// mockRateSource returns the queued results of successive Fetch calls, repeating the last one
+type mockRateSource struct {
+	name    string
+	results []mockRateSourceResult
+	calls   int
+}
+
+type mockRateSourceResult struct {
+	rates *Rates
+	err   error
+}
+
+func (m *mockRateSource) Name() string {
+	return m.name
+}
+
+func (m *mockRateSource) Fetch() (*Rates, error) {
+	result := m.results[min(m.calls, len(m.results)-1)]
+	m.calls++
+	return result.rates, result.err
+}
+
+func TestRateConverterInfoSource(t *testing.T) {
+	mockedHttpServer := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			rw.Write(getMockRates())
+		}),
+	)
+	defer mockedHttpServer.Close()
+
+	metricsMock := &metrics.MetricsEngineMock{}
+	metricsMock.On("RecordCurrencyRatesFetch", DefaultRateSourceName, true).Once()
+
+	currencyConverter := NewRateConverter(&http.Client{}, mockedHttpServer.URL, 24*time.Hour)
+	currencyConverter.SetMetricsEngine(metricsMock)
+	assert.Equal(t, mockedHttpServer.URL, currencyConverter.GetInfo().Source(), "The URL should be reported as the source before the rates are fetched.")
+
+	assert.NoError(t, currencyConverter.Run())
+	assert.Equal(t, mockedHttpServer.URL, currencyConverter.GetInfo().Source())
+	assert.Equal(t, DefaultRateSourceName, currencyConverter.SourcesHealth()[0].Name)
+	metricsMock.AssertExpectations(t)
+}
+
+func TestMultiSourceFallback(t *testing.T) {
+	primaryRates := NewRates(map[string]map[string]float64{"USD": {"PLN": 4.01}})
+	secondaryRates := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.32}})
+
+	primary := &mockRateSource{
+		name: "primary",
+		results: []mockRateSourceResult{
+			{err: errors.New("primary down")},
+			{rates: primaryRates},
+		},
+	}
+	secondary := &mockRateSource{
+		name:    "secondary",
+		results: []mockRateSourceResult{{rates: secondaryRates}},
+	}
+
+	fakeTime := &FakeTime{time: time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)}
+	currencyConverter := NewMultiSourceRateConverter([]RateSource{primary, secondary}, time.Hour, 24*time.Hour)
+	currencyConverter.time = fakeTime
+
+	// First call falls back to the secondary source
+	assert.NoError(t, currencyConverter.Run())
+	assert.Equal(t, secondaryRates, currencyConverter.Rates())
+	assert.Equal(t, "secondary", currencyConverter.GetInfo().Source())
+	assert.Equal(t, []SourceHealth{
+		{Name: "primary", LastAttempt: fakeTime.time, ConsecutiveFailures: 1, LastError: "primary down"},
+		{Name: "secondary", LastAttempt: fakeTime.time, LastSuccess: fakeTime.time},
+	}, currencyConverter.SourcesHealth())
+
+	// Second call is served by the recovered primary source, the secondary one is not queried
+	assert.NoError(t, currencyConverter.Run())
+	assert.Equal(t, primaryRates, currencyConverter.Rates())
+	assert.Equal(t, "primary", currencyConverter.GetInfo().Source())
+	assert.Equal(t, 1, secondary.calls)
+	assert.Equal(t, 0, currencyConverter.SourcesHealth()[0].ConsecutiveFailures)
+}
+
+func TestMultiSourceHardExpiry(t *testing.T) {
+	expectedRates := NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.32}})
+	source := &mockRateSource{
+		name: "source",
+		results: []mockRateSourceResult{
+			{rates: expectedRates},
+			{err: errors.New("source down")},
+		},
+	}
+
+	initialFakeTime := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fakeTime := &FakeTime{time: initialFakeTime}
+	currencyConverter := NewMultiSourceRateConverter([]RateSource{source}, time.Minute, time.Hour)
+	currencyConverter.time = fakeTime
+
+	assert.NoError(t, currencyConverter.Run())
+
+	// Past the stale threshold the last known good rates are still served but flagged as stale
+	fakeTime.time = initialFakeTime.Add(30 * time.Minute)
+	assert.Error(t, currencyConverter.Run())
+	assert.Equal(t, expectedRates, currencyConverter.Rates())
+	assert.Equal(t, rateConverterInfo{
+		Stale: true,
+		Sources: []SourceHealth{
+			{Name: "source", LastAttempt: fakeTime.time, LastSuccess: initialFakeTime, LastError: "source down", ConsecutiveFailures: 1},
+		},
+	}, currencyConverter.GetInfo().AdditionalInfo())
+
+	// Past the hard expiry the rates are discarded
+	fakeTime.time = initialFakeTime.Add(61 * time.Minute)
+	assert.Error(t, currencyConverter.Run())
+	assert.Equal(t, &ConstantRates{}, currencyConverter.Rates())
+	assert.Equal(t, initialFakeTime, currencyConverter.LastUpdated())
+}
+
+func TestMultiSourceMetrics(t *testing.T) {
+	failing := &mockRateSource{name: "failing", results: []mockRateSourceResult{{err: errors.New("down")}}}
+	working := &mockRateSource{name: "working", results: []mockRateSourceResult{{rates: NewRates(nil)}}}
+
+	metricsMock := &metrics.MetricsEngineMock{}
+	metricsMock.On("RecordCurrencyRatesFetch", "failing", false).Once()
+	metricsMock.On("RecordCurrencyRatesFetch", "working", true).Once()
+
+	currencyConverter := NewMultiSourceRateConverter([]RateSource{failing, working}, 0, 0)
+	currencyConverter.SetMetricsEngine(metricsMock)
+
+	assert.NoError(t, currencyConverter.Run())
+	metricsMock.AssertExpectations(t)
+}
+
+func TestMultiSourceAllFailing(t *testing.T) {
+	first := &mockRateSource{name: "first", results: []mockRateSourceResult{{err: errors.New("first down")}}}
+	second := &mockRateSource{name: "second", results: []mockRateSourceResult{{err: errors.New("second down")}}}
+
+	currencyConverter := NewMultiSourceRateConverter([]RateSource{first, second}, 0, 0)
+
+	err := currencyConverter.Run()
+	assert.EqualError(t, err, "All currency rates sources failed (2 errors):\n  1: first: first down\n  2: second: second down\n")
+	assert.Equal(t, &ConstantRates{}, currencyConverter.Rates())
+}
+
+func TestCheckHealth(t *testing.T) {
+	source := &mockRateSource{
+		name:    "source",
+		results: []mockRateSourceResult{{rates: NewRates(nil)}},
+	}
+
+	initialFakeTime := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	fakeTime := &FakeTime{time: initialFakeTime}
+	currencyConverter := NewMultiSourceRateConverter([]RateSource{source}, time.Minute, time.Hour)
+	currencyConverter.time = fakeTime
+
+	assert.EqualError(t, currencyConverter.CheckHealth(context.Background()), "currency rates have not been fetched yet")
+
+	assert.NoError(t, currencyConverter.Run())
+	assert.NoError(t, currencyConverter.CheckHealth(context.Background()))
+
+	fakeTime.time = initialFakeTime.Add(2 * time.Minute)
+	assert.EqualError(t, currencyConverter.CheckHealth(context.Background()), "currency rates are stale, last updated at 2024-03-01T10:00:00Z")
+}
@@ -0,0 +1,176 @@
+package currency
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// RateSource fetches a full table of currency conversion rates from a single origin.
+// The RateConverter queries its sources in order and uses the first one that succeeds.
+type RateSource interface {
+	// Name identifies the source in logs, metrics and the /currency/rates admin endpoint,
+	// so it must be usable in metric names
+	Name() string
+	Fetch() (*Rates, error)
+}
+
+// httpRateSource fetches rates in the prebid currency file JSON format,
+// see https://cdn.jsdelivr.net/gh/prebid/currency-file@1/latest.json
+type httpRateSource struct {
+	name       string
+	httpClient httpClient
+	url        string
+}
+
+// NewHTTPRateSource returns a RateSource reading the prebid currency file JSON format from url
+func NewHTTPRateSource(name string, httpClient httpClient, url string) RateSource {
+	return &httpRateSource{
+		name:       name,
+		httpClient: httpClient,
+		url:        url,
+	}
+}
+
+func (s *httpRateSource) Name() string {
+	return s.name
+}
+
+func (s *httpRateSource) Fetch() (*Rates, error) {
+	bytesJSON, err := httpGet(s.httpClient, s.url)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRates := &Rates{}
+	err = jsonutil.UnmarshalValid(bytesJSON, updatedRates)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedRates, err
+}
+
+// ecbRateSource fetches rates from the European Central Bank daily reference rates XML feed,
+// see https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml
+type ecbRateSource struct {
+	name       string
+	httpClient httpClient
+	url        string
+}
+
+// NewECBRateSource returns a RateSource reading the European Central Bank XML format from url.
+// All rates published by the ECB are quoted against EUR.
+func NewECBRateSource(name string, httpClient httpClient, url string) RateSource {
+	return &ecbRateSource{
+		name:       name,
+		httpClient: httpClient,
+		url:        url,
+	}
+}
+
+func (s *ecbRateSource) Name() string {
+	return s.name
+}
+
+func (s *ecbRateSource) Fetch() (*Rates, error) {
+	bytesXML, err := httpGet(s.httpClient, s.url)
+	if err != nil {
+		return nil, err
+	}
+	return parseECBRates(bytesXML)
+}
+
+// ecbEnvelope mirrors the subset of the ECB eurofxref document needed to build a rates table
+type ecbEnvelope struct {
+	Cube struct {
+		Cube []struct {
+			Time string `xml:"time,attr"`
+			Cube []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func parseECBRates(data []byte) (*Rates, error) {
+	var envelope ecbEnvelope
+	if err := xml.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	if len(envelope.Cube.Cube) == 0 {
+		return nil, &errortypes.BadServerResponse{Message: "The ECB currency rates response contains no rates"}
+	}
+
+	// The daily feed has a single dated cube, historical feeds list the most recent day first
+	eurRates := make(map[string]float64, len(envelope.Cube.Cube[0].Cube))
+	for _, entry := range envelope.Cube.Cube[0].Cube {
+		rate, err := strconv.ParseFloat(entry.Rate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB rate for %s: %v", entry.Currency, err)
+		}
+		eurRates[entry.Currency] = rate
+	}
+
+	return NewRates(map[string]map[string]float64{"EUR": eurRates}), nil
+}
+
+// fileRateSource reads rates in the prebid currency file JSON format from the local file system.
+// It is typically configured as the last source of the chain to provide a static fallback.
+type fileRateSource struct {
+	name string
+	path string
+}
+
+// NewFileRateSource returns a RateSource reading the prebid currency file JSON format from path
+func NewFileRateSource(name string, path string) RateSource {
+	return &fileRateSource{
+		name: name,
+		path: path,
+	}
+}
+
+func (s *fileRateSource) Name() string {
+	return s.name
+}
+
+func (s *fileRateSource) Fetch() (*Rates, error) {
+	bytesJSON, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := &Rates{}
+	if err := jsonutil.UnmarshalValid(bytesJSON, rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func httpGet(client httpClient, url string) ([]byte, error) {
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		message := fmt.Sprintf("The currency rates request failed with status code %d", response.StatusCode)
+		return nil, &errortypes.BadServerResponse{Message: message}
+	}
+
+	return io.ReadAll(response.Body)
+}
@@ -0,0 +1,127 @@
+package currency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestECBRateSource(t *testing.T) {
+	testCases := []struct {
+		description   string
+		giveStatus    int
+		giveResponse  string
+		expectedRates *Rates
+		expectedErr   bool
+	}{
+		{
+			description: "valid_daily_feed",
+			giveStatus:  http.StatusOK,
+			giveResponse: `<?xml version="1.0" encoding="UTF-8"?>
+				<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+					<gesmes:subject>Reference rates</gesmes:subject>
+					<Cube>
+						<Cube time="2024-03-01">
+							<Cube currency="USD" rate="1.0826"/>
+							<Cube currency="PLN" rate="4.3183"/>
+						</Cube>
+					</Cube>
+				</gesmes:Envelope>`,
+			expectedRates: NewRates(map[string]map[string]float64{"EUR": {"USD": 1.0826, "PLN": 4.3183}}),
+		},
+		{
+			description:  "no_rates",
+			giveStatus:   http.StatusOK,
+			giveResponse: `<Envelope><Cube></Cube></Envelope>`,
+			expectedErr:  true,
+		},
+		{
+			description:  "invalid_rate",
+			giveStatus:   http.StatusOK,
+			giveResponse: `<Envelope><Cube><Cube time="2024-03-01"><Cube currency="USD" rate="abc"/></Cube></Cube></Envelope>`,
+			expectedErr:  true,
+		},
+		{
+			description:  "invalid_xml",
+			giveStatus:   http.StatusOK,
+			giveResponse: `{"conversions":{}}`,
+			expectedErr:  true,
+		},
+		{
+			description: "server_error",
+			giveStatus:  http.StatusInternalServerError,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tc.giveStatus)
+				rw.Write([]byte(tc.giveResponse))
+			}))
+			defer server.Close()
+
+			source := NewECBRateSource("ecb", &http.Client{}, server.URL)
+			rates, err := source.Fetch()
+
+			assert.Equal(t, "ecb", source.Name())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRates, rates)
+			}
+		})
+	}
+}
+
+func TestFileRateSource(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "rates.json")
+	invalidPath := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(validPath, getMockRates(), 0644))
+	assert.NoError(t, os.WriteFile(invalidPath, []byte(`{"conversions": invalid}`), 0644))
+
+	testCases := []struct {
+		description   string
+		givePath      string
+		expectedRates *Rates
+		expectedErr   bool
+	}{
+		{
+			description:   "valid_file",
+			givePath:      validPath,
+			expectedRates: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77208}, "GBP": {"USD": 1.2952}}),
+		},
+		{
+			description: "invalid_json",
+			givePath:    invalidPath,
+			expectedErr: true,
+		},
+		{
+			description: "missing_file",
+			givePath:    filepath.Join(dir, "missing.json"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			source := NewFileRateSource("fallback", tc.givePath)
+			rates, err := source.Fetch()
+
+			assert.Equal(t, "fallback", source.Name())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRates, rates)
+			}
+		})
+	}
+}
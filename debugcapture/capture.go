@@ -0,0 +1,98 @@
+package debugcapture
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+)
+
+// Record is the debug data captured for an auction, as if the request had asked for it with
+// ext.prebid.debug and the account and the bidders allowed it.
+type Record struct {
+	Time            time.Time                                             `json:"time"`
+	AccountID       string                                                `json:"account_id"`
+	RequestID       string                                                `json:"request_id"`
+	ResolvedRequest json.RawMessage                                       `json:"resolvedrequest,omitempty"`
+	HttpCalls       map[openrtb_ext.BidderName][]*openrtb_ext.ExtHttpCall `json:"httpcalls,omitempty"`
+	Response        json.RawMessage                                       `json:"response,omitempty"`
+}
+
+// Sink receives the captured records and keeps the latest ones in memory for the admin endpoints
+type Sink interface {
+	Write(record Record) error
+	// Records returns the records kept in memory, oldest first
+	Records() []Record
+}
+
+// Capturer decides which auctions have their debug data captured, from the sample rates set per
+// account at runtime, and hands the captured records to its sink.
+type Capturer struct {
+	mutex       sync.RWMutex
+	sampleRates map[string]float64
+	random      func() float64
+	sink        Sink
+}
+
+func NewCapturer(sink Sink) *Capturer {
+	return &Capturer{
+		sampleRates: make(map[string]float64),
+		random:      rand.Float64,
+		sink:        sink,
+	}
+}
+
+// SetSampleRate sets the fraction of the auctions of the account to capture. A rate of 0 stops the
+// capture for the account.
+func (c *Capturer) SetSampleRate(accountID string, rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("sample rate must be between 0 and 1. Got %f", rate)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if rate == 0 {
+		delete(c.sampleRates, accountID)
+	} else {
+		c.sampleRates[accountID] = rate
+	}
+	return nil
+}
+
+// SampleRates returns the sample rates of the accounts being captured
+func (c *Capturer) SampleRates() map[string]float64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return maps.Clone(c.sampleRates)
+}
+
+// Sample tells whether the debug data of an auction of the account should be captured
+func (c *Capturer) Sample(accountID string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mutex.RLock()
+	rate, ok := c.sampleRates[accountID]
+	c.mutex.RUnlock()
+
+	return ok && c.random() < rate
+}
+
+func (c *Capturer) Record(record Record) {
+	if err := c.sink.Write(record); err != nil {
+		glog.Errorf("Failed to write the debug capture of request %s: %v", record.RequestID, err)
+	}
+}
+
+// Sink returns the sink the records are written to
+func (c *Capturer) Sink() Sink {
+	return c.sink
+}
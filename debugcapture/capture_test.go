@@ -0,0 +1,37 @@
+package debugcapture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapturerSample(t *testing.T) {
+	capturer := NewCapturer(NewRingBuffer(1))
+	capturer.random = func() float64 { return 0.5 }
+
+	assert.NoError(t, capturer.SetSampleRate("sampled", 0.6))
+	assert.NoError(t, capturer.SetSampleRate("rarely-sampled", 0.4))
+
+	assert.True(t, capturer.Sample("sampled"))
+	assert.False(t, capturer.Sample("rarely-sampled"))
+	assert.False(t, capturer.Sample("unknown"))
+	assert.Equal(t, map[string]float64{"sampled": 0.6, "rarely-sampled": 0.4}, capturer.SampleRates())
+
+	assert.NoError(t, capturer.SetSampleRate("sampled", 0))
+	assert.False(t, capturer.Sample("sampled"))
+	assert.Equal(t, map[string]float64{"rarely-sampled": 0.4}, capturer.SampleRates())
+}
+
+func TestCapturerSetSampleRateInvalid(t *testing.T) {
+	capturer := NewCapturer(NewRingBuffer(1))
+
+	assert.EqualError(t, capturer.SetSampleRate("account", 1.5), "sample rate must be between 0 and 1. Got 1.500000")
+	assert.EqualError(t, capturer.SetSampleRate("account", -0.1), "sample rate must be between 0 and 1. Got -0.100000")
+	assert.Empty(t, capturer.SampleRates())
+}
+
+func TestNilCapturerSample(t *testing.T) {
+	var capturer *Capturer
+	assert.False(t, capturer.Sample("account"))
+}
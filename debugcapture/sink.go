@@ -0,0 +1,153 @@
+package debugcapture
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/benbjohnson/clock"
+	"github.com/golang/glog"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/util/fileutil"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// NewSink returns the sink configured for the debug captures
+func NewSink(cfg config.DebugCapture) (Sink, error) {
+	if cfg.Sink == config.DebugCaptureSinkFile {
+		return NewFileSink(cfg, clock.New())
+	}
+	return NewRingBuffer(cfg.BufferSize), nil
+}
+
+// RingBuffer keeps the latest records in memory, the oldest ones are dropped once it's full
+type RingBuffer struct {
+	mutex   sync.Mutex
+	records []Record
+	next    int
+	full    bool
+}
+
+func NewRingBuffer(size int) *RingBuffer {
+	return &RingBuffer{records: make([]Record, size)}
+}
+
+func (b *RingBuffer) Write(record Record) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.records[b.next] = record
+	b.next = (b.next + 1) % len(b.records)
+	if b.next == 0 {
+		b.full = true
+	}
+	return nil
+}
+
+// Records returns the records held by the buffer, oldest first
+func (b *RingBuffer) Records() []Record {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.full {
+		return append([]Record(nil), b.records[:b.next]...)
+	}
+	records := make([]Record, 0, len(b.records))
+	records = append(records, b.records[b.next:]...)
+	return append(records, b.records[:b.next]...)
+}
+
+// FileSink keeps the latest records in memory, like the RingBuffer, and appends them to a rotated file, one
+// JSON document per line. The file is written from its own goroutine through a bounded queue, so the auctions
+// never wait on it: the records arriving while the queue is full are only kept in memory.
+type FileSink struct {
+	memory  *RingBuffer
+	queue   chan Record
+	writer  *fileutil.RotatingWriter
+	dropped atomic.Int64
+	done    chan struct{}
+	// closedMutex guards the queue against being written to after it's closed by Close
+	closedMutex sync.RWMutex
+	closed      bool
+}
+
+func NewFileSink(cfg config.DebugCapture, clock clock.Clock) (*FileSink, error) {
+	maxSize, maxAge, err := fileutil.ParseRotationLimits(cfg.Rotation.MaxSize, cfg.Rotation.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := fileutil.NewRotatingWriter(cfg.FilePath, maxSize, maxAge, cfg.Rotation.Compress, cfg.Rotation.MaxBackups, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileSink{
+		memory: NewRingBuffer(cfg.BufferSize),
+		queue:  make(chan Record, cfg.QueueSize),
+		writer: writer,
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *FileSink) run() {
+	defer close(s.done)
+
+	for record := range s.queue {
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			glog.Warningf("%d debug captures were not written to the file, its queue was full", dropped)
+		}
+		if line, err := jsonutil.Marshal(record); err != nil {
+			glog.Errorf("Failed to marshal the debug capture of request %s: %v", record.RequestID, err)
+		} else if err := s.writer.WriteLine(line); err != nil {
+			glog.Errorf("Failed to write the debug capture of request %s: %v", record.RequestID, err)
+		}
+		// flush once the queue is drained to batch the writes under load
+		if len(s.queue) == 0 {
+			if err := s.writer.Flush(); err != nil {
+				glog.Errorf("Failed to flush the debug captures: %v", err)
+			}
+		}
+	}
+
+	if err := s.writer.Close(); err != nil {
+		glog.Errorf("Failed to close the debug captures file: %v", err)
+	}
+}
+
+func (s *FileSink) Write(record Record) error {
+	s.memory.Write(record)
+
+	s.closedMutex.RLock()
+	defer s.closedMutex.RUnlock()
+	if s.closed {
+		return nil
+	}
+
+	select {
+	case s.queue <- record:
+	default:
+		s.dropped.Add(1)
+	}
+	return nil
+}
+
+// Records returns the records kept in memory, oldest first
+func (s *FileSink) Records() []Record {
+	return s.memory.Records()
+}
+
+// Close writes the queued records and closes the file. Records written afterwards are only kept in memory.
+func (s *FileSink) Close() {
+	s.closedMutex.Lock()
+	if s.closed {
+		s.closedMutex.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.queue)
+	s.closedMutex.Unlock()
+
+	<-s.done
+}
@@ -0,0 +1,116 @@
+package debugcapture
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBuffer(t *testing.T) {
+	testCases := []struct {
+		name            string
+		requestIDs      []string
+		expectedRecords []string
+	}{
+		{
+			name:            "empty",
+			expectedRecords: []string{},
+		},
+		{
+			name:            "not-full",
+			requestIDs:      []string{"1", "2"},
+			expectedRecords: []string{"1", "2"},
+		},
+		{
+			name:            "full",
+			requestIDs:      []string{"1", "2", "3"},
+			expectedRecords: []string{"1", "2", "3"},
+		},
+		{
+			name:            "wrapped",
+			requestIDs:      []string{"1", "2", "3", "4", "5"},
+			expectedRecords: []string{"3", "4", "5"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := NewRingBuffer(3)
+			for _, requestID := range test.requestIDs {
+				assert.NoError(t, buffer.Write(Record{RequestID: requestID}))
+			}
+
+			requestIDs := []string{}
+			for _, record := range buffer.Records() {
+				requestIDs = append(requestIDs, record.RequestID)
+			}
+			assert.Equal(t, test.expectedRecords, requestIDs)
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "captures.jsonl")
+	sink, err := NewFileSink(config.DebugCapture{
+		BufferSize: 10,
+		FilePath:   path,
+		QueueSize:  10,
+		Rotation:   config.FileLogsRotation{MaxSize: "1MB", MaxBackups: 1},
+	}, clock.NewMock())
+	require.NoError(t, err)
+
+	assert.NoError(t, sink.Write(Record{AccountID: "account", RequestID: "1"}))
+	assert.NoError(t, sink.Write(Record{AccountID: "account", RequestID: "2", ResolvedRequest: []byte(`{"id":"2"}`)}))
+	sink.Close()
+
+	// records written once closed are only kept in memory
+	assert.NoError(t, sink.Write(Record{AccountID: "account", RequestID: "3"}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"time":"0001-01-01T00:00:00Z","account_id":"account","request_id":"1"}
+{"time":"0001-01-01T00:00:00Z","account_id":"account","request_id":"2","resolvedrequest":{"id":"2"}}
+`, string(content))
+
+	requestIDs := []string{}
+	for _, record := range sink.Records() {
+		requestIDs = append(requestIDs, record.RequestID)
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, requestIDs)
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	clockMock := clock.NewMock()
+	sink, err := NewFileSink(config.DebugCapture{
+		BufferSize: 10,
+		FilePath:   filepath.Join(dir, "captures.jsonl"),
+		QueueSize:  10,
+		Rotation:   config.FileLogsRotation{MaxSize: "100B", MaxBackups: 1},
+	}, clockMock)
+	require.NoError(t, err)
+
+	for _, requestID := range []string{"1", "2", "3", "4"} {
+		assert.NoError(t, sink.Write(Record{AccountID: "account", RequestID: requestID}))
+	}
+	sink.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "captures*.jsonl"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2, "the current file and a single rotated one are kept")
+}
+
+func TestNewFileSinkInvalidRotation(t *testing.T) {
+	_, err := NewFileSink(config.DebugCapture{
+		BufferSize: 10,
+		FilePath:   filepath.Join(t.TempDir(), "captures.jsonl"),
+		QueueSize:  10,
+		Rotation:   config.FileLogsRotation{MaxSize: "large", MaxBackups: 1},
+	}, clock.NewMock())
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package endpoints
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/exchange"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+type bidderRegistry interface {
+	BidderInfos() config.BidderInfos
+	UpdateBidder(bidder string, update exchange.BidderConfigUpdate) (config.BidderInfo, error)
+	Reload(fsBidderInfos config.BidderInfos) error
+}
+
+// bidderConfig is the effective configuration of a bidder. The credentials of the bidder infos are
+// left out.
+type bidderConfig struct {
+	CoreBidder              string `json:"coreBidder"`
+	AliasOf                 string `json:"aliasOf,omitempty"`
+	Disabled                bool   `json:"disabled"`
+	Endpoint                string `json:"endpoint"`
+	ExtraInfo               string `json:"extra_info,omitempty"`
+	GVLVendorID             uint16 `json:"gvlVendorID,omitempty"`
+	EndpointCompression     string `json:"endpointCompression,omitempty"`
+	ModifyingVastXmlAllowed bool   `json:"modifyingVastXmlAllowed"`
+}
+
+func newBidderConfig(bidder string, info config.BidderInfo) bidderConfig {
+	coreBidder := bidder
+	if len(info.AliasOf) > 0 {
+		coreBidder = info.AliasOf
+	}
+	return bidderConfig{
+		CoreBidder:              coreBidder,
+		AliasOf:                 info.AliasOf,
+		Disabled:                info.Disabled,
+		Endpoint:                info.Endpoint,
+		ExtraInfo:               info.ExtraAdapterInfo,
+		GVLVendorID:             info.GVLVendorID,
+		EndpointCompression:     info.EndpointCompression,
+		ModifyingVastXmlAllowed: info.ModifyingVastXmlAllowed,
+	}
+}
+
+// NewBidderConfigsEndpoint returns the effective configuration of every bidder, including the aliases.
+func NewBidderConfigsEndpoint(registry bidderRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		infos := registry.BidderInfos()
+		configs := make(map[string]bidderConfig, len(infos))
+		for bidder, info := range infos {
+			configs[bidder] = newBidderConfig(bidder, info)
+		}
+		writeAdminResponse(w, "bidder configs", configs)
+	}
+}
+
+// NewBidderConfigEndpoint returns the effective configuration of the bidder of the path.
+func NewBidderConfigEndpoint(registry bidderRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bidder := r.PathValue("bidder")
+		bidderName, found := openrtb_ext.NormalizeBidderName(bidder)
+		if !found {
+			http.Error(w, "unknown bidder: "+bidder, http.StatusNotFound)
+			return
+		}
+		info, found := registry.BidderInfos()[string(bidderName)]
+		if !found {
+			http.Error(w, "unknown bidder: "+bidder, http.StatusNotFound)
+			return
+		}
+		writeAdminResponse(w, "bidder configs", newBidderConfig(string(bidderName), info))
+	}
+}
+
+// NewBidderConfigUpdateEndpoint enables or disables the bidder of the path, or changes its endpoint or
+// extra info, from a JSON body like {"disabled": true}. The adapters of the exchange are rebuilt.
+func NewBidderConfigUpdateEndpoint(registry bidderRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var update exchange.BidderConfigUpdate
+		if err := jsonutil.UnmarshalValid(body, &update); err != nil {
+			http.Error(w, "invalid bidder config update: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		bidder := r.PathValue("bidder")
+		info, err := registry.UpdateBidder(bidder, update)
+		if errors.Is(err, exchange.ErrUnknownBidder) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		glog.Infof("Bidder %s configuration updated at runtime", bidder)
+		bidderName, _ := openrtb_ext.NormalizeBidderName(bidder)
+		writeAdminResponse(w, "bidder configs", newBidderConfig(string(bidderName), info))
+	}
+}
+
+// NewBidderConfigReloadEndpoint reloads the bidder infos of the bidder-info directory and rebuilds the
+// adapters of the exchange. The bidder infos are left unchanged if the reloaded ones are invalid.
+func NewBidderConfigReloadEndpoint(registry bidderRegistry, loadBidderInfos func() (config.BidderInfos, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		fsBidderInfos, err := loadBidderInfos()
+		if err == nil {
+			err = registry.Reload(fsBidderInfos)
+		}
+		if err != nil {
+			glog.Errorf("Failed to reload the bidder infos: %v", err)
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		glog.Info("Bidder infos reloaded")
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeAdminResponse(w http.ResponseWriter, subject string, response interface{}) {
+	jsonOutput, err := jsonutil.Marshal(response)
+	if err != nil {
+		glog.Errorf("Critical error when trying to marshal the %s: %v", subject, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonOutput)
+}
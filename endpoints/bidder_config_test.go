@@ -0,0 +1,172 @@
+package endpoints
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/exchange"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBidderRegistry struct {
+	infos     config.BidderInfos
+	updates   map[string]exchange.BidderConfigUpdate
+	reloaded  config.BidderInfos
+	reloadErr error
+}
+
+func (r *fakeBidderRegistry) BidderInfos() config.BidderInfos {
+	return r.infos
+}
+
+func (r *fakeBidderRegistry) UpdateBidder(bidder string, update exchange.BidderConfigUpdate) (config.BidderInfo, error) {
+	info, ok := r.infos[strings.ToLower(bidder)]
+	if !ok {
+		return config.BidderInfo{}, fmt.Errorf("%w: %s", exchange.ErrUnknownBidder, bidder)
+	}
+	if update.Endpoint != nil && *update.Endpoint == "invalid" {
+		return config.BidderInfo{}, errors.New("The endpoint: invalid for appnexus is not a valid URL")
+	}
+	if update.Disabled != nil {
+		info.Disabled = *update.Disabled
+	}
+	r.updates[bidder] = update
+	return info, nil
+}
+
+func (r *fakeBidderRegistry) Reload(fsBidderInfos config.BidderInfos) error {
+	if r.reloadErr != nil {
+		return r.reloadErr
+	}
+	r.reloaded = fsBidderInfos
+	return nil
+}
+
+func newBidderConfigMux(registry bidderRegistry, loadBidderInfos func() (config.BidderInfos, error)) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /bidders/config", NewBidderConfigsEndpoint(registry))
+	mux.HandleFunc("GET /bidders/config/{bidder}", NewBidderConfigEndpoint(registry))
+	mux.HandleFunc("PATCH /bidders/config/{bidder}", NewBidderConfigUpdateEndpoint(registry))
+	mux.HandleFunc("POST /bidders/config/reload", NewBidderConfigReloadEndpoint(registry, loadBidderInfos))
+	return mux
+}
+
+func TestBidderConfigEndpoints(t *testing.T) {
+	infos := config.BidderInfos{
+		"appnexus": {Endpoint: "http://appnexus.com", ExtraAdapterInfo: "extra", AppSecret: "secret"},
+		"alias":    {Endpoint: "http://alias.com", AliasOf: "appnexus", Disabled: true},
+	}
+	fsBidderInfos := config.BidderInfos{"appnexus": {Endpoint: "http://reloaded.com"}}
+
+	testCases := []struct {
+		name             string
+		method           string
+		path             string
+		body             string
+		loadErr          error
+		reloadErr        error
+		expectedStatus   int
+		expectedBody     string
+		expectedReloaded config.BidderInfos
+	}{
+		{
+			name:           "list",
+			method:         "GET",
+			path:           "/bidders/config",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"alias":{"coreBidder":"appnexus","aliasOf":"appnexus","disabled":true,"endpoint":"http://alias.com","modifyingVastXmlAllowed":false},"appnexus":{"coreBidder":"appnexus","disabled":false,"endpoint":"http://appnexus.com","extra_info":"extra","modifyingVastXmlAllowed":false}}`,
+		},
+		{
+			name:           "detail",
+			method:         "GET",
+			path:           "/bidders/config/AppNexus",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"coreBidder":"appnexus","disabled":false,"endpoint":"http://appnexus.com","extra_info":"extra","modifyingVastXmlAllowed":false}`,
+		},
+		{
+			name:           "detail-unknown-bidder",
+			method:         "GET",
+			path:           "/bidders/config/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "unknown bidder: unknown\n",
+		},
+		{
+			name:           "update",
+			method:         "PATCH",
+			path:           "/bidders/config/appnexus",
+			body:           `{"disabled":true}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"coreBidder":"appnexus","disabled":true,"endpoint":"http://appnexus.com","extra_info":"extra","modifyingVastXmlAllowed":false}`,
+		},
+		{
+			name:           "update-malformed-body",
+			method:         "PATCH",
+			path:           "/bidders/config/appnexus",
+			body:           `{"disabled":"yes"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "update-invalid-config",
+			method:         "PATCH",
+			path:           "/bidders/config/appnexus",
+			body:           `{"endpoint":"invalid"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "The endpoint: invalid for appnexus is not a valid URL\n",
+		},
+		{
+			name:           "update-unknown-bidder",
+			method:         "PATCH",
+			path:           "/bidders/config/unknown",
+			body:           `{"disabled":true}`,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "unknown bidder: unknown\n",
+		},
+		{
+			name:             "reload",
+			method:           "POST",
+			path:             "/bidders/config/reload",
+			expectedStatus:   http.StatusNoContent,
+			expectedReloaded: fsBidderInfos,
+		},
+		{
+			name:           "reload-load-failure",
+			method:         "POST",
+			path:           "/bidders/config/reload",
+			loadErr:        errors.New("error loading bidders data"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   "error loading bidders data\n",
+		},
+		{
+			name:           "reload-invalid-bidder-infos",
+			method:         "POST",
+			path:           "/bidders/config/reload",
+			reloadErr:      errors.New("bidder openx is not known to this instance, adding bidders requires a restart"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   "bidder openx is not known to this instance, adding bidders requires a restart\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			registry := &fakeBidderRegistry{infos: infos, updates: map[string]exchange.BidderConfigUpdate{}, reloadErr: test.reloadErr}
+			loadBidderInfos := func() (config.BidderInfos, error) {
+				return fsBidderInfos, test.loadErr
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			newBidderConfigMux(registry, loadBidderInfos).ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatus, w.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, w.Body.String())
+			}
+			assert.Equal(t, test.expectedReloaded, registry.reloaded)
+		})
+	}
+}
@@ -57,9 +57,9 @@ func newCurrencyRatesInfo(rateConverter rateConverter, fetchingInterval time.Dur
 
 // NewCurrencyRatesEndpoint returns current currency rates applied by the PBS server.
 func NewCurrencyRatesEndpoint(rateConverter rateConverter, fetchingInterval time.Duration) http.HandlerFunc {
-	currencyRateInfo := newCurrencyRatesInfo(rateConverter, fetchingInterval)
-
 	return func(w http.ResponseWriter, _ *http.Request) {
+		// The info is built on every call so rates and sources health reflect the latest fetch
+		currencyRateInfo := newCurrencyRatesInfo(rateConverter, fetchingInterval)
 		jsonOutput, err := jsonutil.Marshal(currencyRateInfo)
 		if err != nil {
 			glog.Errorf("/currency/rates Critical error when trying to marshal currencyRateInfo: %v", err)
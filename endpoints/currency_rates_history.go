@@ -0,0 +1,67 @@
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// currencyRatesAtInfo holds the currency rates effective at a point in time.
+type currencyRatesAtInfo struct {
+	Active      bool                           `json:"active"`
+	At          *time.Time                     `json:"at,omitempty"`
+	EffectiveAt *time.Time                     `json:"effectiveAt,omitempty"`
+	Source      *string                        `json:"source,omitempty"`
+	Rates       *map[string]map[string]float64 `json:"rates,omitempty"`
+}
+
+// NewCurrencyRatesHistoryEndpoint returns the currency rates that were applied by the PBS server at the time given
+// by the RFC 3339 "at" query parameter, or at the current time if the parameter is missing. The history is nil
+// when it's disabled.
+func NewCurrencyRatesHistoryEndpoint(history *currency.RatesHistory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		info := currencyRatesAtInfo{}
+
+		if history != nil {
+			at := time.Now().UTC()
+			if param := r.URL.Query().Get("at"); param != "" {
+				parsed, err := time.Parse(time.RFC3339, param)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte("Invalid \"at\" query parameter, an RFC 3339 timestamp is expected: " + err.Error()))
+					return
+				}
+				at = parsed
+			}
+
+			info.Active = true
+			info.At = &at
+
+			entry, found := history.At(at)
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte("No currency rates had been fetched at " + at.Format(time.RFC3339)))
+				return
+			}
+
+			info.EffectiveAt = &entry.FetchedAt
+			info.Source = &entry.Source
+			if entry.Rates != nil {
+				info.Rates = entry.Rates.GetRates()
+			}
+		}
+
+		jsonOutput, err := jsonutil.Marshal(info)
+		if err != nil {
+			glog.Errorf("/currency/rates/history Critical error when trying to marshal currencyRatesAtInfo: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jsonOutput)
+	}
+}
@@ -0,0 +1,75 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyRatesHistoryEndpoint(t *testing.T) {
+	history, err := currency.NewRatesHistory(10, "")
+	assert.NoError(t, err)
+	history.Add(
+		time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+		"https://sync.test.com",
+		currency.NewRates(map[string]map[string]float64{"EUR": {"PLN": 4.31}}),
+	)
+
+	testCases := []struct {
+		description  string
+		giveHistory  *currency.RatesHistory
+		giveQuery    string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			description:  "history_disabled",
+			giveHistory:  nil,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"active": false}`,
+		},
+		{
+			description:  "rates_effective_at_timestamp",
+			giveHistory:  history,
+			giveQuery:    "?at=2024-03-01T12:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+				"active": true,
+				"at": "2024-03-01T12:00:00Z",
+				"effectiveAt": "2024-03-01T10:00:00Z",
+				"source": "https://sync.test.com",
+				"rates": {"EUR": {"PLN": 4.31}}
+			}`,
+		},
+		{
+			description:  "no_rates_at_timestamp",
+			giveHistory:  history,
+			giveQuery:    "?at=2024-03-01T09:00:00Z",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description:  "invalid_timestamp",
+			giveHistory:  history,
+			giveQuery:    "?at=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			handler := NewCurrencyRatesHistoryEndpoint(tc.giveHistory)
+			w := httptest.NewRecorder()
+
+			handler(w, httptest.NewRequest(http.MethodGet, "/currency/rates/history"+tc.giveQuery, nil))
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
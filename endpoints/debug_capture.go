@@ -0,0 +1,65 @@
+package endpoints
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/debugcapture"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+type debugCaptureSampling struct {
+	SampleRate float64 `json:"sample_rate"`
+}
+
+// NewDebugCaptureSamplingEndpoint returns the sample rates of the accounts having their auctions captured
+func NewDebugCaptureSamplingEndpoint(capturer *debugcapture.Capturer) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeAdminResponse(w, "debug capture sample rates", capturer.SampleRates())
+	}
+}
+
+// NewDebugCaptureSamplingUpdateEndpoint sets the fraction of the auctions of the account of the path
+// to capture, from a JSON body like {"sample_rate": 0.1}. A rate of 0 stops the capture.
+func NewDebugCaptureSamplingUpdateEndpoint(capturer *debugcapture.Capturer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var sampling debugCaptureSampling
+		if err := jsonutil.UnmarshalValid(body, &sampling); err != nil {
+			http.Error(w, "invalid debug capture sampling: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		account := r.PathValue("account")
+		if err := capturer.SetSampleRate(account, sampling.SampleRate); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		glog.Infof("Debug capture sample rate of account %s set to %f", account, sampling.SampleRate)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewDebugCaptureRecordsEndpoint returns the captures held in memory, oldest first, optionally
+// filtered by the account query parameter.
+func NewDebugCaptureRecordsEndpoint(capturer *debugcapture.Capturer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		records := capturer.Sink().Records()
+		if account := r.URL.Query().Get("account"); account != "" {
+			filtered := make([]debugcapture.Record, 0, len(records))
+			for _, record := range records {
+				if record.AccountID == account {
+					filtered = append(filtered, record)
+				}
+			}
+			records = filtered
+		}
+		writeAdminResponse(w, "debug captures", records)
+	}
+}
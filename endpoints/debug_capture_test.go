@@ -0,0 +1,123 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/debugcapture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDebugCaptureMux(capturer *debugcapture.Capturer) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/capture/accounts", NewDebugCaptureSamplingEndpoint(capturer))
+	mux.HandleFunc("PUT /debug/capture/accounts/{account}", NewDebugCaptureSamplingUpdateEndpoint(capturer))
+	mux.HandleFunc("GET /debug/capture/records", NewDebugCaptureRecordsEndpoint(capturer))
+	return mux
+}
+
+func TestDebugCaptureEndpoints(t *testing.T) {
+	testCases := []struct {
+		name                string
+		method              string
+		path                string
+		body                string
+		expectedStatus      int
+		expectedBody        string
+		expectedSampleRates map[string]float64
+	}{
+		{
+			name:                "list-sample-rates",
+			method:              "GET",
+			path:                "/debug/capture/accounts",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        `{"account1":0.5}`,
+			expectedSampleRates: map[string]float64{"account1": 0.5},
+		},
+		{
+			name:                "set-sample-rate",
+			method:              "PUT",
+			path:                "/debug/capture/accounts/account2",
+			body:                `{"sample_rate":0.1}`,
+			expectedStatus:      http.StatusNoContent,
+			expectedSampleRates: map[string]float64{"account1": 0.5, "account2": 0.1},
+		},
+		{
+			name:                "stop-capture",
+			method:              "PUT",
+			path:                "/debug/capture/accounts/account1",
+			body:                `{"sample_rate":0}`,
+			expectedStatus:      http.StatusNoContent,
+			expectedSampleRates: map[string]float64{},
+		},
+		{
+			name:                "invalid-sample-rate",
+			method:              "PUT",
+			path:                "/debug/capture/accounts/account1",
+			body:                `{"sample_rate":2}`,
+			expectedStatus:      http.StatusBadRequest,
+			expectedBody:        "sample rate must be between 0 and 1. Got 2.000000\n",
+			expectedSampleRates: map[string]float64{"account1": 0.5},
+		},
+		{
+			name:                "records",
+			method:              "GET",
+			path:                "/debug/capture/records",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        `[{"time":"0001-01-01T00:00:00Z","account_id":"account1","request_id":"1"},{"time":"0001-01-01T00:00:00Z","account_id":"account2","request_id":"2"}]`,
+			expectedSampleRates: map[string]float64{"account1": 0.5},
+		},
+		{
+			name:                "records-of-account",
+			method:              "GET",
+			path:                "/debug/capture/records?account=account2",
+			expectedStatus:      http.StatusOK,
+			expectedBody:        `[{"time":"0001-01-01T00:00:00Z","account_id":"account2","request_id":"2"}]`,
+			expectedSampleRates: map[string]float64{"account1": 0.5},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			capturer := debugcapture.NewCapturer(debugcapture.NewRingBuffer(10))
+			require.NoError(t, capturer.SetSampleRate("account1", 0.5))
+			capturer.Record(debugcapture.Record{AccountID: "account1", RequestID: "1"})
+			capturer.Record(debugcapture.Record{AccountID: "account2", RequestID: "2"})
+
+			w := httptest.NewRecorder()
+			newDebugCaptureMux(capturer).ServeHTTP(w, httptest.NewRequest(test.method, test.path, strings.NewReader(test.body)))
+
+			assert.Equal(t, test.expectedStatus, w.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, w.Body.String())
+			}
+			assert.Equal(t, test.expectedSampleRates, capturer.SampleRates())
+		})
+	}
+}
+
+func TestDebugCaptureRecordsEndpointFileSink(t *testing.T) {
+	sink, err := debugcapture.NewFileSink(config.DebugCapture{
+		BufferSize: 10,
+		FilePath:   filepath.Join(t.TempDir(), "captures.jsonl"),
+		QueueSize:  10,
+		Rotation:   config.FileLogsRotation{MaxSize: "1MB", MaxBackups: 1},
+	}, clock.NewMock())
+	require.NoError(t, err)
+	defer sink.Close()
+
+	capturer := debugcapture.NewCapturer(sink)
+	capturer.Record(debugcapture.Record{AccountID: "account", RequestID: "1"})
+
+	w := httptest.NewRecorder()
+	newDebugCaptureMux(capturer).ServeHTTP(w, httptest.NewRequest("GET", "/debug/capture/records", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"time":"0001-01-01T00:00:00Z","account_id":"account","request_id":"1"}]`, w.Body.String())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/stored_requests"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -136,7 +137,7 @@ func event(cfg *config.Configuration, fetcher stored_requests.AccountFetcher, ac
 		r    *http.Request
 	}{
 		name: "event",
-		h:    NewEventEndpoint(cfg, fetcher, nil, &metrics.MetricsEngineMock{}),
+		h:    NewEventEndpoint(cfg, fetcher, nil, &metrics.MetricsEngineMock{}, notifications.NilBidStore{}),
 		r:    httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a="+accountID, strings.NewReader("")),
 	}
 }
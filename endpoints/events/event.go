@@ -18,6 +18,7 @@ import (
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/privacy"
 	"github.com/prebid/prebid-server/v3/stored_requests"
 	"github.com/prebid/prebid-server/v3/util/httputil"
@@ -47,15 +48,17 @@ type eventEndpoint struct {
 	Cfg           *config.Configuration
 	TrackingPixel *httputil.Pixel
 	MetricsEngine metrics.MetricsEngine
+	BidStore      notifications.BidStore
 }
 
-func NewEventEndpoint(cfg *config.Configuration, accounts stored_requests.AccountFetcher, analytics analytics.Runner, me metrics.MetricsEngine) httprouter.Handle {
+func NewEventEndpoint(cfg *config.Configuration, accounts stored_requests.AccountFetcher, analytics analytics.Runner, me metrics.MetricsEngine, bidStore notifications.BidStore) httprouter.Handle {
 	ee := &eventEndpoint{
 		Accounts:      accounts,
 		Analytics:     analytics,
 		Cfg:           cfg,
 		TrackingPixel: &httputil.Pixel1x1PNG,
 		MetricsEngine: me,
+		BidStore:      bidStore,
 	}
 
 	return ee.Handle
@@ -123,6 +126,7 @@ func (e *eventEndpoint) Handle(w http.ResponseWriter, r *http.Request, _ httprou
 	e.Analytics.LogNotificationEventObject(&analytics.NotificationEvent{
 		Request: eventRequest,
 		Account: account,
+		Auction: e.BidStore.Find(eventRequest.AccountID, eventRequest.BidID),
 	}, activities)
 
 	// Add tracking pixel if format == image
@@ -12,10 +12,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/privacy"
 	"github.com/prebid/prebid-server/v3/stored_requests"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +27,7 @@ type eventsMockAnalyticsModule struct {
 	Fail    bool
 	Error   error
 	Invoked bool
+	Event   *analytics.NotificationEvent
 }
 
 func (e *eventsMockAnalyticsModule) LogAuctionObject(ao *analytics.AuctionObject, _ privacy.ActivityControl) {
@@ -62,6 +65,7 @@ func (e *eventsMockAnalyticsModule) LogNotificationEventObject(ne *analytics.Not
 		panic(e.Error)
 	}
 	e.Invoked = true
+	e.Event = ne
 }
 
 func (e *eventsMockAnalyticsModule) Shutdown() {}
@@ -123,7 +127,7 @@ func TestShouldReturnBadRequestWhenTypeIsMissing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?b=test", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -159,7 +163,7 @@ func TestShouldReturnBadRequestWhenTypeIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=test&b=t", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccounts, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccounts, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -195,7 +199,7 @@ func TestShouldReturnBadRequestWhenBidIdIsMissing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -231,7 +235,7 @@ func TestShouldReturnBadRequestWhenTimestampIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=q", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -267,7 +271,7 @@ func TestShouldReturnUnauthorizedWhenAccountIsMissing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -303,7 +307,7 @@ func TestShouldReturnBadRequestWhenFormatValueIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=q", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -339,7 +343,7 @@ func TestShouldReturnBadRequestWhenAnalyticsValueIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=4", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -378,7 +382,7 @@ func TestShouldNotPassEventToAnalyticsReporterWhenAccountNotFoundAndDefaultIsFal
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a=testacc", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -412,7 +416,7 @@ func TestShouldReturnBadRequestWhenIntegrationValueIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=bidId&f=b&ts=1000&x=1&a=accountId&bidder=bidder&int=Te$tIntegrationType", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -451,7 +455,7 @@ func TestShouldNotPassEventToAnalyticsReporterWhenAccountEventNotEnabled(t *test
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a=events_disabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -489,7 +493,7 @@ func TestShouldPassEventToAnalyticsReporterWhenAccountEventEnabled(t *testing.T)
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -499,6 +503,31 @@ func TestShouldPassEventToAnalyticsReporterWhenAccountEventEnabled(t *testing.T)
 	assert.Equal(t, true, mockAnalyticsModule.Invoked)
 }
 
+func TestShouldReconcileEventWithAuctionBid(t *testing.T) {
+	mockAccountsFetcher := &mockAccountsFetcher{}
+	mockAnalyticsModule := &eventsMockAnalyticsModule{}
+
+	cfg := &config.Configuration{
+		AccountDefaults: config.Account{},
+	}
+	cfg.MarshalAccountDefaults()
+
+	bid := &analytics.AuctionBid{AuctionID: "auction", BidID: "test", Bidder: "appnexus", Price: 1.5, Currency: "USD"}
+	bidStore := notifications.NewBidStore(config.EventReconciliation{Enabled: true, TTLSeconds: 60, MaxEntries: 10}, clock.NewMock())
+	bidStore.Save("events_enabled", bid)
+
+	req := httptest.NewRequest("GET", "/event?t=win&b=test&f=b&x=1&a=events_enabled", strings.NewReader(""))
+	recorder := httptest.NewRecorder()
+
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, bidStore)
+	e(recorder, req, nil)
+
+	assert.Equal(t, 204, recorder.Result().StatusCode)
+	if assert.NotNil(t, mockAnalyticsModule.Event) {
+		assert.Equal(t, bid, mockAnalyticsModule.Event.Auction)
+	}
+}
+
 func TestShouldNotPassEventToAnalyticsReporterWhenAnalyticsValueIsZero(t *testing.T) {
 
 	// mock AccountsFetcher
@@ -523,7 +552,7 @@ func TestShouldNotPassEventToAnalyticsReporterWhenAnalyticsValueIsZero(t *testin
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=0&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -557,7 +586,7 @@ func TestShouldRespondWithPixelAndContentTypeWhenRequestFormatIsImage(t *testing
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=i&x=1&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -598,7 +627,7 @@ func TestShouldRespondWithNoContentWhenRequestFormatIsNotDefined(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=imp&b=test&ts=1234&x=1&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 
 	// execute
 	e(recorder, req, nil)
@@ -815,7 +844,7 @@ func TestShouldReturnBadRequestWhenVTypeIsInvalid(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+		e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifications.NilBidStore{})
 		e(recorder, test.req, nil)
 
 		d, err := io.ReadAll(recorder.Result().Body)
@@ -0,0 +1,41 @@
+package endpoints
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/julienschmidt/httprouter"
+	"github.com/prebid/prebid-server/v3/health"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// NewHealthzEndpoint returns a handler which reports the state of every dependency check. It always
+// responds with a 200 so failing checks are only visible in the details of the report.
+func NewHealthzEndpoint(checks []health.Check, timeout time.Duration) httprouter.Handle {
+	return newHealthEndpoint(checks, timeout, false)
+}
+
+// NewReadyzEndpoint returns a handler which reports the state of every dependency check. It responds
+// with a 503 when a check gating the readiness fails.
+func NewReadyzEndpoint(checks []health.Check, timeout time.Duration) httprouter.Handle {
+	return newHealthEndpoint(checks, timeout, true)
+}
+
+func newHealthEndpoint(checks []health.Check, timeout time.Duration, gateReadiness bool) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		report := health.Run(r.Context(), checks, timeout)
+		jsonOutput, err := jsonutil.Marshal(report)
+		if err != nil {
+			glog.Errorf("%s Critical error when trying to marshal the health report: %v", r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if gateReadiness && report.Status == health.StatusFail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write(jsonOutput)
+	}
+}
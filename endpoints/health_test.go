@@ -0,0 +1,105 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/health"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	pass := health.CheckerFunc(func(ctx context.Context) error { return nil })
+	fail := health.CheckerFunc(func(ctx context.Context) error { return errors.New("unreachable") })
+
+	testCases := []struct {
+		name                 string
+		checks               []health.Check
+		expectedHealthzCode  int
+		expectedReadyzCode   int
+		expectedReportStatus health.Status
+		expectedCheckStatus  map[string]health.Status
+	}{
+		{
+			name:                 "no-checks",
+			expectedHealthzCode:  http.StatusOK,
+			expectedReadyzCode:   http.StatusOK,
+			expectedReportStatus: health.StatusPass,
+			expectedCheckStatus:  map[string]health.Status{},
+		},
+		{
+			name: "failing-check-not-gating-readiness",
+			checks: []health.Check{
+				{Name: health.CheckStoredRequests, Checker: pass, GatesReadiness: true},
+				{Name: health.CheckPrebidCache, Checker: fail},
+			},
+			expectedHealthzCode:  http.StatusOK,
+			expectedReadyzCode:   http.StatusOK,
+			expectedReportStatus: health.StatusPass,
+			expectedCheckStatus: map[string]health.Status{
+				health.CheckStoredRequests: health.StatusPass,
+				health.CheckPrebidCache:    health.StatusFail,
+			},
+		},
+		{
+			name: "failing-check-gating-readiness",
+			checks: []health.Check{
+				{Name: health.CheckStoredRequests, Checker: fail, GatesReadiness: true},
+				{Name: health.CheckPrebidCache, Checker: pass},
+			},
+			expectedHealthzCode:  http.StatusOK,
+			expectedReadyzCode:   http.StatusServiceUnavailable,
+			expectedReportStatus: health.StatusFail,
+			expectedCheckStatus: map[string]health.Status{
+				health.CheckStoredRequests: health.StatusFail,
+				health.CheckPrebidCache:    health.StatusPass,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			endpoints := map[string]struct {
+				handler      func(w http.ResponseWriter, r *http.Request)
+				expectedCode int
+			}{
+				"/healthz": {
+					handler: func(w http.ResponseWriter, r *http.Request) {
+						NewHealthzEndpoint(test.checks, time.Second)(w, r, nil)
+					},
+					expectedCode: test.expectedHealthzCode,
+				},
+				"/readyz": {
+					handler: func(w http.ResponseWriter, r *http.Request) {
+						NewReadyzEndpoint(test.checks, time.Second)(w, r, nil)
+					},
+					expectedCode: test.expectedReadyzCode,
+				},
+			}
+
+			for path, endpoint := range endpoints {
+				w := httptest.NewRecorder()
+				endpoint.handler(w, httptest.NewRequest("GET", path, nil))
+
+				assert.Equal(t, endpoint.expectedCode, w.Code, path)
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"), path)
+
+				var report health.Report
+				require.NoError(t, jsonutil.UnmarshalValid(w.Body.Bytes(), &report), path)
+				assert.Equal(t, test.expectedReportStatus, report.Status, path)
+
+				checkStatus := make(map[string]health.Status, len(report.Checks))
+				for name, result := range report.Checks {
+					checkStatus[name] = result.Status
+				}
+				assert.Equal(t, test.expectedCheckStatus, checkStatus, path)
+			}
+		})
+	}
+}
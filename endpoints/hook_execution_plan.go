@@ -0,0 +1,97 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+type planInspector interface {
+	Validate(plan config.HookExecutionPlan) []error
+	DryRun(ctx context.Context, endpoint, accountID string, plan *config.HookExecutionPlan) (hookexecution.PlanDescription, []error)
+}
+
+type hookExecutionPlanValidation struct {
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type hookExecutionPlanDryRunRequest struct {
+	Endpoint      string                    `json:"endpoint"`
+	AccountID     string                    `json:"account_id,omitempty"`
+	ExecutionPlan *config.HookExecutionPlan `json:"execution_plan,omitempty"`
+}
+
+type hookExecutionPlanDryRun struct {
+	Stages hookexecution.PlanDescription `json:"stages"`
+	Errors []string                      `json:"errors,omitempty"`
+}
+
+// NewHookExecutionPlanValidationEndpoint validates the hook execution plan of the body, in the format
+// of the account hooks.execution_plan, against the modules of this instance.
+func NewHookExecutionPlanValidationEndpoint(inspector planInspector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var plan config.HookExecutionPlan
+		if err := jsonutil.UnmarshalValid(body, &plan); err != nil {
+			http.Error(w, "invalid hook execution plan: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		errs := inspector.Validate(plan)
+		writeAdminResponse(w, "hook execution plan validation", hookExecutionPlanValidation{
+			Valid:  len(errs) == 0,
+			Errors: errorMessages(errs),
+		})
+	}
+}
+
+// NewHookExecutionPlanDryRunEndpoint returns the hooks which would run at every stage for the requests
+// of an account to an endpoint, from a body like {"endpoint": "/openrtb2/auction", "account_id": "1001"}.
+// An execution_plan in the body replaces the one of the account, and is validated.
+func NewHookExecutionPlanDryRunEndpoint(inspector planInspector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var dryRunRequest hookExecutionPlanDryRunRequest
+		if err := jsonutil.UnmarshalValid(body, &dryRunRequest); err != nil {
+			http.Error(w, "invalid hook execution plan dry run: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		stages, errs := inspector.DryRun(r.Context(), dryRunRequest.Endpoint, dryRunRequest.AccountID, dryRunRequest.ExecutionPlan)
+		if len(errs) > 0 {
+			http.Error(w, errors.Join(errs...).Error(), http.StatusBadRequest)
+			return
+		}
+
+		response := hookExecutionPlanDryRun{Stages: stages}
+		if dryRunRequest.ExecutionPlan != nil {
+			response.Errors = errorMessages(inspector.Validate(*dryRunRequest.ExecutionPlan))
+		}
+		writeAdminResponse(w, "hook execution plan dry run", response)
+	}
+}
+
+func errorMessages(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
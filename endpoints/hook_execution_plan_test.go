@@ -0,0 +1,101 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePlanInspector struct{}
+
+func (fakePlanInspector) Validate(plan config.HookExecutionPlan) []error {
+	if _, ok := plan.Endpoints["/openrtb2/video"]; ok {
+		return []error{errors.New("endpoint /openrtb2/video is not supported")}
+	}
+	return nil
+}
+
+func (fakePlanInspector) DryRun(_ context.Context, endpoint, accountID string, plan *config.HookExecutionPlan) (hookexecution.PlanDescription, []error) {
+	if accountID == "unknown" {
+		return nil, []error{errors.New("account not found")}
+	}
+	return hookexecution.PlanDescription{
+		hooks.StageEntrypoint: {{TimeoutMS: 5, Hooks: []hookexecution.PlannedHook{{ModuleCode: "foobar.body", HookImplCode: accountID}}}},
+	}, nil
+}
+
+func TestHookExecutionPlanEndpoints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "valid-plan",
+			path:           "/hooks/execution_plan/validate",
+			body:           `{"endpoints":{"/openrtb2/auction":{"stages":{}}}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"valid":true}`,
+		},
+		{
+			name:           "invalid-plan",
+			path:           "/hooks/execution_plan/validate",
+			body:           `{"endpoints":{"/openrtb2/video":{"stages":{}}}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"valid":false,"errors":["endpoint /openrtb2/video is not supported"]}`,
+		},
+		{
+			name:           "malformed-plan",
+			path:           "/hooks/execution_plan/validate",
+			body:           `{"endpoints":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "dry-run",
+			path:           "/hooks/execution_plan/dry_run",
+			body:           `{"endpoint":"/openrtb2/auction","account_id":"1001"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"stages":{"entrypoint":[{"timeout_ms":5,"hooks":[{"module_code":"foobar.body","hook_impl_code":"1001"}]}]}}`,
+		},
+		{
+			name:           "dry-run-invalid-proposed-plan",
+			path:           "/hooks/execution_plan/dry_run",
+			body:           `{"endpoint":"/openrtb2/auction","account_id":"1001","execution_plan":{"endpoints":{"/openrtb2/video":{"stages":{}}}}}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"stages":{"entrypoint":[{"timeout_ms":5,"hooks":[{"module_code":"foobar.body","hook_impl_code":"1001"}]}]},"errors":["endpoint /openrtb2/video is not supported"]}`,
+		},
+		{
+			name:           "dry-run-unknown-account",
+			path:           "/hooks/execution_plan/dry_run",
+			body:           `{"endpoint":"/openrtb2/auction","account_id":"unknown"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "account not found\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /hooks/execution_plan/validate", NewHookExecutionPlanValidationEndpoint(fakePlanInspector{}))
+			mux.HandleFunc("POST /hooks/execution_plan/dry_run", NewHookExecutionPlanDryRunEndpoint(fakePlanInspector{}))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("POST", test.path, strings.NewReader(test.body)))
+
+			assert.Equal(t, test.expectedStatus, w.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"flag"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// logLevel is the verbosity of the glog logs, set by the -v and -vmodule flags at startup
+type logLevel struct {
+	Verbosity *int    `json:"v,omitempty"`
+	VModule   *string `json:"vmodule,omitempty"`
+}
+
+// NewLogLevelEndpoint returns the verbosity of the logs
+func NewLogLevelEndpoint(flags *flag.FlagSet) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeLogLevel(w, flags)
+	}
+}
+
+// NewLogLevelUpdateEndpoint changes the verbosity of the logs from a JSON body like {"v": 3} or
+// {"vmodule": "exchange=3"}, until the next restart.
+func NewLogLevelUpdateEndpoint(flags *flag.FlagSet) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var update logLevel
+		if err := jsonutil.UnmarshalValid(body, &update); err != nil {
+			http.Error(w, "invalid log level: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if update.Verbosity != nil {
+			if *update.Verbosity < 0 {
+				http.Error(w, "v must be >= 0. Got "+strconv.Itoa(*update.Verbosity), http.StatusBadRequest)
+				return
+			}
+			if err := flags.Set("v", strconv.Itoa(*update.Verbosity)); err != nil {
+				http.Error(w, "invalid v: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if update.VModule != nil {
+			if err := flags.Set("vmodule", *update.VModule); err != nil {
+				http.Error(w, "invalid vmodule: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		glog.Infof("Log level changed at runtime to v=%s vmodule=%s", flags.Lookup("v").Value, flags.Lookup("vmodule").Value)
+		writeLogLevel(w, flags)
+	}
+}
+
+func writeLogLevel(w http.ResponseWriter, flags *flag.FlagSet) {
+	verbosity, err := strconv.Atoi(flags.Lookup("v").Value.String())
+	if err != nil {
+		glog.Errorf("Failed to read the log verbosity: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	vmodule := flags.Lookup("vmodule").Value.String()
+	writeAdminResponse(w, "log level", logLevel{Verbosity: &verbosity, VModule: &vmodule})
+}
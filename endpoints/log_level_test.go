@@ -0,0 +1,74 @@
+package endpoints
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelEndpoints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "get",
+			method:         "GET",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"v":1,"vmodule":""}`,
+		},
+		{
+			name:           "set-verbosity",
+			method:         "PUT",
+			body:           `{"v":3}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"v":3,"vmodule":""}`,
+		},
+		{
+			name:           "set-vmodule",
+			method:         "PUT",
+			body:           `{"vmodule":"exchange=3"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"v":1,"vmodule":"exchange=3"}`,
+		},
+		{
+			name:           "negative-verbosity",
+			method:         "PUT",
+			body:           `{"v":-1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "v must be >= 0. Got -1\n",
+		},
+		{
+			name:           "malformed-body",
+			method:         "PUT",
+			body:           `{"v":"high"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.Int("v", 1, "")
+			flags.String("vmodule", "", "")
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /log/level", NewLogLevelEndpoint(flags))
+			mux.HandleFunc("PUT /log/level", NewLogLevelUpdateEndpoint(flags))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(test.method, "/log/level", strings.NewReader(test.body)))
+
+			assert.Equal(t, test.expectedStatus, w.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/modules"
+)
+
+type moduleConfigUpdater interface {
+	UpdateHostConfig(ctx context.Context, id string, cfg json.RawMessage) error
+}
+
+// NewModuleConfigUpdateEndpoint passes the JSON body as the new host config of the module of the path,
+// given in the "vendor.module_name" format. Only modules implementing modules.ConfigUpdater accept it.
+func NewModuleConfigUpdateEndpoint(updater moduleConfigUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !json.Valid(body) {
+			http.Error(w, "invalid module config: the body must be a JSON document", http.StatusBadRequest)
+			return
+		}
+
+		module := r.PathValue("module")
+		err = updater.UpdateHostConfig(r.Context(), module, body)
+		switch {
+		case errors.Is(err, modules.ErrUnknownModule):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, modules.ErrConfigUpdateNotSupported):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			glog.Infof("Module %s configuration updated at runtime", module)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/modules"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConfigUpdaterModule struct {
+	cfg json.RawMessage
+}
+
+func (m *fakeConfigUpdaterModule) OnConfigUpdate(_ context.Context, _ string, cfg json.RawMessage) error {
+	if string(cfg) == `{"enabled":"yes"}` {
+		return errors.New("enabled must be a boolean")
+	}
+	m.cfg = cfg
+	return nil
+}
+
+func TestModuleConfigUpdateEndpoint(t *testing.T) {
+	testCases := []struct {
+		name           string
+		module         string
+		body           string
+		expectedStatus int
+		expectedConfig string
+	}{
+		{
+			name:           "updated",
+			module:         "acme.updater",
+			body:           `{"enabled":true}`,
+			expectedStatus: http.StatusNoContent,
+			expectedConfig: `{"enabled":true}`,
+		},
+		{
+			name:           "rejected-by-module",
+			module:         "acme.updater",
+			body:           `{"enabled":"yes"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "malformed-config",
+			module:         "acme.updater",
+			body:           `{"enabled":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown-module",
+			module:         "acme.unknown",
+			body:           `{}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "updates-not-supported",
+			module:         "acme.static",
+			body:           `{}`,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			updater := &fakeConfigUpdaterModule{}
+			manager := modules.NewManager(map[string]interface{}{"acme.updater": updater, "acme.static": struct{}{}})
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /hooks/modules/{module}/config", NewModuleConfigUpdateEndpoint(manager))
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/hooks/modules/"+test.module+"/config", strings.NewReader(test.body)))
+
+			assert.Equal(t, test.expectedStatus, recorder.Code, recorder.Body.String())
+			assert.Equal(t, test.expectedConfig, string(updater.cfg))
+		})
+	}
+}
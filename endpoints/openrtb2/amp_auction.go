@@ -179,6 +179,7 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 	}
 
 	ao.RequestWrapper = reqWrapper
+	labels.RequestID = reqWrapper.ID
 
 	ctx := context.Background()
 	var cancel context.CancelFunc
@@ -230,6 +231,7 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 		ao.Errors = append(ao.Errors, acctIDErrs...)
 		return
 	}
+	ao.Account = account
 
 	// Populate any "missing" OpenRTB fields with info from other sources, (e.g. HTTP request headers).
 	if errs := deps.setFieldsImplicitly(r, reqWrapper, account); len(errs) > 0 {
@@ -288,6 +290,8 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 		response = auctionResponse.BidResponse
 	}
 	ao.SeatNonBid = auctionResponse.GetSeatNonBid()
+	ao.CurrencyConversions = auctionResponse.GetCurrencyConversions()
+	ao.BidderCalls = auctionResponse.GetBidderCalls()
 	ao.AuctionResponse = response
 	rejectErr, isRejectErr := hookexecution.CastRejectErr(err)
 	if err != nil && !isRejectErr {
@@ -328,9 +332,19 @@ func rejectAmpRequest(
 	ao analytics.AmpObject,
 	errs []error,
 ) (metrics.Labels, analytics.AmpObject) {
+	ao.Errors = append(ao.Errors, rejectErr)
+	if rejectErr.Response != nil {
+		ao.Status = rejectErr.Response.StatusCode
+		if err := writeRejectResponse(w, hookExecutor, rejectErr.Response); err != nil {
+			labels.RequestStatus = metrics.RequestStatusNetworkErr
+			ao.Errors = append(ao.Errors, fmt.Errorf("/openrtb2/amp Failed to send response: %v", err))
+		}
+		ao.HookExecutionOutcome = hookExecutor.GetOutcomes()
+		return labels, ao
+	}
+
 	response := &openrtb2.BidResponse{NBR: openrtb3.NoBidReason(rejectErr.NBR).Ptr()}
 	ao.AuctionResponse = response
-	ao.Errors = append(ao.Errors, rejectErr)
 
 	return sendAmpResponse(w, hookExecutor, &exchange.AuctionResponse{BidResponse: response}, reqWrapper, account, labels, ao, errs)
 }
@@ -404,7 +418,8 @@ func sendAmpResponse(
 	ao.AmpTargetingValues = targets
 
 	// Fixes #231
-	enc := json.NewEncoder(w) // nosemgrep: json-encoder-needs-type
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body) // nosemgrep: json-encoder-needs-type
 	enc.SetEscapeHTML(false)
 	// Explicitly set content type to text/plain, which had previously been
 	// the implied behavior from the time the project was launched.
@@ -412,10 +427,17 @@ func sendAmpResponse(
 	// nevertheless we will keep it as such for compatibility reasons.
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 
-	// If an error happens when encoding the response, there isn't much we can do.
+	// If an error happens when encoding or writing the response, there isn't much we can do.
 	// If we've sent _any_ bytes, then Go would have sent the 200 status code first.
 	// That status code can't be un-sent... so the best we can do is log the error.
-	if err := enc.Encode(ampResponse); err != nil {
+	err := enc.Encode(ampResponse)
+	if err == nil {
+		err = writeResponse(w, hookExecutor, http.StatusOK, body.Bytes())
+		if reqWrapper != nil {
+			ao.HookExecutionOutcome = hookExecutor.GetOutcomes()
+		}
+	}
+	if err != nil {
 		labels.RequestStatus = metrics.RequestStatusNetworkErr
 		ao.Errors = append(ao.Errors, fmt.Errorf("/openrtb2/amp Failed to send response: %v", err))
 	}
@@ -221,7 +221,7 @@ func TestAMPPageInfo(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -318,7 +318,7 @@ func TestGDPRConsent(t *testing.T) {
 				GDPR:           config.GDPR{Enabled: true},
 			},
 			&metricsConfig.NilMetricsEngine{},
-			analyticsBuild.New(&config.Analytics{}),
+			analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 			map[string]string{},
 			[]byte{},
 			openrtb_ext.BuildBidderMap(),
@@ -706,7 +706,7 @@ func TestCCPAConsent(t *testing.T) {
 			empty_fetcher.EmptyFetcher{},
 			&config.Configuration{MaxRequestSize: maxSize},
 			&metricsConfig.NilMetricsEngine{},
-			analyticsBuild.New(&config.Analytics{}),
+			analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 			map[string]string{},
 			[]byte{},
 			openrtb_ext.BuildBidderMap(),
@@ -813,7 +813,7 @@ func TestConsentWarnings(t *testing.T) {
 			empty_fetcher.EmptyFetcher{},
 			&config.Configuration{MaxRequestSize: maxSize},
 			&metricsConfig.NilMetricsEngine{},
-			analyticsBuild.New(&config.Analytics{}),
+			analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 			map[string]string{},
 			[]byte{},
 			openrtb_ext.BuildBidderMap(),
@@ -914,7 +914,7 @@ func TestNewAndLegacyConsentBothProvided(t *testing.T) {
 				GDPR:           config.GDPR{Enabled: true},
 			},
 			&metricsConfig.NilMetricsEngine{},
-			analyticsBuild.New(&config.Analytics{}),
+			analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 			map[string]string{},
 			[]byte{},
 			openrtb_ext.BuildBidderMap(),
@@ -962,7 +962,7 @@ func TestAMPSiteExt(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		nil,
 		nil,
 		openrtb_ext.BuildBidderMap(),
@@ -1027,7 +1027,7 @@ func TestAmpBadRequests(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -1118,7 +1118,7 @@ func TestAmpDebug(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -1254,7 +1254,7 @@ func TestQueryParamOverrides(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -1412,7 +1412,7 @@ func (s formatOverrideSpec) execute(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -1982,7 +1982,7 @@ func TestAmpAuctionResponseHeaders(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -2018,7 +2018,7 @@ func TestRequestWithTargeting(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		nil,
 		nil,
 		openrtb_ext.BuildBidderMap(),
@@ -2251,6 +2251,46 @@ func TestValidAmpResponseWhenRequestRejected(t *testing.T) {
 	}
 }
 
+func TestAmpRejectResponse(t *testing.T) {
+	const file = "sample-requests/hooks/amp_entrypoint_reject.json"
+	response := &hookstage.HTTPResponse{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       []byte(`{"error":"bot traffic"}`),
+	}
+
+	fileData, err := os.ReadFile(file)
+	require.NoError(t, err, "Failed to read test file.")
+
+	test := testCase{}
+	require.NoError(t, jsonutil.UnmarshalValid(fileData, &test), "Failed to parse test file.")
+
+	request := httptest.NewRequest("GET", fmt.Sprintf("/openrtb2/auction/amp?%s", test.Query), nil)
+	recorder := httptest.NewRecorder()
+	tagID := request.URL.Query().Get("tag_id")
+
+	test.StoredRequest = map[string]json.RawMessage{tagID: test.BidRequest}
+	test.planBuilder = mockPlanBuilder{entrypointPlan: makePlan[hookstage.Entrypoint](mockRejectResponseHook{response})}
+	test.endpointType = AMP_ENDPOINT
+
+	cfg := &config.Configuration{MaxRequestSize: maxSize}
+	ampEndpointHandler, _, mockBidServers, mockCurrencyRatesServer, err := buildTestEndpoint(test, cfg)
+	require.NoError(t, err, "Failed to build test endpoint.")
+	defer func() {
+		for _, mockBidServer := range mockBidServers {
+			mockBidServer.Close()
+		}
+		mockCurrencyRatesServer.Close()
+	}()
+
+	ampEndpointHandler(recorder, request, nil)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "Invalid status code.")
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"), "Hook header not added.")
+	assert.NotEmpty(t, recorder.Header().Get("X-Prebid"), "Endpoint header not preserved.")
+	assert.JSONEq(t, `{"error":"bot traffic"}`, recorder.Body.String(), "Invalid body.")
+}
+
 func TestSendAmpResponse_LogsErrors(t *testing.T) {
 	testCases := []struct {
 		description    string
@@ -2476,7 +2516,7 @@ func TestAmpAuctionDebugWarningsOnly(t *testing.T) {
 			},
 		},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -1,12 +1,14 @@
 package openrtb2
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -39,6 +41,7 @@ import (
 	"github.com/prebid/prebid-server/v3/exchange"
 	"github.com/prebid/prebid-server/v3/gdpr"
 	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/metrics"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/prebid_cache_client"
@@ -201,6 +204,7 @@ func (deps *endpointDeps) Auction(w http.ResponseWriter, r *http.Request, _ http
 		labels, ao = rejectAuctionRequest(*rejectErr, w, hookExecutor, req.BidRequest, account, labels, ao)
 		return
 	}
+	labels.RequestID = req.ID
 
 	tcf2Config := gdpr.NewTCF2Config(deps.cfg.GDPR.TCF2, account.GDPR)
 
@@ -275,6 +279,8 @@ func (deps *endpointDeps) Auction(w http.ResponseWriter, r *http.Request, _ http
 	}
 	ao.Response = response
 	ao.SeatNonBid = auctionResponse.GetSeatNonBid()
+	ao.CurrencyConversions = auctionResponse.GetCurrencyConversions()
+	ao.BidderCalls = auctionResponse.GetBidderCalls()
 	rejectErr, isRejectErr := hookexecution.CastRejectErr(err)
 	if err != nil && !isRejectErr {
 		if errortypes.ReadCode(err) == errortypes.BadInputErrorCode {
@@ -336,13 +342,22 @@ func rejectAuctionRequest(
 	labels metrics.Labels,
 	ao analytics.AuctionObject,
 ) (metrics.Labels, analytics.AuctionObject) {
+	ao.Errors = append(ao.Errors, rejectErr)
+	if rejectErr.Response != nil {
+		ao.Status = rejectErr.Response.StatusCode
+		if err := writeRejectResponse(w, hookExecutor, rejectErr.Response); err != nil {
+			labels.RequestStatus = metrics.RequestStatusNetworkErr
+			ao.Errors = append(ao.Errors, fmt.Errorf("/openrtb2/auction Failed to send response: %v", err))
+		}
+		ao.HookExecutionOutcome = hookExecutor.GetOutcomes()
+		return labels, ao
+	}
+
 	response := &openrtb2.BidResponse{NBR: openrtb3.NoBidReason(rejectErr.NBR).Ptr()}
 	if request != nil {
 		response.ID = request.ID
 	}
-
 	ao.Response = response
-	ao.Errors = append(ao.Errors, rejectErr)
 
 	return sendAuctionResponse(w, hookExecutor, response, request, account, labels, ao)
 }
@@ -377,15 +392,23 @@ func sendAuctionResponse(
 	}
 
 	// Fixes #231
-	enc := json.NewEncoder(w)
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
 	enc.SetEscapeHTML(false)
 
 	w.Header().Set("Content-Type", "application/json")
 
-	// If an error happens when encoding the response, there isn't much we can do.
+	// If an error happens when encoding or writing the response, there isn't much we can do.
 	// If we've sent _any_ bytes, then Go would have sent the 200 status code first.
 	// That status code can't be un-sent... so the best we can do is log the error.
-	if err := enc.Encode(response); err != nil {
+	err := enc.Encode(response)
+	if err == nil {
+		err = writeResponse(w, hookExecutor, http.StatusOK, body.Bytes())
+		if response != nil {
+			ao.HookExecutionOutcome = hookExecutor.GetOutcomes()
+		}
+	}
+	if err != nil {
 		labels.RequestStatus = metrics.RequestStatusNetworkErr
 		ao.Errors = append(ao.Errors, fmt.Errorf("/openrtb2/auction Failed to send response: %v", err))
 	}
@@ -393,6 +416,34 @@ func sendAuctionResponse(
 	return labels, ao
 }
 
+// writeResponse runs the exitpoint hooks on the serialized response, which may change its status,
+// headers and body, before writing it. The outcome of the exitpoint stage can only reach analytics.
+// The body isn't written with the status codes which don't allow one, e.g. 204.
+func writeResponse(w http.ResponseWriter, hookExecutor hookexecution.StageExecutor, statusCode int, body []byte) error {
+	statusCode, headers, body := hookExecutor.ExecuteExitpointStage(statusCode, w.Header().Clone(), body)
+
+	clear(w.Header())
+	maps.Copy(w.Header(), headers)
+	w.WriteHeader(statusCode)
+	if !httputil.StatusAllowsBody(statusCode) {
+		return nil
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+// writeRejectResponse writes the response a hook rejected the request with, its headers replacing the
+// headers of the endpoint. The exitpoint hooks are run on it like on the other responses.
+func writeRejectResponse(w http.ResponseWriter, hookExecutor hookexecution.StageExecutor, response *hookstage.HTTPResponse) error {
+	for name, values := range response.Header {
+		w.Header().Del(name)
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	return writeResponse(w, hookExecutor, response.StatusCode, response.Body)
+}
+
 // setBrowsingTopicsHeader always set the Observe-Browsing-Topics header to a value of ?1 if the Sec-Browsing-Topics is present in request
 func setBrowsingTopicsHeader(w http.ResponseWriter, r *http.Request) {
 	if value := r.Header.Get(secBrowsingTopics); value != "" {
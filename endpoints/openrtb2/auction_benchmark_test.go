@@ -18,6 +18,7 @@ import (
 	"github.com/prebid/prebid-server/v3/hooks"
 	"github.com/prebid/prebid-server/v3/macros"
 	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/ortb"
 	"github.com/prebid/prebid-server/v3/stored_requests/backends/empty_fetcher"
@@ -100,6 +101,9 @@ func BenchmarkOpenrtbEndpoint(b *testing.B) {
 		macros.NewStringIndexBasedReplacer(),
 		nil,
 		singleFormatBidders,
+		notifications.NilBidStore{},
+		nil,
+		nil,
 	)
 
 	endpoint, _ := NewEndpoint(
@@ -110,7 +114,7 @@ func BenchmarkOpenrtbEndpoint(b *testing.B) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		nilMetrics,
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		nil,
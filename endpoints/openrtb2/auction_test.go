@@ -42,6 +42,7 @@ import (
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/prebid/prebid-server/v3/util/ptrutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const jsonFileExtension string = ".json"
@@ -480,7 +481,7 @@ func TestExplicitUserId(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		cfg,
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -538,7 +539,7 @@ func doBadAliasRequest(t *testing.T, filename string, expectMsg string) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		disabledBidders,
 		aliasJSON,
 		bidderMap,
@@ -593,7 +594,7 @@ func TestNilExchange(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -619,7 +620,7 @@ func TestExchangeError(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -746,7 +747,7 @@ func TestImplicitIPsEndToEnd(t *testing.T) {
 			empty_fetcher.EmptyFetcher{},
 			cfg,
 			&metricsConfig.NilMetricsEngine{},
-			analyticsBuild.New(&config.Analytics{}),
+			analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 			map[string]string{},
 			[]byte{},
 			openrtb_ext.BuildBidderMap(),
@@ -946,7 +947,7 @@ func TestImplicitDNTEndToEnd(t *testing.T) {
 			empty_fetcher.EmptyFetcher{},
 			&config.Configuration{MaxRequestSize: maxSize},
 			&metricsConfig.NilMetricsEngine{},
-			analyticsBuild.New(&config.Analytics{}),
+			analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 			map[string]string{},
 			[]byte{},
 			openrtb_ext.BuildBidderMap(),
@@ -1183,7 +1184,7 @@ func TestStoredRequests(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -1633,7 +1634,7 @@ func TestValidateRequest(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2351,7 +2352,7 @@ func TestSetIntegrationType(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2418,7 +2419,7 @@ func TestStoredRequestGenerateUuid(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2523,7 +2524,7 @@ func TestOversizedRequest(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: int64(len(reqBody) - 1)},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2563,7 +2564,7 @@ func TestRequestSizeEdgeCase(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: int64(len(reqBody))},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2601,7 +2602,7 @@ func TestNoEncoding(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -2686,7 +2687,7 @@ func TestContentType(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -2724,7 +2725,7 @@ func TestCurrencyTrunc(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2773,7 +2774,7 @@ func TestCCPAInvalid(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2826,7 +2827,7 @@ func TestNoSaleInvalid(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2882,7 +2883,7 @@ func TestValidateSourceTID(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		cfg,
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -2928,7 +2929,7 @@ func TestSChainInvalid(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -3369,7 +3370,7 @@ func TestEidPermissionsInvalid(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -3648,7 +3649,7 @@ func TestIOS14EndToEnd(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -3711,7 +3712,7 @@ func TestAuctionWarnings(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -3758,7 +3759,7 @@ func TestParseRequestParseImpInfoError(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: int64(len(reqBody))},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -3839,7 +3840,7 @@ func TestParseGzipedRequest(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: int64(50), Compression: config.Compression{Request: config.CompressionInfo{GZIP: false}}},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -3947,7 +3948,7 @@ func TestAuctionResponseHeaders(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		[]byte{},
 		openrtb_ext.BuildBidderMap(),
@@ -4022,7 +4023,7 @@ func TestParseRequestMergeBidderParams(t *testing.T) {
 				empty_fetcher.EmptyFetcher{},
 				&config.Configuration{MaxRequestSize: int64(len(test.givenRequestBody))},
 				&metricsConfig.NilMetricsEngine{},
-				analyticsBuild.New(&config.Analytics{}),
+				analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 				map[string]string{},
 				false,
 				[]byte{},
@@ -4128,7 +4129,7 @@ func TestParseRequestStoredResponses(t *testing.T) {
 				empty_fetcher.EmptyFetcher{},
 				&config.Configuration{MaxRequestSize: int64(len(test.givenRequestBody))},
 				&metricsConfig.NilMetricsEngine{},
-				analyticsBuild.New(&config.Analytics{}),
+				analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 				map[string]string{},
 				false,
 				[]byte{},
@@ -4241,7 +4242,7 @@ func TestParseRequestStoredBidResponses(t *testing.T) {
 				empty_fetcher.EmptyFetcher{},
 				&config.Configuration{MaxRequestSize: int64(len(test.givenRequestBody))},
 				&metricsConfig.NilMetricsEngine{},
-				analyticsBuild.New(&config.Analytics{}),
+				analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 				map[string]string{},
 				false,
 				[]byte{},
@@ -4279,7 +4280,7 @@ func TestValidateStoredResp(t *testing.T) {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -4967,6 +4968,93 @@ func TestValidResponseAfterExecutingStages(t *testing.T) {
 	}
 }
 
+func TestAuctionRejectResponse(t *testing.T) {
+	const file = "sample-requests/hooks/auction_entrypoint_reject.json"
+	response := &hookstage.HTTPResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"30"}, "Content-Type": {"text/plain"}},
+		Body:       []byte("rate limit exceeded"),
+	}
+
+	testCases := []struct {
+		description string
+		planBuilder hooks.ExecutionPlanBuilder
+	}{
+		{
+			description: "Hook response sent when request rejected at entrypoint stage",
+			planBuilder: mockPlanBuilder{entrypointPlan: makePlan[hookstage.Entrypoint](mockRejectResponseHook{response})},
+		},
+		{
+			description: "Hook response sent when request rejected at raw-auction stage",
+			planBuilder: mockPlanBuilder{rawAuctionPlan: makePlan[hookstage.RawAuctionRequest](mockRejectResponseHook{response})},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			fileData, err := os.ReadFile(file)
+			require.NoError(t, err, "Failed to read test file.")
+
+			test, err := parseTestData(fileData, file)
+			require.NoError(t, err, "Failed to parse test file.")
+			test.planBuilder = tc.planBuilder
+			test.endpointType = OPENRTB_ENDPOINT
+
+			cfg := &config.Configuration{MaxRequestSize: maxSize}
+			auctionEndpointHandler, _, mockBidServers, mockCurrencyRatesServer, err := buildTestEndpoint(test, cfg)
+			require.NoError(t, err, "Failed to build test endpoint.")
+			defer func() {
+				for _, mockBidServer := range mockBidServers {
+					mockBidServer.Close()
+				}
+				mockCurrencyRatesServer.Close()
+			}()
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/openrtb2/auction", bytes.NewReader(test.BidRequest))
+			auctionEndpointHandler(recorder, req, nil)
+
+			assert.Equal(t, http.StatusTooManyRequests, recorder.Code, "Invalid status code.")
+			assert.Equal(t, "30", recorder.Header().Get("Retry-After"), "Hook header not added.")
+			assert.Equal(t, "text/plain", recorder.Header().Get("Content-Type"), "Endpoint header not replaced by hook header.")
+			assert.NotEmpty(t, recorder.Header().Get("X-Prebid"), "Endpoint header not preserved.")
+			assert.Equal(t, "rate limit exceeded", recorder.Body.String(), "Invalid body.")
+		})
+	}
+}
+
+func TestWriteResponseWithoutBody(t *testing.T) {
+	testCases := []struct {
+		description  string
+		statusCode   int
+		expectedBody string
+	}{
+		{
+			description:  "Body written",
+			statusCode:   http.StatusForbidden,
+			expectedBody: "blocked",
+		},
+		{
+			description:  "Body not written with status code not allowing it",
+			statusCode:   http.StatusNoContent,
+			expectedBody: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			err := writeResponse(recorder, hookexecution.EmptyHookExecutor{}, test.statusCode, []byte("blocked"))
+			assert.NoError(t, err)
+			assert.Equal(t, test.statusCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+
+	// the server fails the writes of a body with such status codes, e.g. with http.ErrBodyNotAllowed
+	assert.NoError(t, writeResponse(errorResponseWriter{}, hookexecution.EmptyHookExecutor{}, http.StatusNoContent, []byte("blocked")))
+}
+
 func TestSendAuctionResponse_LogsErrors(t *testing.T) {
 	hookExecutor := &mockStageExecutor{
 		outcomes: []hookexecution.StageOutcome{
@@ -5039,6 +5127,46 @@ func TestSendAuctionResponse_LogsErrors(t *testing.T) {
 	}
 }
 
+func TestSendAuctionResponse_RunsExitpointHooks(t *testing.T) {
+	planBuilder := mockPlanBuilder{exitpointPlan: makePlan[hookstage.Exitpoint](mockExitpointHook{})}
+	hookExecutor := hookexecution.NewHookExecutor(planBuilder, hookexecution.EndpointAuction, &metricsConfig.NilMetricsEngine{})
+
+	writer := httptest.NewRecorder()
+	writer.Header().Set("Content-Type", "application/json")
+	response := &openrtb2.BidResponse{ID: "some-id"}
+	request := &openrtb2.BidRequest{ID: "some-id"}
+
+	_, ao := sendAuctionResponse(writer, hookExecutor, response, request, &config.Account{}, metrics.Labels{}, analytics.AuctionObject{})
+
+	assert.Empty(t, ao.Errors, "Unexpected errors.")
+	assert.Equal(t, http.StatusAccepted, writer.Code, "Status code not changed by exitpoint hook.")
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"), "Existing header not preserved.")
+	assert.Equal(t, "bar", writer.Header().Get("X-Foo"), "Header not added by exitpoint hook.")
+	assert.JSONEq(t, `{"id":"new-id"}`, writer.Body.String(), "Body not changed by exitpoint hook.")
+
+	if assert.Len(t, ao.HookExecutionOutcome, 1, "Exitpoint outcome not reported to analytics.") {
+		assert.Equal(t, hooks.StageExitpoint.String(), ao.HookExecutionOutcome[0].Stage)
+	}
+}
+
+type mockExitpointHook struct{}
+
+func (m mockExitpointHook) HandleExitpointHook(
+	_ context.Context,
+	_ hookstage.ModuleInvocationContext,
+	_ hookstage.ExitpointPayload,
+) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+	c := hookstage.ChangeSet[hookstage.ExitpointPayload]{}
+	c.AddMutation(func(payload hookstage.ExitpointPayload) (hookstage.ExitpointPayload, error) {
+		payload.StatusCode = http.StatusAccepted
+		payload.Headers.Set("X-Foo", "bar")
+		payload.Body = []byte(`{"id":"new-id"}`)
+		return payload, nil
+	}, hookstage.MutationUpdate, "httpResponse")
+
+	return hookstage.HookResult[hookstage.ExitpointPayload]{ChangeSet: c}, nil
+}
+
 func TestParseRequestMultiBid(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -5097,7 +5225,7 @@ func TestParseRequestMultiBid(t *testing.T) {
 				empty_fetcher.EmptyFetcher{},
 				&config.Configuration{MaxRequestSize: int64(len(test.givenRequestBody))},
 				&metricsConfig.NilMetricsEngine{},
-				analyticsBuild.New(&config.Analytics{}),
+				analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 				map[string]string{},
 				false,
 				[]byte{},
@@ -5813,7 +5941,7 @@ func TestValidateRequestCookieDeprecation(t *testing.T) {
 		&mockAccountFetcher{},
 		&config.Configuration{},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
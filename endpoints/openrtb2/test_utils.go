@@ -33,6 +33,7 @@ import (
 	"github.com/prebid/prebid-server/v3/macros"
 	"github.com/prebid/prebid-server/v3/metrics"
 	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/ortb"
 	pbc "github.com/prebid/prebid-server/v3/prebid_cache_client"
@@ -1276,6 +1277,9 @@ func buildTestExchange(testCfg *testConfigValues, adapterMap map[openrtb_ext.Bid
 		macros.NewStringIndexBasedReplacer(),
 		nil,
 		singleFormatBidders,
+		notifications.NilBidStore{},
+		nil,
+		nil,
 	)
 
 	testExchange = &exchangeTestWrapper{
@@ -1409,7 +1413,7 @@ func buildTestEndpoint(test testCase, cfg *config.Configuration) (httprouter.Han
 		accountFetcher,
 		cfg,
 		met,
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		disabledBidders,
 		[]byte(test.Config.AliasJSON),
 		bidderMap,
@@ -1529,6 +1533,7 @@ type mockPlanBuilder struct {
 	rawBidderResponsePlan        hooks.Plan[hookstage.RawBidderResponse]
 	allProcessedBidResponsesPlan hooks.Plan[hookstage.AllProcessedBidResponses]
 	auctionResponsePlan          hooks.Plan[hookstage.AuctionResponse]
+	exitpointPlan                hooks.Plan[hookstage.Exitpoint]
 }
 
 func (m mockPlanBuilder) PlanForEntrypointStage(_ string) hooks.Plan[hookstage.Entrypoint] {
@@ -1559,6 +1564,10 @@ func (m mockPlanBuilder) PlanForAuctionResponseStage(_ string, _ *config.Account
 	return m.auctionResponsePlan
 }
 
+func (m mockPlanBuilder) PlanForExitpointStage(_ string, _ *config.Account) hooks.Plan[hookstage.Exitpoint] {
+	return m.exitpointPlan
+}
+
 func makePlan[H any](hook H) hooks.Plan[H] {
 	return hooks.Plan[H]{
 		{
@@ -1631,6 +1640,27 @@ func (m mockRejectionHook) HandleRawBidderResponseHook(
 	return result, nil
 }
 
+// mockRejectResponseHook rejects the request with the given HTTP response.
+type mockRejectResponseHook struct {
+	response *hookstage.HTTPResponse
+}
+
+func (m mockRejectResponseHook) HandleEntrypointHook(
+	_ context.Context,
+	_ hookstage.ModuleInvocationContext,
+	_ hookstage.EntrypointPayload,
+) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	return hookstage.HookResult[hookstage.EntrypointPayload]{Reject: true, RejectResponse: m.response}, nil
+}
+
+func (m mockRejectResponseHook) HandleRawAuctionHook(
+	_ context.Context,
+	_ hookstage.ModuleInvocationContext,
+	_ hookstage.RawAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.RawAuctionRequestPayload], error) {
+	return hookstage.HookResult[hookstage.RawAuctionRequestPayload]{Reject: true, RejectResponse: m.response}, nil
+}
+
 var entryPointHookUpdateWithErrors = hooks.HookWrapper[hookstage.Entrypoint]{
 	Module: "foobar",
 	Code:   "foo",
@@ -57,6 +57,7 @@ func NewVideoEndpoint(
 	defReqJSON []byte,
 	bidderMap map[string]openrtb_ext.BidderName,
 	cache prebid_cache_client.Client,
+	planBuilder hooks.ExecutionPlanBuilder,
 	tmaxAdjustments *exchange.TmaxAdjustmentsPreprocessed,
 ) (httprouter.Handle, error) {
 
@@ -91,7 +92,7 @@ func NewVideoEndpoint(
 		videoEndpointRegexp,
 		ipValidator,
 		empty_fetcher.EmptyFetcher{},
-		hooks.EmptyPlanBuilder{},
+		planBuilder,
 		tmaxAdjustments,
 		openrtb_ext.NormalizeBidderName}).VideoAuctionEndpoint), nil
 }
@@ -152,6 +153,9 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 
 	activityControl := privacy.ActivityControl{}
 
+	// only the entrypoint and exitpoint stages are run for the video endpoint, its auction uses an EmptyHookExecutor
+	hookExecutor := hookexecution.NewHookExecutor(deps.hookExecutionPlanBuilder, hookexecution.EndpointVideo, deps.metricsEngine)
+
 	defer func() {
 		if len(debugLog.CacheKey) > 0 && vo.VideoResponse == nil {
 			err := debugLog.PutDebugLogError(deps.cache, deps.cfg.CacheURL.ExpectedTimeMillis, vo.Errors)
@@ -161,6 +165,8 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 		}
 		deps.metricsEngine.RecordRequest(labels)
 		deps.metricsEngine.RecordRequestTime(labels, time.Since(start))
+		// the video response has no trace of the hooks, their outcome only reaches analytics
+		vo.HookExecutionOutcome = hookExecutor.GetOutcomes()
 		deps.analytics.LogVideoObject(&vo, activityControl)
 	}()
 
@@ -177,6 +183,12 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	requestJson, rejectErr := hookExecutor.ExecuteEntrypointStage(r, requestJson)
+	if rejectErr != nil {
+		rejectVideoRequest(*rejectErr, w, hookExecutor, &labels, &vo)
+		return
+	}
+
 	resolvedRequest := requestJson
 	if debugLog.DebugEnabledOrOverridden {
 		debugLog.Data.Request = string(requestJson)
@@ -302,6 +314,7 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 		handleError(&labels, w, acctIDErrs, &vo, &debugLog)
 		return
 	}
+	vo.Account = account
 
 	// Populate any "missing" OpenRTB fields with info from other sources, (e.g. HTTP request headers).
 	if errs := deps.setFieldsImplicitly(r, bidReqWrapper, account); len(errs) > 0 {
@@ -348,6 +361,8 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 	}
 	vo.Response = response
 	vo.SeatNonBid = auctionResponse.GetSeatNonBid()
+	vo.CurrencyConversions = auctionResponse.GetCurrencyConversions()
+	vo.BidderCalls = auctionResponse.GetBidderCalls()
 	if err != nil {
 		errL := []error{err}
 		handleError(&labels, w, errL, &vo, &debugLog)
@@ -393,8 +408,31 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	hookExecutor.SetAccount(account)
+	hookExecutor.SetActivityControl(activityControl)
+
 	w.Header().Set("Content-Type", "application/json")
-	w.Write(resp)
+	if err := writeResponse(w, hookExecutor, http.StatusOK, resp); err != nil {
+		vo.Errors = append(vo.Errors, fmt.Errorf("/openrtb2/video Failed to send response: %v", err))
+	}
+}
+
+// rejectVideoRequest sends the response the hook rejected the request with or, by default, a response without ad pods.
+func rejectVideoRequest(rejectErr hookexecution.RejectError, w http.ResponseWriter, hookExecutor hookexecution.HookStageExecutor, labels *metrics.Labels, vo *analytics.VideoObject) {
+	vo.Errors = append(vo.Errors, rejectErr)
+
+	var err error
+	if rejectErr.Response != nil {
+		vo.Status = rejectErr.Response.StatusCode
+		err = writeRejectResponse(w, hookExecutor, rejectErr.Response)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		err = writeResponse(w, hookExecutor, http.StatusOK, []byte(`{"adPods":[]}`))
+	}
+	if err != nil {
+		labels.RequestStatus = metrics.RequestStatusNetworkErr
+		vo.Errors = append(vo.Errors, fmt.Errorf("/openrtb2/video Failed to send response: %v", err))
+	}
 }
 
 func cleanupVideoBidRequest(videoReq *openrtb_ext.BidRequestVideo, podErrors []PodError) *openrtb_ext.BidRequestVideo {
@@ -17,6 +17,7 @@ import (
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/exchange"
 	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/metrics"
 	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
@@ -1226,6 +1227,53 @@ func TestVideoAuctionResponseHeaders(t *testing.T) {
 	}
 }
 
+func TestVideoEndpointRejectedByHook(t *testing.T) {
+	testCases := []struct {
+		description         string
+		givenResponse       *hookstage.HTTPResponse
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			description:         "Response without ad pods sent by default",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        `{"adPods":[]}`,
+		},
+		{
+			description:    "Hook response sent",
+			givenResponse:  &hookstage.HTTPResponse{StatusCode: http.StatusNoContent},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			ex := &mockExchangeVideo{}
+			deps, _, analyticsModule := mockDepsWithMetrics(t, ex)
+			deps.hookExecutionPlanBuilder = mockPlanBuilder{entrypointPlan: makePlan[hookstage.Entrypoint](mockRejectResponseHook{test.givenResponse})}
+
+			reqBody := readVideoTestFile(t, "sample-requests/video/video_valid_sample.json")
+			req := httptest.NewRequest("POST", "/openrtb2/video", strings.NewReader(reqBody))
+			recorder := httptest.NewRecorder()
+
+			deps.VideoAuctionEndpoint(recorder, req, nil)
+
+			assert.Nil(t, ex.lastRequest, "Auction run for the rejected request.")
+			assert.Equal(t, test.expectedStatus, recorder.Code, "Invalid status code.")
+			assert.Equal(t, test.expectedContentType, recorder.Header().Get("Content-Type"), "Invalid content type.")
+			assert.Equal(t, test.expectedBody, recorder.Body.String(), "Invalid body.")
+
+			require.Len(t, analyticsModule.videoObjects, 1)
+			outcomes := analyticsModule.videoObjects[0].HookExecutionOutcome
+			require.Len(t, outcomes, 1, "Outcome of the entrypoint stage not logged.")
+			assert.Equal(t, hooks.StageEntrypoint.String(), outcomes[0].Stage)
+		})
+	}
+}
+
 func mockDepsWithMetrics(t *testing.T, ex *mockExchangeVideo) (*endpointDeps, *metrics.Metrics, *mockAnalyticsModule) {
 	mockModule := &mockAnalyticsModule{}
 
@@ -1291,7 +1339,7 @@ func mockDeps(t *testing.T, ex *mockExchangeVideo) *endpointDeps {
 		&mockAccountFetcher{data: mockVideoAccountData},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -1316,7 +1364,7 @@ func mockDepsAppendBidderNames(t *testing.T, ex *mockExchangeAppendBidderNames)
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
@@ -1343,7 +1391,7 @@ func mockDepsNoBids(t *testing.T, ex *mockExchangeVideoNoBids) *endpointDeps {
 		empty_fetcher.EmptyFetcher{},
 		&config.Configuration{MaxRequestSize: maxSize},
 		&metricsConfig.NilMetricsEngine{},
-		analyticsBuild.New(&config.Analytics{}),
+		analyticsBuild.New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{}),
 		map[string]string{},
 		false,
 		[]byte{},
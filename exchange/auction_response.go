@@ -2,6 +2,8 @@ package exchange
 
 import (
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/currency"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
 
@@ -9,6 +11,10 @@ import (
 type AuctionResponse struct {
 	*openrtb2.BidResponse
 	ExtBidResponse *openrtb_ext.ExtBidResponse
+	// CurrencyConversions are the currency rates applied to the auction
+	CurrencyConversions currency.Conversions
+	// BidderCalls are the outcome of every bidder called during the auction
+	BidderCalls []*analytics.BidderCall
 }
 
 // GetSeatNonBid returns array of seat non-bid if present. nil otherwise
@@ -18,3 +24,19 @@ func (ar *AuctionResponse) GetSeatNonBid() []openrtb_ext.SeatNonBid {
 	}
 	return nil
 }
+
+// GetCurrencyConversions returns the currency rates applied to the auction if present. nil otherwise
+func (ar *AuctionResponse) GetCurrencyConversions() currency.Conversions {
+	if ar != nil {
+		return ar.CurrencyConversions
+	}
+	return nil
+}
+
+// GetBidderCalls returns the outcome of the bidder calls if present. nil otherwise
+func (ar *AuctionResponse) GetBidderCalls() []*analytics.BidderCall {
+	if ar != nil {
+		return ar.BidderCalls
+	}
+	return nil
+}
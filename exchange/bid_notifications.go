@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/macros"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/privacy"
+)
+
+// LossReason is the OpenRTB loss reason code substituted to the ${AUCTION_LOSS} macro of the loss notifications
+type LossReason int
+
+const (
+	LossReasonInvalidBidResponse LossReason = 3   // Invalid Bid Response
+	LossReasonBelowAuctionFloor  LossReason = 100 // Bid was Below Auction Floor
+	LossReasonBelowDealFloor     LossReason = 101 // Bid was Below Deal Floor
+	LossReasonLostToHigherBid    LossReason = 102 // Lost to Higher Bid
+	LossReasonLostToDeal         LossReason = 103 // Lost to a Bid for a PMP Deal
+)
+
+// notifyBids fires the win and loss notifications enabled for the account and saves the winning bids for the
+// reconciliation of the /event notifications. The auction is only run with targeting, without it the winners are
+// picked by the publisher and Prebid Server can't notify the bidders.
+func (e *exchange) notifyBids(r *AuctionRequest, auc *auction, bidResponse *openrtb2.BidResponse, floorRejectedBids []*entities.PbsOrtbSeatBid) {
+	responseBids := bidIDsBySeat(bidResponse)
+
+	for impID, bidsByBidder := range auc.allBidsByBidder {
+		winner := auc.winningBids[impID]
+		for bidder, bids := range bidsByBidder {
+			for _, bid := range bids {
+				_, inResponse := responseBids[bidder.String()][bid.Bid.ID]
+				switch {
+				case !inResponse:
+					e.notifyLoss(r, bidder, bid, LossReasonInvalidBidResponse)
+				case bid == winner:
+					e.notifyWin(r, bidder, bid, bidResponse.Cur)
+				case winner != nil && winner.Bid.DealID != "" && bid.Bid.DealID == "":
+					e.notifyLoss(r, bidder, bid, LossReasonLostToDeal)
+				default:
+					e.notifyLoss(r, bidder, bid, LossReasonLostToHigherBid)
+				}
+			}
+		}
+	}
+
+	for _, rejectedBid := range floorRejectedBids {
+		for _, bid := range rejectedBid.Bids {
+			reason := LossReasonBelowAuctionFloor
+			if bid.Bid.DealID != "" {
+				reason = LossReasonBelowDealFloor
+			}
+			e.notifyLoss(r, openrtb_ext.BidderName(rejectedBid.Seat), bid, reason)
+		}
+	}
+}
+
+// bidIDsBySeat returns the ids of the bids of the response, some bids of the auction may have been removed from it
+// by the validations applied when building the response
+func bidIDsBySeat(bidResponse *openrtb2.BidResponse) map[string]map[string]struct{} {
+	responseBids := make(map[string]map[string]struct{}, len(bidResponse.SeatBid))
+	for _, seatBid := range bidResponse.SeatBid {
+		bidIDs := make(map[string]struct{}, len(seatBid.Bid))
+		for _, bid := range seatBid.Bid {
+			bidIDs[bid.ID] = struct{}{}
+		}
+		responseBids[seatBid.Seat] = bidIDs
+	}
+	return responseBids
+}
+
+// notifyWin fires the nurl of a winning bid having a markup, when the markup is missing the nurl is what returns
+// it and must be called by the client rendering the ad
+func (e *exchange) notifyWin(r *AuctionRequest, bidder openrtb_ext.BidderName, bid *entities.PbsOrtbBid, currency string) {
+	if r.Account.BidNotifications.Win && bid.Bid.NURL != "" && bid.Bid.AdM != "" && notificationAllowed(r, bidder) {
+		e.bidNotifier.Notify(macros.ResolveAuctionMacros(bid.Bid.NURL, auctionMacros(r, bidder, bid, 0)))
+	}
+
+	if e.bidStore != nil {
+		// the events are sent with the bid id generated by Prebid Server when there's one
+		bidID := bid.Bid.ID
+		if bid.GeneratedBidID != "" {
+			bidID = bid.GeneratedBidID
+		}
+		e.bidStore.Save(r.Account.ID, &analytics.AuctionBid{
+			AuctionID:        r.BidRequestWrapper.ID,
+			AuctionTimestamp: r.StartTime.UnixMilli(),
+			ImpID:            bid.Bid.ImpID,
+			BidID:            bidID,
+			Bidder:           bidder.String(),
+			Price:            bid.Bid.Price,
+			Currency:         currency,
+		})
+	}
+}
+
+func (e *exchange) notifyLoss(r *AuctionRequest, bidder openrtb_ext.BidderName, bid *entities.PbsOrtbBid, reason LossReason) {
+	if r.Account.BidNotifications.Loss && bid.Bid.LURL != "" && notificationAllowed(r, bidder) {
+		e.bidNotifier.Notify(macros.ResolveAuctionMacros(bid.Bid.LURL, auctionMacros(r, bidder, bid, reason)))
+	}
+}
+
+// notificationAllowed reports whether the activity controls of the account let the outcome of the auction be reported
+// to the bidder, the notifications are sent from Prebid Server like the reports of the analytics modules
+func notificationAllowed(r *AuctionRequest, bidder openrtb_ext.BidderName) bool {
+	scope := privacy.Component{Type: privacy.ComponentTypeBidder, Name: bidder.String()}
+	return r.Activities.Allow(privacy.ActivityReportAnalytics, scope, privacy.NewRequestFromBidRequest(*r.BidRequestWrapper))
+}
+
+// auctionMacros returns the macro values of a bid. The price is the one returned by the bidder since the
+// notifications are in the currency of the bid.
+func auctionMacros(r *AuctionRequest, bidder openrtb_ext.BidderName, bid *entities.PbsOrtbBid, reason LossReason) macros.AuctionMacros {
+	return macros.AuctionMacros{
+		AuctionID: r.BidRequestWrapper.ID,
+		BidID:     bid.Bid.ID,
+		ImpID:     bid.Bid.ImpID,
+		SeatID:    bidder.String(),
+		AdID:      bid.Bid.AdID,
+		Price:     bid.OriginalBidCPM,
+		Currency:  bid.OriginalBidCur,
+		Loss:      int(reason),
+	}
+}
@@ -0,0 +1,168 @@
+package exchange
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/privacy"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockNotifier struct {
+	urls []string
+}
+
+func (n *mockNotifier) Notify(notificationURL string) {
+	n.urls = append(n.urls, notificationURL)
+}
+
+type mockBidStore struct {
+	bids map[string]*analytics.AuctionBid
+}
+
+func (s *mockBidStore) Save(accountID string, bid *analytics.AuctionBid) {
+	s.bids[accountID+"/"+bid.BidID] = bid
+}
+
+func (s *mockBidStore) Find(accountID, bidID string) *analytics.AuctionBid {
+	return s.bids[accountID+"/"+bidID]
+}
+
+func TestNotifyBids(t *testing.T) {
+	winner := &entities.PbsOrtbBid{
+		Bid:            &openrtb2.Bid{ID: "win", ImpID: "imp-1", Price: 2.2, AdM: "<div>", NURL: "https://a.com/win?p=${AUCTION_PRICE}&c=${AUCTION_CURRENCY}", LURL: "https://a.com/loss"},
+		OriginalBidCPM: 2,
+		OriginalBidCur: "EUR",
+		GeneratedBidID: "generated",
+	}
+	loser := &entities.PbsOrtbBid{
+		Bid:            &openrtb2.Bid{ID: "lose", ImpID: "imp-1", Price: 1, NURL: "https://b.com/win", LURL: "https://b.com/loss?b=${AUCTION_BID_ID}&l=${AUCTION_LOSS}&p=${AUCTION_PRICE}"},
+		OriginalBidCPM: 1,
+		OriginalBidCur: "USD",
+	}
+	invalid := &entities.PbsOrtbBid{
+		Bid:            &openrtb2.Bid{ID: "invalid", ImpID: "imp-2", Price: 1, LURL: "https://c.com/loss?l=${AUCTION_LOSS}"},
+		OriginalBidCPM: 1,
+	}
+	belowFloor := &entities.PbsOrtbBid{
+		Bid:            &openrtb2.Bid{ID: "floor", ImpID: "imp-1", Price: 0.1, DealID: "deal", LURL: "https://d.com/loss?l=${AUCTION_LOSS}"},
+		OriginalBidCPM: 0.1,
+	}
+
+	auc := &auction{
+		winningBids: map[string]*entities.PbsOrtbBid{"imp-1": winner, "imp-2": invalid},
+		allBidsByBidder: map[string]map[openrtb_ext.BidderName][]*entities.PbsOrtbBid{
+			"imp-1": {"appnexus": {winner}, "rubicon": {loser}},
+			"imp-2": {"pubmatic": {invalid}},
+		},
+	}
+	bidResponse := &openrtb2.BidResponse{
+		Cur: "USD",
+		SeatBid: []openrtb2.SeatBid{
+			{Seat: "appnexus", Bid: []openrtb2.Bid{{ID: "win"}}},
+			{Seat: "rubicon", Bid: []openrtb2.Bid{{ID: "lose"}}},
+		},
+	}
+	floorRejectedBids := []*entities.PbsOrtbSeatBid{{Seat: "openx", Bids: []*entities.PbsOrtbBid{belowFloor}}}
+
+	denyReportToRubicon := &config.AccountPrivacy{AllowActivities: &config.AllowActivities{
+		ReportAnalytics: config.Activity{Rules: []config.ActivityRule{
+			{Condition: config.ActivityCondition{ComponentName: []string{"rubicon"}, ComponentType: []string{"bidder"}}},
+		}},
+	}}
+
+	testCases := []struct {
+		name          string
+		notifications config.AccountBidNotifications
+		privacy       *config.AccountPrivacy
+		expectedURLs  []string
+	}{
+		{
+			name:          "disabled",
+			notifications: config.AccountBidNotifications{},
+			expectedURLs:  nil,
+		},
+		{
+			name:          "win",
+			notifications: config.AccountBidNotifications{Win: true},
+			expectedURLs:  []string{"https://a.com/win?p=2&c=EUR"},
+		},
+		{
+			name:          "loss",
+			notifications: config.AccountBidNotifications{Loss: true},
+			expectedURLs: []string{
+				"https://b.com/loss?b=lose&l=102&p=",
+				"https://c.com/loss?l=3",
+				"https://d.com/loss?l=101",
+			},
+		},
+		{
+			name:          "loss-denied-by-activity-controls",
+			notifications: config.AccountBidNotifications{Win: true, Loss: true},
+			privacy:       denyReportToRubicon,
+			expectedURLs: []string{
+				"https://a.com/win?p=2&c=EUR",
+				"https://c.com/loss?l=3",
+				"https://d.com/loss?l=101",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			notifier := &mockNotifier{}
+			store := &mockBidStore{bids: map[string]*analytics.AuctionBid{}}
+			e := &exchange{bidNotifier: notifier, bidStore: store}
+			r := &AuctionRequest{
+				BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "auction"}},
+				Account:           config.Account{ID: "account", BidNotifications: test.notifications},
+				StartTime:         time.UnixMilli(1000),
+				Activities:        privacy.NewActivityControl(test.privacy),
+			}
+
+			e.notifyBids(r, auc, bidResponse, floorRejectedBids)
+
+			sort.Strings(notifier.urls)
+			assert.Equal(t, test.expectedURLs, notifier.urls)
+			assert.Equal(t, map[string]*analytics.AuctionBid{
+				"account/generated": {
+					AuctionID:        "auction",
+					AuctionTimestamp: 1000,
+					ImpID:            "imp-1",
+					BidID:            "generated",
+					Bidder:           "appnexus",
+					Price:            2.2,
+					Currency:         "USD",
+				},
+			}, store.bids)
+		})
+	}
+}
+
+func TestNotifyBidsLostToDeal(t *testing.T) {
+	winner := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "deal", ImpID: "imp", Price: 1, DealID: "deal"}}
+	loser := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "open", ImpID: "imp", Price: 2, LURL: "https://b.com/loss?l=${AUCTION_LOSS}"}}
+
+	auc := &auction{
+		winningBids:     map[string]*entities.PbsOrtbBid{"imp": winner},
+		allBidsByBidder: map[string]map[openrtb_ext.BidderName][]*entities.PbsOrtbBid{"imp": {"appnexus": {winner, loser}}},
+	}
+	bidResponse := &openrtb2.BidResponse{SeatBid: []openrtb2.SeatBid{{Seat: "appnexus", Bid: []openrtb2.Bid{{ID: "deal"}, {ID: "open"}}}}}
+
+	notifier := &mockNotifier{}
+	e := &exchange{bidNotifier: notifier}
+	r := &AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "auction"}},
+		Account:           config.Account{BidNotifications: config.AccountBidNotifications{Loss: true}},
+	}
+
+	e.notifyBids(r, auc, bidResponse, nil)
+
+	assert.Equal(t, []string{"https://b.com/loss?l=103"}, notifier.urls)
+}
@@ -30,6 +30,7 @@ import (
 	nativeResponse "github.com/prebid/openrtb/v20/native1/response"
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/metrics"
@@ -72,11 +73,17 @@ type bidRequestOptions struct {
 	tmaxAdjustments        *TmaxAdjustmentsPreprocessed
 	bidderRequestStartTime time.Time
 	responseDebugAllowed   bool
+	captureDebug           bool
 }
 
 type extraBidderRespInfo struct {
 	respProcessingStartTime time.Time
 	seatNonBidBuilder       SeatNonBidBuilder
+	// httpStatuses has the status code of every call made to the bidder, 0 when no response was received
+	httpStatuses []int
+	// capturedHttpCalls has every call made to the bidder when the auction is sampled by the debug
+	// capture, whatever the debug settings of the request, the account and the bidder
+	capturedHttpCalls []*openrtb_ext.ExtHttpCall
 }
 
 type extraAuctionResponseInfo struct {
@@ -84,6 +91,7 @@ type extraAuctionResponseInfo struct {
 	bidsFound               bool
 	bidderResponseStartTime time.Time
 	seatNonBidBuilder       SeatNonBidBuilder
+	bidderCalls             []*analytics.BidderCall
 }
 
 const ImpIdReqBody = "Stored bid response for impression id: "
@@ -238,6 +246,10 @@ func (bidder *BidderAdapter) requestBid(ctx context.Context, bidderRequest Bidde
 	// even if the timeout occurs sometime halfway through.
 	for i := 0; i < dataLen; i++ {
 		httpInfo := <-responseChannel
+		extraRespInfo.httpStatuses = append(extraRespInfo.httpStatuses, httpInfoToStatus(httpInfo))
+		if bidRequestOptions.captureDebug {
+			extraRespInfo.capturedHttpCalls = append(extraRespInfo.capturedHttpCalls, makeExt(httpInfo))
+		}
 		// If this is a test bid, capture debugging info from the requests.
 		// Write debug data to ext in case if:
 		// - headerDebugAllowed (debug override header specified correct) - it overrides all other debug restrictions
@@ -524,6 +536,14 @@ func makeExt(httpInfo *httpCallInfo) *openrtb_ext.ExtHttpCall {
 	return ext
 }
 
+// httpInfoToStatus returns the status code of the bidder response, 0 if the bidder didn't respond.
+func httpInfoToStatus(httpInfo *httpCallInfo) int {
+	if httpInfo.response == nil {
+		return 0
+	}
+	return httpInfo.response.StatusCode
+}
+
 // doRequest makes a request, handles the response, and returns the data needed by the
 // Bidder interface.
 func (bidder *BidderAdapter) doRequest(ctx context.Context, req *adapters.RequestData, bidderRequestStartTime time.Time, tmaxAdjustments *TmaxAdjustmentsPreprocessed) *httpCallInfo {
@@ -0,0 +1,92 @@
+package exchange
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+)
+
+// newBidderCall builds the analytics record of a bidder call from the bids it returned, before the exchange
+// applies floors, category mapping and the response validations
+func newBidderCall(bidder, adapter openrtb_ext.BidderName, seatBids []*entities.PbsOrtbSeatBid, ae *seatResponseExtra, httpStatuses []int) *analytics.BidderCall {
+	call := &analytics.BidderCall{
+		Bidder:             bidder.String(),
+		Adapter:            adapter.String(),
+		ResponseTimeMillis: ae.ResponseTimeMillis,
+		HttpStatuses:       httpStatuses,
+		Errors:             ae.Errors,
+	}
+	for _, seatBid := range seatBids {
+		if seatBid == nil {
+			continue
+		}
+		for _, bid := range seatBid.Bids {
+			if bid == nil || bid.Bid == nil {
+				continue
+			}
+			call.Bids = append(call.Bids, &analytics.BidderCallBid{
+				Seat:             seatBid.Seat,
+				ImpID:            bid.Bid.ImpID,
+				BidID:            bid.Bid.ID,
+				DealID:           bid.Bid.DealID,
+				BidType:          bid.BidType,
+				OriginalPrice:    bid.OriginalBidCPM,
+				OriginalCurrency: bid.OriginalBidCur,
+				Price:            bid.Bid.Price,
+				Currency:         seatBid.Currency,
+			})
+		}
+	}
+	return call
+}
+
+// setBidderCallsNonBids attaches the non bids of the auction to the bidder calls. A non bid is matched to the
+// bid it rejected on its seat, imp and prices since the non bids don't carry the bid id.
+func setBidderCallsNonBids(calls []*analytics.BidderCall, seatNonBidBuilder SeatNonBidBuilder) {
+	for _, call := range calls {
+		for _, seat := range bidderCallSeats(call) {
+			for _, nonBid := range seatNonBidBuilder[seat] {
+				call.NonBids = append(call.NonBids, nonBid)
+				if bid := findRejectedBid(call.Bids, seat, nonBid); bid != nil {
+					bid.NonBidReason = nonBid.StatusCode
+				}
+			}
+		}
+	}
+}
+
+// bidderCallSeats returns the bidder and the alternate seats it bid with, sorted
+func bidderCallSeats(call *analytics.BidderCall) []string {
+	seats := []string{call.Bidder}
+	for _, bid := range call.Bids {
+		if !slices.Contains(seats, bid.Seat) {
+			seats = append(seats, bid.Seat)
+		}
+	}
+	sort.Strings(seats[1:])
+	return seats
+}
+
+func findRejectedBid(bids []*analytics.BidderCallBid, seat string, nonBid openrtb_ext.NonBid) *analytics.BidderCallBid {
+	if nonBid.Ext == nil {
+		return nil
+	}
+	rejected := nonBid.Ext.Prebid.Bid
+	for _, bid := range bids {
+		if bid.NonBidReason == 0 && bid.Seat == seat && bid.ImpID == nonBid.ImpId &&
+			bid.Price == rejected.Price && bid.OriginalPrice == rejected.OriginalBidCPM && bid.DealID == rejected.DealID {
+			return bid
+		}
+	}
+	return nil
+}
+
+// sortBidderCalls orders the bidder calls by bidder since they're collected as the bidders respond
+func sortBidderCalls(calls []*analytics.BidderCall) {
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Bidder < calls[j].Bidder
+	})
+}
@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBidderCall(t *testing.T) {
+	ae := &seatResponseExtra{
+		ResponseTimeMillis: 85,
+		Errors:             []openrtb_ext.ExtBidderMessage{{Code: 1, Message: "timeout"}},
+	}
+
+	tests := []struct {
+		name     string
+		seatBids []*entities.PbsOrtbSeatBid
+		want     *analytics.BidderCall
+	}{
+		{
+			name:     "no_bids",
+			seatBids: nil,
+			want: &analytics.BidderCall{
+				Bidder:             "appnexus",
+				Adapter:            "appnexus",
+				ResponseTimeMillis: 85,
+				HttpStatuses:       []int{200, 0},
+				Errors:             ae.Errors,
+			},
+		},
+		{
+			name: "bids_of_all_seats",
+			seatBids: []*entities.PbsOrtbSeatBid{
+				nil,
+				{
+					Seat:     "appnexus",
+					Currency: "USD",
+					Bids: []*entities.PbsOrtbBid{
+						{
+							Bid:            &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", Price: 1.3},
+							BidType:        openrtb_ext.BidTypeBanner,
+							OriginalBidCPM: 1.2,
+							OriginalBidCur: "EUR",
+						},
+						{Bid: nil},
+					},
+				},
+				{
+					Seat:     "groupm",
+					Currency: "USD",
+					Bids: []*entities.PbsOrtbBid{
+						{
+							Bid:            &openrtb2.Bid{ID: "bid-2", ImpID: "imp-1", Price: 2, DealID: "deal"},
+							BidType:        openrtb_ext.BidTypeVideo,
+							OriginalBidCPM: 2,
+							OriginalBidCur: "USD",
+						},
+					},
+				},
+			},
+			want: &analytics.BidderCall{
+				Bidder:             "appnexus",
+				Adapter:            "appnexus",
+				ResponseTimeMillis: 85,
+				HttpStatuses:       []int{200, 0},
+				Errors:             ae.Errors,
+				Bids: []*analytics.BidderCallBid{
+					{Seat: "appnexus", ImpID: "imp-1", BidID: "bid-1", BidType: openrtb_ext.BidTypeBanner, OriginalPrice: 1.2, OriginalCurrency: "EUR", Price: 1.3, Currency: "USD"},
+					{Seat: "groupm", ImpID: "imp-1", BidID: "bid-2", DealID: "deal", BidType: openrtb_ext.BidTypeVideo, OriginalPrice: 2, OriginalCurrency: "USD", Price: 2, Currency: "USD"},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := newBidderCall(openrtb_ext.BidderAppnexus, openrtb_ext.BidderAppnexus, test.seatBids, ae, []int{200, 0})
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSetBidderCallsNonBids(t *testing.T) {
+	rejectedBid := &entities.PbsOrtbBid{
+		Bid:            &openrtb2.Bid{ID: "bid-2", ImpID: "imp-1", Price: 0.5},
+		OriginalBidCPM: 0.5,
+		OriginalBidCur: "USD",
+	}
+	seatNonBidBuilder := SeatNonBidBuilder{}
+	seatNonBidBuilder.rejectImps([]string{"imp-2"}, ErrorTimeout, "appnexus")
+	seatNonBidBuilder.rejectBid(rejectedBid, int(ResponseRejectedBelowFloor), "groupm")
+	seatNonBidBuilder.rejectImps([]string{"imp-1"}, ErrorGeneral, "rubicon")
+
+	calls := []*analytics.BidderCall{
+		{
+			Bidder: "appnexus",
+			Bids: []*analytics.BidderCallBid{
+				{Seat: "groupm", ImpID: "imp-1", BidID: "bid-1", OriginalPrice: 2, Price: 2},
+				{Seat: "groupm", ImpID: "imp-1", BidID: "bid-2", OriginalPrice: 0.5, Price: 0.5},
+			},
+		},
+		{
+			Bidder: "pubmatic",
+		},
+	}
+
+	setBidderCallsNonBids(calls, seatNonBidBuilder)
+
+	assert.Equal(t, []openrtb_ext.NonBid{seatNonBidBuilder["appnexus"][0], seatNonBidBuilder["groupm"][0]}, calls[0].NonBids)
+	assert.Equal(t, 0, calls[0].Bids[0].NonBidReason)
+	assert.Equal(t, int(ResponseRejectedBelowFloor), calls[0].Bids[1].NonBidReason)
+	assert.Empty(t, calls[1].NonBids)
+}
+
+func TestSortBidderCalls(t *testing.T) {
+	calls := []*analytics.BidderCall{{Bidder: "rubicon"}, {Bidder: "appnexus"}, {Bidder: "pubmatic"}}
+
+	sortBidderCalls(calls)
+
+	assert.Equal(t, []*analytics.BidderCall{{Bidder: "appnexus"}, {Bidder: "pubmatic"}, {Bidder: "rubicon"}}, calls)
+}
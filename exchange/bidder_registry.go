@@ -0,0 +1,166 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/experiment/adscert"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+)
+
+// ErrUnknownBidder is returned when updating the configuration of a bidder which doesn't exist
+var ErrUnknownBidder = errors.New("unknown bidder")
+
+// BidderUpdater is implemented by the Exchange to swap the bidders the auctions are run with.
+type BidderUpdater interface {
+	UpdateBidders(adapters map[openrtb_ext.BidderName]AdaptedBidder, singleFormatBidders map[openrtb_ext.BidderName]struct{}, infos config.BidderInfos)
+}
+
+// UpdateBidders swaps the adapters and the bidder infos at once. Auctions already running keep the
+// bidders they started with.
+func (e *exchange) UpdateBidders(adapters map[openrtb_ext.BidderName]AdaptedBidder, singleFormatBidders map[openrtb_ext.BidderName]struct{}, infos config.BidderInfos) {
+	e.biddersMutex.Lock()
+	defer e.biddersMutex.Unlock()
+
+	e.adapterMap = adapters
+	e.singleFormatBidders = singleFormatBidders
+	e.bidderInfo = infos
+	e.requestSplitter.bidderInfo = infos
+}
+
+// disabledAdapter stands for a bidder disabled at runtime. The request validation only knows about
+// the bidders of the startup configuration, so requests for them still reach the exchange.
+type disabledAdapter struct{}
+
+func (disabledAdapter) requestBid(ctx context.Context, bidderRequest BidderRequest, conversions currency.Conversions, reqInfo *adapters.ExtraRequestInfo, adsCertSigner adscert.Signer, bidRequestOptions bidRequestOptions, alternateBidderCodes openrtb_ext.ExtAlternateBidderCodes, hookExecutor hookexecution.StageExecutor, ruleToAdjustments openrtb_ext.AdjustmentsByDealID) ([]*entities.PbsOrtbSeatBid, extraBidderRespInfo, []error) {
+	msg := fmt.Sprintf(`Bidder "%s" has been disabled on this instance of Prebid Server. Please work with the PBS host to enable this bidder again.`, bidderRequest.BidderName)
+	return nil, extraBidderRespInfo{}, []error{&errortypes.BidderTemporarilyDisabled{Message: msg}}
+}
+
+// BidderConfigUpdate is a change of the configuration of a bidder made at runtime. Nil fields are
+// left unchanged.
+type BidderConfigUpdate struct {
+	Disabled  *bool   `json:"disabled,omitempty"`
+	Endpoint  *string `json:"endpoint,omitempty"`
+	ExtraInfo *string `json:"extra_info,omitempty"`
+}
+
+type adaptersBuilder func(infos config.BidderInfos) (map[openrtb_ext.BidderName]AdaptedBidder, map[openrtb_ext.BidderName]struct{}, []error)
+
+// BidderRegistry holds the effective bidder infos and rebuilds the adapters of the exchange when they
+// change at runtime.
+//
+// Bidders can't be added at runtime, nor enabled if they were disabled at startup, since the bidders
+// accepted by the request validation are set at startup.
+type BidderRegistry struct {
+	mutex        sync.Mutex
+	infos        config.BidderInfos
+	startupInfos config.BidderInfos
+	mergeInfos   func(fsBidderInfos config.BidderInfos) (config.BidderInfos, error)
+	build        adaptersBuilder
+	updater      BidderUpdater
+}
+
+func NewBidderRegistry(client *http.Client, cfg *config.Configuration, me metrics.MetricsEngine, updater BidderUpdater) *BidderRegistry {
+	return &BidderRegistry{
+		infos:        cfg.BidderInfos,
+		startupInfos: cfg.BidderInfos,
+		mergeInfos:   cfg.MergeBidderInfos,
+		build: func(infos config.BidderInfos) (map[openrtb_ext.BidderName]AdaptedBidder, map[openrtb_ext.BidderName]struct{}, []error) {
+			return BuildAdapters(client, cfg, infos, me)
+		},
+		updater: updater,
+	}
+}
+
+// BidderInfos returns the effective bidder infos. They must not be modified.
+func (r *BidderRegistry) BidderInfos() config.BidderInfos {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.infos
+}
+
+// UpdateBidder changes the configuration of a bidder, or of an alias, and returns its new bidder info
+func (r *BidderRegistry) UpdateBidder(bidder string, update BidderConfigUpdate) (config.BidderInfo, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bidderName, found := openrtb_ext.NormalizeBidderName(bidder)
+	if !found {
+		return config.BidderInfo{}, fmt.Errorf("%w: %s", ErrUnknownBidder, bidder)
+	}
+	info, found := r.infos[string(bidderName)]
+	if !found {
+		return config.BidderInfo{}, fmt.Errorf("%w: %s", ErrUnknownBidder, bidder)
+	}
+
+	if update.Disabled != nil {
+		info.Disabled = *update.Disabled
+	}
+	if update.Endpoint != nil {
+		info.Endpoint = *update.Endpoint
+	}
+	if update.ExtraInfo != nil {
+		info.ExtraAdapterInfo = *update.ExtraInfo
+	}
+
+	infos := maps.Clone(r.infos)
+	infos[string(bidderName)] = info
+	if err := infos.Validate(); err != nil {
+		return config.BidderInfo{}, err
+	}
+	if err := r.apply(infos); err != nil {
+		return config.BidderInfo{}, err
+	}
+	return info, nil
+}
+
+// Reload replaces the bidder infos by the ones loaded from the bidder-info directory, once the host
+// adapter overrides are applied. Changes made by UpdateBidder are discarded. The aliases are only set
+// once the bidder infos are validated and applied, so a rejected reload leaves them unchanged.
+func (r *BidderRegistry) Reload(fsBidderInfos config.BidderInfos) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	infos, err := r.mergeInfos(fsBidderInfos)
+	if err != nil {
+		return err
+	}
+	if err := r.apply(infos); err != nil {
+		return err
+	}
+	return openrtb_ext.SetAliasBidderNames(infos.AliasBidderNames())
+}
+
+func (r *BidderRegistry) apply(infos config.BidderInfos) error {
+	for bidder, info := range infos {
+		startupInfo, found := r.startupInfos[bidder]
+		if !found {
+			return fmt.Errorf("bidder %s is not known to this instance, adding bidders requires a restart", bidder)
+		}
+		if info.IsEnabled() && !startupInfo.IsEnabled() {
+			return fmt.Errorf("bidder %s was disabled at startup, enabling it requires a restart", bidder)
+		}
+	}
+
+	adapters, singleFormatBidders, errs := r.build(infos)
+	if len(errs) > 0 {
+		return errortypes.NewAggregateError("Failed to build adapters", errs)
+	}
+
+	r.updater.UpdateBidders(adapters, singleFormatBidders, infos)
+	r.infos = infos
+	return nil
+}
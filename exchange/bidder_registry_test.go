@@ -0,0 +1,219 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBidderUpdater struct {
+	adapters map[openrtb_ext.BidderName]AdaptedBidder
+	infos    config.BidderInfos
+}
+
+func (u *fakeBidderUpdater) UpdateBidders(adapters map[openrtb_ext.BidderName]AdaptedBidder, singleFormatBidders map[openrtb_ext.BidderName]struct{}, infos config.BidderInfos) {
+	u.adapters = adapters
+	u.infos = infos
+}
+
+func newTestBidderRegistry(infos config.BidderInfos, updater BidderUpdater) *BidderRegistry {
+	return &BidderRegistry{
+		infos:        infos,
+		startupInfos: infos,
+		mergeInfos: func(fsBidderInfos config.BidderInfos) (config.BidderInfos, error) {
+			return fsBidderInfos, nil
+		},
+		build: func(infos config.BidderInfos) (map[openrtb_ext.BidderName]AdaptedBidder, map[openrtb_ext.BidderName]struct{}, []error) {
+			adapters := make(map[openrtb_ext.BidderName]AdaptedBidder)
+			for bidder, info := range infos {
+				if info.IsEnabled() {
+					adapters[openrtb_ext.BidderName(bidder)] = disabledAdapter{}
+				}
+			}
+			return adapters, nil, nil
+		},
+		updater: updater,
+	}
+}
+
+func TestBidderRegistryUpdateBidder(t *testing.T) {
+	validInfo := config.BidderInfo{
+		Endpoint:     "http://appnexus.com",
+		Maintainer:   &config.MaintainerInfo{Email: "maintainer@appnexus.com"},
+		Capabilities: &config.CapabilitiesInfo{Site: &config.PlatformInfo{MediaTypes: []openrtb_ext.BidType{openrtb_ext.BidTypeBanner}}},
+	}
+	disabledInfo := validInfo
+	disabledInfo.Disabled = true
+	disabled := true
+	enabled := false
+	invalidEndpoint := "not a url"
+	newEndpoint := "http://new.appnexus.com"
+
+	testCases := []struct {
+		name             string
+		bidder           string
+		update           BidderConfigUpdate
+		expectedEndpoint string
+		expectedAdapters []openrtb_ext.BidderName
+		expectedErr      string
+		expectedErrIs    error
+	}{
+		{
+			name:             "disable",
+			bidder:           "appnexus",
+			update:           BidderConfigUpdate{Disabled: &disabled},
+			expectedEndpoint: "http://appnexus.com",
+			expectedAdapters: []openrtb_ext.BidderName{"rubicon"},
+		},
+		{
+			name:             "change-endpoint-case-insensitive",
+			bidder:           "AppNexus",
+			update:           BidderConfigUpdate{Endpoint: &newEndpoint},
+			expectedEndpoint: newEndpoint,
+			expectedAdapters: []openrtb_ext.BidderName{"appnexus", "rubicon"},
+		},
+		{
+			name:          "unknown-bidder",
+			bidder:        "unknown",
+			update:        BidderConfigUpdate{Disabled: &disabled},
+			expectedErrIs: ErrUnknownBidder,
+		},
+		{
+			name:        "invalid-endpoint",
+			bidder:      "appnexus",
+			update:      BidderConfigUpdate{Endpoint: &invalidEndpoint},
+			expectedErr: "The endpoint: not a url for appnexus is not a valid URL",
+		},
+		{
+			name:        "enable-bidder-disabled-at-startup",
+			bidder:      "openx",
+			update:      BidderConfigUpdate{Disabled: &enabled},
+			expectedErr: "bidder openx was disabled at startup, enabling it requires a restart",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			updater := &fakeBidderUpdater{}
+			infos := config.BidderInfos{"appnexus": validInfo, "rubicon": validInfo, "openx": disabledInfo}
+			registry := newTestBidderRegistry(infos, updater)
+
+			info, err := registry.UpdateBidder(test.bidder, test.update)
+
+			if test.expectedErrIs != nil || test.expectedErr != "" {
+				if test.expectedErrIs != nil {
+					assert.ErrorIs(t, err, test.expectedErrIs)
+				} else {
+					assert.ErrorContains(t, err, test.expectedErr)
+				}
+				assert.Nil(t, updater.adapters, "adapters must not be updated")
+				assert.Equal(t, infos, registry.BidderInfos())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedEndpoint, info.Endpoint)
+			assert.ElementsMatch(t, test.expectedAdapters, adapterNames(updater.adapters))
+			assert.Equal(t, info, registry.BidderInfos()["appnexus"])
+			assert.Equal(t, validInfo, infos["appnexus"], "the previous bidder infos must not be modified")
+		})
+	}
+}
+
+func TestBidderRegistryReload(t *testing.T) {
+	info := config.BidderInfo{Endpoint: "http://bidder.com"}
+	disabledInfo := config.BidderInfo{Endpoint: "http://bidder.com", Disabled: true}
+
+	testCases := []struct {
+		name             string
+		fsBidderInfos    config.BidderInfos
+		mergeErr         error
+		expectedAdapters []openrtb_ext.BidderName
+		expectedErr      string
+	}{
+		{
+			name:             "reloaded",
+			fsBidderInfos:    config.BidderInfos{"appnexus": disabledInfo, "rubicon": info},
+			expectedAdapters: []openrtb_ext.BidderName{"rubicon"},
+		},
+		{
+			name:          "merge-failure",
+			fsBidderInfos: config.BidderInfos{"appnexus": info},
+			mergeErr:      errors.New("invalid bidder infos"),
+			expectedErr:   "invalid bidder infos",
+		},
+		{
+			name:          "bidder-added",
+			fsBidderInfos: config.BidderInfos{"appnexus": info, "rubicon": info, "openx": info},
+			expectedErr:   "bidder openx is not known to this instance, adding bidders requires a restart",
+		},
+		{
+			name:          "alias-added",
+			fsBidderInfos: config.BidderInfos{"appnexus": info, "rubicon": info, "reloadAlias": {Endpoint: "http://bidder.com", AliasOf: "appnexus"}},
+			expectedErr:   "bidder reloadAlias is not known to this instance, adding bidders requires a restart",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			updater := &fakeBidderUpdater{}
+			registry := newTestBidderRegistry(config.BidderInfos{"appnexus": info, "rubicon": info}, updater)
+			registry.mergeInfos = func(fsBidderInfos config.BidderInfos) (config.BidderInfos, error) {
+				return fsBidderInfos, test.mergeErr
+			}
+
+			err := registry.Reload(test.fsBidderInfos)
+
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				assert.Nil(t, updater.adapters, "adapters must not be updated")
+				for alias := range test.fsBidderInfos.AliasBidderNames() {
+					_, found := openrtb_ext.NormalizeBidderName(alias)
+					assert.False(t, found, "alias %s must not be set", alias)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, test.expectedAdapters, adapterNames(updater.adapters))
+			assert.Equal(t, test.fsBidderInfos, updater.infos)
+			assert.Equal(t, test.fsBidderInfos, registry.BidderInfos())
+		})
+	}
+}
+
+func TestExchangeUpdateBidders(t *testing.T) {
+	e := &exchange{}
+	adapters := map[openrtb_ext.BidderName]AdaptedBidder{"appnexus": disabledAdapter{}}
+	singleFormatBidders := map[openrtb_ext.BidderName]struct{}{"appnexus": {}}
+	infos := config.BidderInfos{"appnexus": {Endpoint: "http://appnexus.com"}}
+
+	e.UpdateBidders(adapters, singleFormatBidders, infos)
+
+	assert.Equal(t, adapters, e.adapterMap)
+	assert.Equal(t, singleFormatBidders, e.singleFormatBidders)
+	assert.Equal(t, infos, e.bidderInfo)
+	assert.Equal(t, infos, e.requestSplitter.bidderInfo)
+}
+
+func TestDisabledAdapterRequestBid(t *testing.T) {
+	seatBids, _, errs := disabledAdapter{}.requestBid(context.Background(), BidderRequest{BidderName: "appnexus"}, nil, nil, nil, bidRequestOptions{}, openrtb_ext.ExtAlternateBidderCodes{}, nil, nil)
+
+	assert.Empty(t, seatBids)
+	if assert.Len(t, errs, 1) {
+		assert.IsType(t, &errortypes.BidderTemporarilyDisabled{}, errs[0])
+		assert.Contains(t, errs[0].Error(), `Bidder "appnexus" has been disabled on this instance of Prebid Server`)
+	}
+}
+
+func adapterNames(adapters map[openrtb_ext.BidderName]AdaptedBidder) []openrtb_ext.BidderName {
+	keys := make([]openrtb_ext.BidderName, 0, len(adapters))
+	for bidder := range adapters {
+		keys = append(keys, bidder)
+	}
+	return keys
+}
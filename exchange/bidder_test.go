@@ -3566,3 +3566,32 @@ func BenchmarkCompressToGZIPOptimized(b *testing.B) {
 		getRequestBody(req, "GZIP")
 	}
 }
+
+func TestHttpInfoToStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		httpInfo *httpCallInfo
+		want     int
+	}{
+		{
+			name:     "response",
+			httpInfo: &httpCallInfo{response: &adapters.ResponseData{StatusCode: http.StatusNoContent}},
+			want:     http.StatusNoContent,
+		},
+		{
+			name:     "error_status_response",
+			httpInfo: &httpCallInfo{response: &adapters.ResponseData{StatusCode: http.StatusBadRequest}, err: &errortypes.BadServerResponse{}},
+			want:     http.StatusBadRequest,
+		},
+		{
+			name:     "no_response",
+			httpInfo: &httpCallInfo{err: context.DeadlineExceeded},
+			want:     0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, httpInfoToStatus(test.httpInfo))
+		})
+	}
+}
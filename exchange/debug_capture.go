@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/debugcapture"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// captureDebug hands the resolved request, the bidder calls and the response of an auction sampled
+// by the debug capture to the capturer.
+func (e *exchange) captureDebug(r *AuctionRequest, adapterExtra map[openrtb_ext.BidderName]*seatResponseExtra, bidResponse *openrtb2.BidResponse) {
+	httpCalls := make(map[openrtb_ext.BidderName][]*openrtb_ext.ExtHttpCall, len(adapterExtra))
+	for bidderName, responseExtra := range adapterExtra {
+		if len(responseExtra.capturedHttpCalls) > 0 {
+			httpCalls[bidderName] = responseExtra.capturedHttpCalls
+		}
+	}
+
+	record := debugcapture.Record{
+		Time:            time.Now(),
+		AccountID:       r.Account.ID,
+		RequestID:       r.BidRequestWrapper.ID,
+		ResolvedRequest: r.ResolvedBidRequest,
+		HttpCalls:       httpCalls,
+	}
+	response, err := jsonutil.Marshal(bidResponse)
+	if err != nil {
+		glog.Errorf("Failed to marshal the response of request %s for the debug capture: %v", record.RequestID, err)
+	} else {
+		record.Response = response
+	}
+	e.debugCapturer.Record(record)
+}
@@ -0,0 +1,37 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/debugcapture"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureDebug(t *testing.T) {
+	buffer := debugcapture.NewRingBuffer(1)
+	e := &exchange{debugCapturer: debugcapture.NewCapturer(buffer)}
+	r := &AuctionRequest{
+		BidRequestWrapper:  &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "request"}},
+		Account:            config.Account{ID: "account"},
+		ResolvedBidRequest: []byte(`{"id":"request"}`),
+	}
+	capturedCall := &openrtb_ext.ExtHttpCall{Uri: "http://appnexus.com", Status: 200}
+	adapterExtra := map[openrtb_ext.BidderName]*seatResponseExtra{
+		"appnexus": {capturedHttpCalls: []*openrtb_ext.ExtHttpCall{capturedCall}},
+		"rubicon":  {HttpCalls: []*openrtb_ext.ExtHttpCall{{Uri: "http://rubicon.com"}}},
+	}
+
+	e.captureDebug(r, adapterExtra, &openrtb2.BidResponse{ID: "request"})
+
+	records := buffer.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "account", records[0].AccountID)
+	assert.Equal(t, "request", records[0].RequestID)
+	assert.JSONEq(t, `{"id":"request"}`, string(records[0].ResolvedRequest))
+	assert.Equal(t, map[openrtb_ext.BidderName][]*openrtb_ext.ExtHttpCall{"appnexus": {capturedCall}}, records[0].HttpCalls)
+	assert.JSONEq(t, `{"id":"request"}`, string(records[0].Response))
+}
@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prebid/prebid-server/v3/ortb"
@@ -19,9 +20,11 @@ import (
 
 	"github.com/prebid/prebid-server/v3/adapters"
 	"github.com/prebid/prebid-server/v3/adservertargeting"
+	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/bidadjustment"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/debugcapture"
 	"github.com/prebid/prebid-server/v3/dsa"
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/exchange/entities"
@@ -32,6 +35,7 @@ import (
 	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
 	"github.com/prebid/prebid-server/v3/macros"
 	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/prebid_cache_client"
 	"github.com/prebid/prebid-server/v3/stored_requests"
@@ -64,6 +68,9 @@ type IdFetcher interface {
 }
 
 type exchange struct {
+	// biddersMutex guards the bidder fields swapped by UpdateBidders: adapterMap, bidderInfo,
+	// singleFormatBidders and requestSplitter.bidderInfo
+	biddersMutex             sync.RWMutex
 	adapterMap               map[openrtb_ext.BidderName]AdaptedBidder
 	bidderInfo               config.BidderInfos
 	bidderToSyncerKey        map[string]string
@@ -86,6 +93,9 @@ type exchange struct {
 	priceFloorEnabled        bool
 	priceFloorFetcher        floors.FloorFetcher
 	singleFormatBidders      map[openrtb_ext.BidderName]struct{}
+	bidNotifier              notifications.Notifier
+	bidStore                 notifications.BidStore
+	debugCapturer            *debugcapture.Capturer
 }
 
 // Container to pass out response ext data from the GetAllBids goroutines back into the main thread
@@ -98,6 +108,8 @@ type seatResponseExtra struct {
 	HttpCalls []*openrtb_ext.ExtHttpCall
 	// NonBid contains non bid reason information
 	NonBid *openrtb_ext.NonBid
+	// capturedHttpCalls is the list of debugging info kept for the debug capture, it never reaches the response
+	capturedHttpCalls []*openrtb_ext.ExtHttpCall
 }
 
 type bidResponseWrapper struct {
@@ -107,6 +119,7 @@ type bidResponseWrapper struct {
 	adapter                 openrtb_ext.BidderName
 	bidderResponseStartTime time.Time
 	seatNonBidBuilder       SeatNonBidBuilder
+	bidderCall              *analytics.BidderCall
 }
 
 type BidIDGenerator interface {
@@ -137,7 +150,7 @@ func (randomDeduplicateBidBooleanGenerator) Generate() bool {
 	return rand.Intn(100) < 50
 }
 
-func NewExchange(adapters map[openrtb_ext.BidderName]AdaptedBidder, cache prebid_cache_client.Client, cfg *config.Configuration, requestValidator ortb.RequestValidator, syncersByBidder map[string]usersync.Syncer, metricsEngine metrics.MetricsEngine, infos config.BidderInfos, gdprPermsBuilder gdpr.PermissionsBuilder, currencyConverter *currency.RateConverter, categoriesFetcher stored_requests.CategoryFetcher, adsCertSigner adscert.Signer, macroReplacer macros.Replacer, priceFloorFetcher floors.FloorFetcher, singleFormatBidders map[openrtb_ext.BidderName]struct{}) Exchange {
+func NewExchange(adapters map[openrtb_ext.BidderName]AdaptedBidder, cache prebid_cache_client.Client, cfg *config.Configuration, requestValidator ortb.RequestValidator, syncersByBidder map[string]usersync.Syncer, metricsEngine metrics.MetricsEngine, infos config.BidderInfos, gdprPermsBuilder gdpr.PermissionsBuilder, currencyConverter *currency.RateConverter, categoriesFetcher stored_requests.CategoryFetcher, adsCertSigner adscert.Signer, macroReplacer macros.Replacer, priceFloorFetcher floors.FloorFetcher, singleFormatBidders map[openrtb_ext.BidderName]struct{}, bidStore notifications.BidStore, bidNotifier notifications.Notifier, debugCapturer *debugcapture.Capturer) Exchange {
 	bidderToSyncerKey := map[string]string{}
 	for bidder, syncer := range syncersByBidder {
 		bidderToSyncerKey[bidder] = syncer.Key()
@@ -186,6 +199,9 @@ func NewExchange(adapters map[openrtb_ext.BidderName]AdaptedBidder, cache prebid
 		priceFloorEnabled:        cfg.PriceFloors.Enabled,
 		priceFloorFetcher:        priceFloorFetcher,
 		singleFormatBidders:      singleFormatBidders,
+		bidNotifier:              bidNotifier,
+		bidStore:                 bidStore,
+		debugCapturer:            debugCapturer,
 	}
 }
 
@@ -275,7 +291,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 	}
 
 	// Get currency rates conversions for the auction
-	conversions := currency.GetAuctionCurrencyRates(e.currencyConverter, requestExtPrebid.CurrencyConversions)
+	conversions := currency.GetAuctionCurrencyRates(e.currencyConverter, requestExtPrebid.CurrencyConversions, &r.Account.Currency)
 
 	var floorErrs []error
 	if e.priceFloorEnabled {
@@ -283,9 +299,10 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 	}
 
 	responseDebugAllow, accountDebugAllow, debugLog := getDebugInfo(r.BidRequestWrapper.Test, requestExtPrebid, r.Account.DebugAllow, debugLog)
+	captureDebug := e.debugCapturer.Sample(r.Account.ID)
 
 	// save incoming request with stored requests (if applicable) to return in debug logs
-	if responseDebugAllow || len(requestExtPrebid.AdServerTargeting) > 0 {
+	if responseDebugAllow || captureDebug || len(requestExtPrebid.AdServerTargeting) > 0 {
 		if err := r.BidRequestWrapper.RebuildRequest(); err != nil {
 			return nil, err
 		}
@@ -347,7 +364,11 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		Prebid: *requestExtPrebid,
 		SChain: requestExt.GetSChain(),
 	}
-	bidderRequests, privacyLabels, errs := e.requestSplitter.cleanOpenRTBRequests(ctx, *r, requestExtLegacy, gdprSignal, gdprEnforced, bidAdjustmentFactors)
+	e.biddersMutex.RLock()
+	splitter, bidderInfo, singleFormatBidders := e.requestSplitter, e.bidderInfo, e.singleFormatBidders
+	e.biddersMutex.RUnlock()
+
+	bidderRequests, privacyLabels, errs := splitter.cleanOpenRTBRequests(ctx, *r, requestExtLegacy, gdprSignal, gdprEnforced, bidAdjustmentFactors)
 	for _, err := range errs {
 		if errortypes.ReadCode(err) == errortypes.InvalidImpFirstPartyDataErrorCode {
 			return nil, err
@@ -383,6 +404,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		// List of bidders we have requests for.
 		liveAdapters      []openrtb_ext.BidderName
 		seatNonBidBuilder SeatNonBidBuilder = SeatNonBidBuilder{}
+		bidderCalls       []*analytics.BidderCall
 	)
 
 	if len(r.StoredAuctionResponses) > 0 {
@@ -404,32 +426,33 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 			alternateBidderCodes = *r.Account.AlternateBidderCodes
 		}
 
-		liveAdaptersPreferredMediaType := getBidderPreferredMediaTypeMap(requestExtPrebid, &r.Account, liveAdapters, e.singleFormatBidders)
+		liveAdaptersPreferredMediaType := getBidderPreferredMediaTypeMap(requestExtPrebid, &r.Account, liveAdapters, singleFormatBidders)
 
 		var extraRespInfo extraAuctionResponseInfo
-		adapterBids, adapterExtra, extraRespInfo = e.getAllBids(auctionCtx, bidderRequests, bidAdjustmentFactors, conversions, accountDebugAllow, r.GlobalPrivacyControlHeader, debugLog.DebugOverride, alternateBidderCodes, requestExtLegacy.Prebid.Experiment, r.HookExecutor, r.StartTime, bidAdjustmentRules, r.TmaxAdjustments, responseDebugAllow, liveAdaptersPreferredMediaType)
+		adapterBids, adapterExtra, extraRespInfo = e.getAllBids(auctionCtx, bidderRequests, bidAdjustmentFactors, conversions, accountDebugAllow, r.GlobalPrivacyControlHeader, debugLog.DebugOverride, alternateBidderCodes, requestExtLegacy.Prebid.Experiment, r.HookExecutor, r.StartTime, bidAdjustmentRules, r.TmaxAdjustments, responseDebugAllow, captureDebug, liveAdaptersPreferredMediaType)
 		fledge = extraRespInfo.fledge
 		anyBidsReturned = extraRespInfo.bidsFound
 		r.BidderResponseStartTime = extraRespInfo.bidderResponseStartTime
 		if extraRespInfo.seatNonBidBuilder != nil {
 			seatNonBidBuilder = extraRespInfo.seatNonBidBuilder
 		}
+		bidderCalls = extraRespInfo.bidderCalls
 	}
 
 	var (
-		auc            *auction
-		cacheErrs      []error
-		bidResponseExt *openrtb_ext.ExtBidResponse
+		auc               *auction
+		cacheErrs         []error
+		bidResponseExt    *openrtb_ext.ExtBidResponse
+		floorRejectedBids []*entities.PbsOrtbSeatBid
 	)
 
 	if anyBidsReturned {
 		if e.priceFloorEnabled {
-			var rejectedBids []*entities.PbsOrtbSeatBid
 			var enforceErrs []error
 
-			adapterBids, enforceErrs, rejectedBids = floors.Enforce(r.BidRequestWrapper, adapterBids, r.Account, conversions)
+			adapterBids, enforceErrs, floorRejectedBids = floors.Enforce(r.BidRequestWrapper, adapterBids, r.Account, conversions)
 			errs = append(errs, enforceErrs...)
-			for _, rejectedBid := range rejectedBids {
+			for _, rejectedBid := range floorRejectedBids {
 				errs = append(errs, &errortypes.Warning{
 					Message:     fmt.Sprintf("%s bid id %s rejected - bid price %.4f %s is less than bid floor %.4f %s for imp %s", rejectedBid.Seat, rejectedBid.Bids[0].Bid.ID, rejectedBid.Bids[0].Bid.Price, rejectedBid.Currency, rejectedBid.Bids[0].BidFloors.FloorValue, rejectedBid.Bids[0].BidFloors.FloorCurrency, rejectedBid.Bids[0].Bid.ImpID),
 					WarningCode: errortypes.FloorBidRejectionWarningCode})
@@ -466,7 +489,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 			}
 		}
 
-		evTracking := getEventTracking(requestExtPrebid, r.StartTime, &r.Account, e.bidderInfo, e.externalURL)
+		evTracking := getEventTracking(requestExtPrebid, r.StartTime, &r.Account, bidderInfo, e.externalURL)
 		adapterBids = evTracking.modifyBidsForEvents(adapterBids)
 
 		r.HookExecutor.ExecuteAllProcessedBidResponsesStage(adapterBids)
@@ -543,15 +566,28 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 	bidResponse := e.buildBidResponse(ctx, liveAdapters, adapterBids, r.BidRequestWrapper, adapterExtra, auc, bidResponseExt, cacheInstructions.returnCreative, r.ImpExtInfoMap, r.PubID, errs, &seatNonBidBuilder)
 	bidResponse = adservertargeting.Apply(r.BidRequestWrapper, r.ResolvedBidRequest, bidResponse, r.QueryParams, bidResponseExt, r.Account.TruncateTargetAttribute)
 
+	if auc != nil {
+		e.recordWins(auc, bidResponse)
+		e.notifyBids(r, auc, bidResponse, floorRejectedBids)
+	}
+
 	bidResponse.Ext, err = encodeBidResponseExt(bidResponseExt)
 	if err != nil {
 		return nil, err
 	}
+	seatNonBidBuilder.appendSeatNonBid(r.HookExecutor.GetSeatNonBid())
 	bidResponseExt = setSeatNonBid(bidResponseExt, seatNonBidBuilder)
+	setBidderCallsNonBids(bidderCalls, seatNonBidBuilder)
+
+	if captureDebug {
+		e.captureDebug(r, adapterExtra, bidResponse)
+	}
 
 	return &AuctionResponse{
-		BidResponse:    bidResponse,
-		ExtBidResponse: bidResponseExt,
+		BidResponse:         bidResponse,
+		ExtBidResponse:      bidResponseExt,
+		CurrencyConversions: conversions,
+		BidderCalls:         bidderCalls,
 	}, nil
 }
 
@@ -754,6 +790,7 @@ func (e *exchange) getAllBids(
 	bidAdjustmentRules map[string][]openrtb_ext.Adjustment,
 	tmaxAdjustments *TmaxAdjustmentsPreprocessed,
 	responseDebugAllowed bool,
+	captureDebug bool,
 	liveAdaptersPreferredMediaType openrtb_ext.PreferredMediaType) (
 	map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid,
 	map[openrtb_ext.BidderName]*seatResponseExtra,
@@ -766,6 +803,10 @@ func (e *exchange) getAllBids(
 
 	e.me.RecordOverheadTime(metrics.MakeBidderRequests, time.Since(pbsRequestStartTime))
 
+	e.biddersMutex.RLock()
+	adapterMap, bidderInfo := e.adapterMap, e.bidderInfo
+	e.biddersMutex.RUnlock()
+
 	for _, bidder := range bidderRequests {
 		// Here we actually call the adapters and collect the bids.
 		bidderRunner := e.recoverSafely(bidderRequests, func(bidderRequest BidderRequest, conversions currency.Conversions) {
@@ -796,13 +837,19 @@ func (e *exchange) getAllBids(
 			bidReqOptions := bidRequestOptions{
 				accountDebugAllowed:    accountDebugAllowed,
 				headerDebugAllowed:     headerDebugAllowed,
-				addCallSignHeader:      isAdsCertEnabled(experiment, e.bidderInfo[string(bidderRequest.BidderName)]),
+				addCallSignHeader:      isAdsCertEnabled(experiment, bidderInfo[string(bidderRequest.BidderName)]),
 				bidAdjustments:         bidAdjustments,
 				tmaxAdjustments:        tmaxAdjustments,
 				bidderRequestStartTime: start,
 				responseDebugAllowed:   responseDebugAllowed,
+				captureDebug:           captureDebug,
 			}
-			seatBids, extraBidderRespInfo, err := e.adapterMap[bidderRequest.BidderCoreName].requestBid(ctx, bidderRequest, conversions, &reqInfo, e.adsCertSigner, bidReqOptions, alternateBidderCodes, hookExecutor, bidAdjustmentRules)
+			adapter, ok := adapterMap[bidderRequest.BidderCoreName]
+			if !ok {
+				// the bidder was disabled by UpdateBidders after the request was validated
+				adapter = disabledAdapter{}
+			}
+			seatBids, extraBidderRespInfo, err := adapter.requestBid(ctx, bidderRequest, conversions, &reqInfo, e.adsCertSigner, bidReqOptions, alternateBidderCodes, hookExecutor, bidAdjustmentRules)
 			brw.bidderResponseStartTime = extraBidderRespInfo.respProcessingStartTime
 
 			// Add in time reporting
@@ -815,6 +862,7 @@ func (e *exchange) getAllBids(
 			if len(seatBids) != 0 {
 				ae.HttpCalls = seatBids[0].HttpCalls
 			}
+			ae.capturedHttpCalls = extraBidderRespInfo.capturedHttpCalls
 			// Timing statistics
 			e.me.RecordAdapterTime(bidderRequest.BidderLabels, elapsed)
 			bidderRequest.BidderLabels.AdapterBids = bidsToMetric(brw.adapterSeatBids)
@@ -823,6 +871,7 @@ func (e *exchange) getAllBids(
 			ae.Errors = errsToBidderErrors(err)
 			ae.Warnings = errsToBidderWarnings(err)
 			brw.adapterExtra = ae
+			brw.bidderCall = newBidderCall(bidderRequest.BidderName, bidderRequest.BidderCoreName, seatBids, ae, extraBidderRespInfo.httpStatuses)
 			for _, seatBid := range seatBids {
 				if seatBid != nil {
 					for _, bid := range seatBid.Bids {
@@ -865,7 +914,11 @@ func (e *exchange) getAllBids(
 		// collect adapter non bids
 		extraRespInfo.seatNonBidBuilder.append(brw.seatNonBidBuilder)
 
+		if brw.bidderCall != nil {
+			extraRespInfo.bidderCalls = append(extraRespInfo.bidderCalls, brw.bidderCall)
+		}
 	}
+	sortBidderCalls(extraRespInfo.bidderCalls)
 
 	return adapterBids, adapterExtra, extraRespInfo
 }
@@ -1599,7 +1652,7 @@ func isAdsCertEnabled(experiment *openrtb_ext.Experiment, info config.BidderInfo
 	return requestAdsCertEnabled && bidderAdsCertEnabled
 }
 
-func (e exchange) validateBannerCreativeSize(bid *entities.PbsOrtbBid, bidResponseExt *openrtb_ext.ExtBidResponse, adapter openrtb_ext.BidderName, pubID string, validationType string) bool {
+func (e *exchange) validateBannerCreativeSize(bid *entities.PbsOrtbBid, bidResponseExt *openrtb_ext.ExtBidResponse, adapter openrtb_ext.BidderName, pubID string, validationType string) bool {
 	if bid.Bid.W > e.bidValidationEnforcement.MaxCreativeWidth || bid.Bid.H > e.bidValidationEnforcement.MaxCreativeHeight {
 		// Add error to debug array
 		errorMessage := setErrorMessageCreativeSize(validationType)
@@ -1617,7 +1670,7 @@ func (e exchange) validateBannerCreativeSize(bid *entities.PbsOrtbBid, bidRespon
 	return true
 }
 
-func (e exchange) validateBidAdM(bid *entities.PbsOrtbBid, bidResponseExt *openrtb_ext.ExtBidResponse, adapter openrtb_ext.BidderName, pubID string, validationType string) bool {
+func (e *exchange) validateBidAdM(bid *entities.PbsOrtbBid, bidResponseExt *openrtb_ext.ExtBidResponse, adapter openrtb_ext.BidderName, pubID string, validationType string) bool {
 	invalidAdM := []string{"http:", "http%3A"}
 	requiredAdM := []string{"https:", "https%3A"}
 
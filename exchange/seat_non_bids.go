@@ -75,3 +75,13 @@ func (b SeatNonBidBuilder) append(nonBids ...SeatNonBidBuilder) {
 		}
 	}
 }
+
+// appendSeatNonBid adds the non bids of the given seats, like the ones reported by the hooks.
+func (b SeatNonBidBuilder) appendSeatNonBid(seatNonBids []openrtb_ext.SeatNonBid) {
+	if b == nil {
+		return
+	}
+	for _, seatNonBid := range seatNonBids {
+		b[seatNonBid.Seat] = append(b[seatNonBid.Seat], seatNonBid.NonBid...)
+	}
+}
@@ -254,6 +254,46 @@ func TestAppend(t *testing.T) {
 	}
 }
 
+func TestAppendSeatNonBid(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  SeatNonBidBuilder
+		toAppend []openrtb_ext.SeatNonBid
+		expected SeatNonBidBuilder
+	}{
+		{
+			name:     "nil_buider",
+			builder:  nil,
+			toAppend: []openrtb_ext.SeatNonBid{{Seat: "seat1", NonBid: []openrtb_ext.NonBid{{ImpId: "imp1"}}}},
+			expected: nil,
+		},
+		{
+			name:     "nil_append",
+			builder:  SeatNonBidBuilder{"seat1": []openrtb_ext.NonBid{{ImpId: "imp1"}}},
+			toAppend: nil,
+			expected: SeatNonBidBuilder{"seat1": []openrtb_ext.NonBid{{ImpId: "imp1"}}},
+		},
+		{
+			name:    "append_same_and_different_seats",
+			builder: SeatNonBidBuilder{"seat1": []openrtb_ext.NonBid{{ImpId: "imp1"}}},
+			toAppend: []openrtb_ext.SeatNonBid{
+				{Seat: "seat1", NonBid: []openrtb_ext.NonBid{{ImpId: "imp2"}}},
+				{Seat: "seat2", NonBid: []openrtb_ext.NonBid{{ImpId: "imp3"}}},
+			},
+			expected: SeatNonBidBuilder{
+				"seat1": []openrtb_ext.NonBid{{ImpId: "imp1"}, {ImpId: "imp2"}},
+				"seat2": []openrtb_ext.NonBid{{ImpId: "imp3"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.builder.appendSeatNonBid(tt.toAppend)
+			assert.Equal(t, tt.expected, tt.builder)
+		})
+	}
+}
+
 func TestRejectImps(t *testing.T) {
 	tests := []struct {
 		name    string
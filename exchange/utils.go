@@ -247,6 +247,7 @@ func (rs *requestSplitter) cleanOpenRTBRequests(ctx context.Context,
 			bidderLabels.Source = auctionReq.LegacyLabels.Source
 			bidderLabels.RType = auctionReq.LegacyLabels.RType
 			bidderLabels.PubID = auctionReq.LegacyLabels.PubID
+			bidderLabels.RequestID = auctionReq.LegacyLabels.RequestID
 			bidderLabels.CookieFlag = auctionReq.LegacyLabels.CookieFlag
 			bidderLabels.AdapterBids = metrics.AdapterBidPresent
 		}
@@ -0,0 +1,36 @@
+package exchange
+
+import (
+	"slices"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/metrics"
+)
+
+// recordWins records the metrics of the bids winning the auction of their imp and returned in the response
+func (e *exchange) recordWins(auc *auction, bidResponse *openrtb2.BidResponse) {
+	responseBids := bidIDsBySeat(bidResponse)
+
+	for impID, winner := range auc.winningBids {
+		for seat, bids := range auc.allBidsByBidder[impID] {
+			if !slices.Contains(bids, winner) {
+				continue
+			}
+			if _, inResponse := responseBids[seat.String()][winner.Bid.ID]; !inResponse {
+				break
+			}
+
+			adapter := winner.AdapterCode
+			if adapter == "" {
+				adapter = seat
+			}
+			e.me.RecordAdapterWin(metrics.AdapterWinLabels{
+				Adapter: adapter,
+				Seat:    seat.String(),
+				BidType: winner.BidType,
+				Deal:    winner.Bid.DealID != "",
+			}, winner.Bid.Price*1000)
+			break
+		}
+	}
+}
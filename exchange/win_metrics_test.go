@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+)
+
+func TestRecordWins(t *testing.T) {
+	deal := &entities.PbsOrtbBid{
+		Bid:         &openrtb2.Bid{ID: "deal", ImpID: "imp-1", Price: 2, DealID: "deal"},
+		BidType:     openrtb_ext.BidTypeVideo,
+		AdapterCode: openrtb_ext.BidderAppnexus,
+	}
+	loser := &entities.PbsOrtbBid{
+		Bid:         &openrtb2.Bid{ID: "lose", ImpID: "imp-1", Price: 3},
+		BidType:     openrtb_ext.BidTypeVideo,
+		AdapterCode: openrtb_ext.BidderRubicon,
+	}
+	openMarket := &entities.PbsOrtbBid{
+		Bid:         &openrtb2.Bid{ID: "open", ImpID: "imp-2", Price: 1.5},
+		BidType:     openrtb_ext.BidTypeBanner,
+		AdapterCode: openrtb_ext.BidderAppnexus,
+	}
+	removed := &entities.PbsOrtbBid{
+		Bid:         &openrtb2.Bid{ID: "removed", ImpID: "imp-3", Price: 1},
+		BidType:     openrtb_ext.BidTypeBanner,
+		AdapterCode: openrtb_ext.BidderPubmatic,
+	}
+
+	auc := &auction{
+		winningBids: map[string]*entities.PbsOrtbBid{"imp-1": deal, "imp-2": openMarket, "imp-3": removed},
+		allBidsByBidder: map[string]map[openrtb_ext.BidderName][]*entities.PbsOrtbBid{
+			"imp-1": {"appnexus": {deal}, "rubicon": {loser}},
+			"imp-2": {"alternate-seat": {openMarket}},
+			"imp-3": {"pubmatic": {removed}},
+		},
+	}
+	bidResponse := &openrtb2.BidResponse{
+		SeatBid: []openrtb2.SeatBid{
+			{Seat: "appnexus", Bid: []openrtb2.Bid{{ID: "deal"}}},
+			{Seat: "rubicon", Bid: []openrtb2.Bid{{ID: "lose"}}},
+			{Seat: "alternate-seat", Bid: []openrtb2.Bid{{ID: "open"}}},
+		},
+	}
+
+	me := &metrics.MetricsEngineMock{}
+	me.On("RecordAdapterWin", metrics.AdapterWinLabels{Adapter: openrtb_ext.BidderAppnexus, Seat: "appnexus", BidType: openrtb_ext.BidTypeVideo, Deal: true}, float64(2000)).Once()
+	me.On("RecordAdapterWin", metrics.AdapterWinLabels{Adapter: openrtb_ext.BidderAppnexus, Seat: "alternate-seat", BidType: openrtb_ext.BidTypeBanner}, float64(1500)).Once()
+
+	e := &exchange{me: me}
+	e.recordWins(auc, bidResponse)
+
+	me.AssertExpectations(t)
+	me.AssertNumberOfCalls(t, "RecordAdapterWin", 2)
+}
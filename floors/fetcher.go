@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"math"
 	"net/http"
@@ -192,6 +193,21 @@ func (f *PriceFloorFetcher) Stop() {
 	close(f.configReceiver)
 }
 
+// CheckHealth fails when the fetcher is stopped or when the channel receiving the fetch requests is full, the
+// auctions missing floors in the cache then block until the fetcher catches up
+func (f *PriceFloorFetcher) CheckHealth(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return errors.New("price floor fetcher is stopped")
+	default:
+	}
+
+	if capacity := cap(f.configReceiver); capacity > 0 && len(f.configReceiver) >= capacity {
+		return fmt.Errorf("price floor fetcher queue is full with %d pending fetches", capacity)
+	}
+	return nil
+}
+
 func (f *PriceFloorFetcher) submit(fetchConfig *fetchInfo) {
 	status := f.pool.TrySubmit(func() {
 		f.worker(*fetchConfig)
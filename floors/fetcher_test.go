@@ -1,6 +1,7 @@
 package floors
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -1305,3 +1306,18 @@ func TestFetchWhenPriceFloorsDisabled(t *testing.T) {
 	assert.Equal(t, (*openrtb_ext.PriceFloorRules)(nil), data, "floor data should be nil as fetcher instance does not created")
 	assert.Equal(t, openrtb_ext.FetchNone, status, "floor status should be none as fetcher instance does not created")
 }
+
+func TestPriceFloorFetcherCheckHealth(t *testing.T) {
+	fetcherInstance := &PriceFloorFetcher{
+		configReceiver: make(chan fetchInfo, 1),
+		done:           make(chan struct{}),
+	}
+	assert.NoError(t, fetcherInstance.CheckHealth(context.Background()), "empty queue")
+
+	fetcherInstance.configReceiver <- fetchInfo{}
+	assert.EqualError(t, fetcherInstance.CheckHealth(context.Background()), "price floor fetcher queue is full with 1 pending fetches")
+
+	<-fetcherInstance.configReceiver
+	close(fetcherInstance.done)
+	assert.EqualError(t, fetcherInstance.CheckHealth(context.Background()), "price floor fetcher is stopped")
+}
@@ -28,11 +28,32 @@ type VendorListFetcher func(ctx context.Context, specVersion uint16, listVersion
 // Nothing in this file is exported. Public APIs can be found in gdpr.go
 
 func NewVendorListFetcher(initCtx context.Context, cfg config.GDPR, client *http.Client, urlMaker func(uint16, uint16) string) VendorListFetcher {
+	fetcher, _ := NewVendorListFetcherWithHealth(initCtx, cfg, client, urlMaker)
+	return fetcher
+}
+
+// VendorListHealth reports whether the latest vendor list of every spec version was loaded when the fetcher was
+// created. Without it the consent strings of the recent lists can't be enforced until they're fetched on demand.
+type VendorListHealth struct {
+	missingSpecVersions []uint16
+}
+
+func (h *VendorListHealth) CheckHealth(ctx context.Context) error {
+	if len(h.missingSpecVersions) > 0 {
+		return fmt.Errorf("the latest gdpr vendor list of spec versions %v could not be loaded", h.missingSpecVersions)
+	}
+	return nil
+}
+
+// NewVendorListFetcherWithHealth returns a VendorListFetcher along with the health of the lists it preloaded
+func NewVendorListFetcherWithHealth(initCtx context.Context, cfg config.GDPR, client *http.Client, urlMaker func(uint16, uint16) string) (VendorListFetcher, *VendorListHealth) {
 	cacheSave, cacheLoad := newVendorListCache()
 
 	preloadContext, cancel := context.WithTimeout(initCtx, cfg.Timeouts.InitTimeout())
 	defer cancel()
-	preloadCache(preloadContext, client, urlMaker, cacheSave)
+	health := &VendorListHealth{
+		missingSpecVersions: preloadCache(preloadContext, client, urlMaker, cacheSave),
+	}
 
 	saveOneRateLimited := newOccasionalSaver(cfg.Timeouts.ActiveTimeout())
 	return func(ctx context.Context, specVersion, listVersion uint16) (vendorlist.VendorList, error) {
@@ -53,15 +74,16 @@ func NewVendorListFetcher(initCtx context.Context, cfg config.GDPR, client *http
 
 		// Give Up
 		return nil, makeVendorListNotFoundError(specVersion, listVersion)
-	}
+	}, health
 }
 
 func makeVendorListNotFoundError(specVersion, listVersion uint16) error {
 	return fmt.Errorf("gdpr vendor list spec version %d list version %d does not exist, or has not been loaded yet. Try again in a few minutes", specVersion, listVersion)
 }
 
-// preloadCache saves all the known versions of the vendor list for future use.
-func preloadCache(ctx context.Context, client *http.Client, urlMaker func(uint16, uint16) string, saver saveVendors) {
+// preloadCache saves all the known versions of the vendor list for future use. It returns the spec versions
+// whose latest vendor list couldn't be loaded.
+func preloadCache(ctx context.Context, client *http.Client, urlMaker func(uint16, uint16) string, saver saveVendors) (missingSpecVersions []uint16) {
 	versions := [2]struct {
 		specVersion      uint16
 		firstListVersion uint16
@@ -77,11 +99,15 @@ func preloadCache(ctx context.Context, client *http.Client, urlMaker func(uint16
 	}
 	for _, v := range versions {
 		latestVersion := saveOne(ctx, client, urlMaker(v.specVersion, 0), saver)
+		if latestVersion == 0 {
+			missingSpecVersions = append(missingSpecVersions, v.specVersion)
+		}
 
 		for i := v.firstListVersion; i < latestVersion; i++ {
 			saveOne(ctx, client, urlMaker(v.specVersion, i), saver)
 		}
 	}
+	return
 }
 
 // Make a URL which can be used to fetch a given version of the Global Vendor List. If the version is 0,
@@ -147,6 +147,24 @@ func TestServerUnavailable(t *testing.T) {
 	assert.EqualError(t, err, "gdpr vendor list spec version 3 list version 1 does not exist, or has not been loaded yet. Try again in a few minutes")
 }
 
+func TestVendorListHealth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(mockServer(serverSettings{
+		vendorListLatestVersion: 1,
+		vendorLists: map[int]map[int]string{
+			3: {
+				1: MarshalVendorList(vendorList{GVLSpecificationVersion: 3, VendorListVersion: 1}),
+			},
+		},
+	})))
+	defer server.Close()
+
+	_, health := NewVendorListFetcherWithHealth(context.Background(), testConfig(), server.Client(), testURLMaker(server))
+	assert.EqualError(t, health.CheckHealth(context.Background()), "the latest gdpr vendor list of spec versions [2] could not be loaded")
+
+	health = &VendorListHealth{}
+	assert.NoError(t, health.CheckHealth(context.Background()))
+}
+
 func TestVendorListURLMaker(t *testing.T) {
 	testCases := []struct {
 		description string
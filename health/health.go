@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Names of the checks of the dependencies of Prebid Server
+const (
+	CheckStoredRequests = "stored_requests"
+	CheckCurrencyRates  = "currency_rates"
+	CheckGVL            = "gvl"
+	CheckFloorsFetcher  = "floors_fetcher"
+	CheckPrebidCache    = "prebid_cache"
+	CheckModules        = "modules"
+)
+
+// CheckNames returns the names of all the checks
+func CheckNames() []string {
+	return []string{
+		CheckStoredRequests,
+		CheckCurrencyRates,
+		CheckGVL,
+		CheckFloorsFetcher,
+		CheckPrebidCache,
+		CheckModules,
+	}
+}
+
+// Checker is implemented by the dependencies able to report their state. A nil error means the
+// dependency is healthy.
+type Checker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to the Checker interface
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Check is a named Checker
+type Check struct {
+	Name    string
+	Checker Checker
+	// GatesReadiness is true when a failure of the check makes Prebid Server not ready to serve requests
+	GatesReadiness bool
+}
+
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+)
+
+// Result is the outcome of a check
+type Result struct {
+	Status         Status `json:"status"`
+	Error          string `json:"error,omitempty"`
+	DurationMillis int64  `json:"duration_ms"`
+	GatesReadiness bool   `json:"gates_readiness"`
+}
+
+// Report is the outcome of all the checks, its status fails when a check gating the readiness fails
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Run runs the checks concurrently, a check not returning within the timeout fails
+func Run(ctx context.Context, checks []Check, timeout time.Duration) Report {
+	report := Report{
+		Status: StatusPass,
+		Checks: make(map[string]Result, len(checks)),
+	}
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check, timeout)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status == StatusFail && check.GatesReadiness {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, check Check, timeout time.Duration) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- check.Checker.CheckHealth(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{
+		Status:         StatusPass,
+		DurationMillis: time.Since(start).Milliseconds(),
+		GatesReadiness: check.GatesReadiness,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	pass := CheckerFunc(func(ctx context.Context) error { return nil })
+	fail := CheckerFunc(func(ctx context.Context) error { return errors.New("unreachable") })
+	hang := CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	testCases := []struct {
+		name           string
+		checks         []Check
+		expectedStatus Status
+		expectedErrors map[string]string
+	}{
+		{
+			name:           "no-checks",
+			checks:         nil,
+			expectedStatus: StatusPass,
+			expectedErrors: map[string]string{},
+		},
+		{
+			name: "all-pass",
+			checks: []Check{
+				{Name: "a", Checker: pass, GatesReadiness: true},
+				{Name: "b", Checker: pass},
+			},
+			expectedStatus: StatusPass,
+			expectedErrors: map[string]string{"a": "", "b": ""},
+		},
+		{
+			name: "non-gating-failure",
+			checks: []Check{
+				{Name: "a", Checker: pass, GatesReadiness: true},
+				{Name: "b", Checker: fail},
+			},
+			expectedStatus: StatusPass,
+			expectedErrors: map[string]string{"a": "", "b": "unreachable"},
+		},
+		{
+			name: "gating-failure",
+			checks: []Check{
+				{Name: "a", Checker: fail, GatesReadiness: true},
+				{Name: "b", Checker: pass},
+			},
+			expectedStatus: StatusFail,
+			expectedErrors: map[string]string{"a": "unreachable", "b": ""},
+		},
+		{
+			name: "timeout",
+			checks: []Check{
+				{Name: "a", Checker: hang, GatesReadiness: true},
+			},
+			expectedStatus: StatusFail,
+			expectedErrors: map[string]string{"a": context.DeadlineExceeded.Error()},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			report := Run(context.Background(), test.checks, 10*time.Millisecond)
+
+			assert.Equal(t, test.expectedStatus, report.Status)
+			errs := make(map[string]string, len(report.Checks))
+			for name, result := range report.Checks {
+				errs[name] = result.Error
+				if result.Error == "" {
+					assert.Equal(t, StatusPass, result.Status, name)
+				} else {
+					assert.Equal(t, StatusFail, result.Status, name)
+				}
+			}
+			assert.Equal(t, test.expectedErrors, errs)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
 )
 
 // TimeoutError indicates exceeding of the max execution time allotted for hook.
@@ -34,6 +35,8 @@ type RejectError struct {
 	NBR   int
 	Hook  HookID
 	Stage string
+	// Response is the HTTP response the endpoint sends instead of its no-bid response, if any.
+	Response *hookstage.HTTPResponse
 }
 
 func (e RejectError) Code() int {
@@ -1,9 +1,11 @@
 package hookexecution
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/ortb"
 	"github.com/prebid/prebid-server/v3/privacy"
+	"github.com/prebid/prebid-server/v3/util/httputil"
 	"github.com/prebid/prebid-server/v3/util/iputil"
 )
 
@@ -23,6 +26,7 @@ type hookResponse[T any] struct {
 	Err           error
 	ExecutionTime time.Duration
 	HookID        HookID
+	Position      int // position of the hook in its group
 	Result        hookstage.HookResult[T]
 }
 
@@ -71,14 +75,19 @@ func executeGroup[H any, P any](
 	rejected := make(chan struct{})
 	resp := make(chan hookResponse[P], len(group.Hooks))
 
-	for _, hook := range group.Hooks {
+	declaredPaths := make([]hookstage.PayloadPaths, len(group.Hooks))
+	for i, hook := range group.Hooks {
+		if declarer, ok := any(hook.Hook).(hookstage.PayloadPathsDeclarer); ok {
+			declaredPaths[i] = declarer.PayloadPaths(executionCtx.stage)
+		}
+
 		mCtx := executionCtx.getModuleContext(hook.Module)
 		newPayload := handleModuleActivities(hook.Code, executionCtx.activityControl, payload, executionCtx.account)
 		wg.Add(1)
-		go func(hw hooks.HookWrapper[H], moduleCtx hookstage.ModuleInvocationContext) {
+		go func(position int, hw hooks.HookWrapper[H], moduleCtx hookstage.ModuleInvocationContext) {
 			defer wg.Done()
-			executeHook(moduleCtx, hw, newPayload, hookHandler, group.Timeout, resp, rejected)
-		}(hook, mCtx)
+			executeHook(moduleCtx, hw, position, newPayload, hookHandler, group.Timeout, resp, rejected)
+		}(i, hook, mCtx)
 	}
 
 	go func() {
@@ -87,13 +96,20 @@ func executeGroup[H any, P any](
 	}()
 
 	hookResponses := collectHookResponses(resp, rejected)
-
-	return handleHookResponses(executionCtx, hookResponses, payload, metricEngine)
+	// hooks complete in any order, their results are handled in the order of the group
+	// so that the mutations of the group are merged deterministically
+	slices.SortFunc(hookResponses, func(a, b hookResponse[P]) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+	merger := newMutationMerger(group.MergePolicy, declaredPaths, hookResponses)
+
+	return handleHookResponses(executionCtx, hookResponses, payload, merger, metricEngine)
 }
 
 func executeHook[H any, P any](
 	moduleCtx hookstage.ModuleInvocationContext,
 	hw hooks.HookWrapper[H],
+	position int,
 	payload P,
 	hookHandler hookHandler[H, P],
 	timeout time.Duration,
@@ -124,6 +140,7 @@ func executeHook[H any, P any](
 	select {
 	case res := <-hookRespCh:
 		res.HookID = hookId
+		res.Position = position
 		res.ExecutionTime = time.Since(startTime)
 		resp <- res
 	case <-time.After(timeout):
@@ -131,6 +148,7 @@ func executeHook[H any, P any](
 			Err:           TimeoutError{},
 			ExecutionTime: time.Since(startTime),
 			HookID:        hookId,
+			Position:      position,
 			Result:        hookstage.HookResult[P]{},
 		}
 	case <-rejected:
@@ -155,28 +173,31 @@ func handleHookResponses[P any](
 	executionCtx executionContext,
 	hookResponses []hookResponse[P],
 	payload P,
+	merger *mutationMerger,
 	metricEngine metrics.MetricsEngine,
 ) (GroupOutcome, P, groupModuleContext, *RejectError) {
 	groupOutcome := GroupOutcome{}
 	groupOutcome.InvocationResults = make([]HookOutcome, 0, len(hookResponses))
 	groupModuleCtx := make(groupModuleContext, len(hookResponses))
 
+	// hooks completed before the rejecting one are handled too, whatever their position
+	var rejectErr *RejectError
 	for _, r := range hookResponses {
 		groupModuleCtx[r.HookID.ModuleCode] = r.Result.ModuleContext
 		if r.ExecutionTime > groupOutcome.ExecutionTimeMillis {
 			groupOutcome.ExecutionTimeMillis = r.ExecutionTime
 		}
 
-		updatedPayload, hookOutcome, rejectErr := handleHookResponse(executionCtx, payload, r, metricEngine)
+		updatedPayload, hookOutcome, err := handleHookResponse(executionCtx, payload, r, merger, metricEngine)
 		groupOutcome.InvocationResults = append(groupOutcome.InvocationResults, hookOutcome)
 		payload = updatedPayload
 
-		if rejectErr != nil {
-			return groupOutcome, payload, groupModuleCtx, rejectErr
+		if err != nil {
+			rejectErr = err
 		}
 	}
 
-	return groupOutcome, payload, groupModuleCtx, nil
+	return groupOutcome, payload, groupModuleCtx, rejectErr
 }
 
 // moduleReplacer changes unwanted symbols to be in compliance with metric naming requirements
@@ -188,6 +209,7 @@ func handleHookResponse[P any](
 	ctx executionContext,
 	payload P,
 	hr hookResponse[P],
+	merger *mutationMerger,
 	metricEngine metrics.MetricsEngine,
 ) (P, HookOutcome, *RejectError) {
 	var rejectErr *RejectError
@@ -209,7 +231,12 @@ func handleHookResponse[P any](
 		handleHookError(hr, &hookOutcome, metricEngine, labels)
 		rejectErr = handleHookReject(ctx, hr, &hookOutcome, metricEngine, labels)
 	} else {
-		payload = handleHookMutations(payload, hr, &hookOutcome, metricEngine, labels)
+		payload = handleHookMutations(payload, hr, &hookOutcome, merger, metricEngine, labels)
+	}
+
+	// non bids are only reported by hooks whose result was applied
+	if hr.Err == nil && hookOutcome.Status == StatusSuccess {
+		hookOutcome.SeatNonBid = hr.Result.SeatNonBid
 	}
 
 	return payload, hookOutcome, rejectErr
@@ -270,6 +297,18 @@ func handleHookReject[P any](
 	}
 
 	rejectErr := &RejectError{NBR: hr.Result.NbrCode, Hook: hr.HookID, Stage: ctx.stage}
+	if response := hr.Result.RejectResponse; response != nil {
+		if !stage.RejectsRequest() {
+			hookOutcome.Warnings = append(hookOutcome.Warnings, fmt.Sprintf("reject response ignored, the %s stage rejects a single bidder", ctx.stage))
+		} else if response.StatusCode < 200 || response.StatusCode > 599 {
+			hookOutcome.Warnings = append(hookOutcome.Warnings, fmt.Sprintf("reject response ignored, invalid status code %d", response.StatusCode))
+		} else if len(response.Body) > 0 && !httputil.StatusAllowsBody(response.StatusCode) {
+			hookOutcome.Warnings = append(hookOutcome.Warnings, fmt.Sprintf("reject response body dropped, the status code %d doesn't allow a body", response.StatusCode))
+			rejectErr.Response = &hookstage.HTTPResponse{StatusCode: response.StatusCode, Header: response.Header}
+		} else {
+			rejectErr.Response = response
+		}
+	}
 	hookOutcome.Action = ActionReject
 	hookOutcome.Errors = append(hookOutcome.Errors, rejectErr.Error())
 	metricEngine.RecordModuleSuccessRejected(labels)
@@ -277,11 +316,13 @@ func handleHookReject[P any](
 	return rejectErr
 }
 
-// handleHookMutations applies mutations returned by hook to provided payload.
+// handleHookMutations applies mutations returned by hook to provided payload,
+// unless the merge policy of the group discards them.
 func handleHookMutations[P any](
 	payload P,
 	hr hookResponse[P],
 	hookOutcome *HookOutcome,
+	merger *mutationMerger,
 	metricEngine metrics.MetricsEngine,
 	labels metrics.ModuleLabels,
 ) P {
@@ -293,8 +334,23 @@ func handleHookMutations[P any](
 
 	hookOutcome.Action = ActionUpdate
 	successfulMutations := 0
+	discardedMutations := 0
+	var appliedKeys []string
 	for _, mut := range hr.Result.ChangeSet.Mutations() {
+		key := strings.Join(mut.Key(), ".")
+		conflicts, apply := merger.merge(hr.Position, key)
+		if !apply {
+			hookOutcome.Conflicts = append(hookOutcome.Conflicts, conflicts...)
+			hookOutcome.Warnings = append(hookOutcome.Warnings, discardedWarning(conflicts[0]))
+			discardedMutations++
+			continue
+		}
+
 		p, err := mut.Apply(payload)
+		for i := range conflicts {
+			conflicts[i].Applied = err == nil
+		}
+		hookOutcome.Conflicts = append(hookOutcome.Conflicts, conflicts...)
 		if err != nil {
 			hookOutcome.Warnings = append(
 				hookOutcome.Warnings,
@@ -304,11 +360,12 @@ func handleHookMutations[P any](
 		}
 
 		payload = p
+		appliedKeys = append(appliedKeys, key)
 		hookOutcome.DebugMessages = append(
 			hookOutcome.DebugMessages,
 			fmt.Sprintf(
 				"Hook mutation successfully applied, affected key: %s, mutation type: %s",
-				strings.Join(mut.Key(), "."),
+				key,
 				mut.Type(),
 			),
 		)
@@ -318,7 +375,12 @@ func handleHookMutations[P any](
 	// if at least one mutation from a given module was successfully applied
 	// we consider that the module was processed successfully
 	if successfulMutations > 0 {
+		merger.addWrites(hr.HookID, hr.Position, appliedKeys)
 		metricEngine.RecordModuleSuccessUpdated(labels)
+	} else if discardedMutations == len(hr.Result.ChangeSet.Mutations()) {
+		// all the mutations lost to the mutations of the previous hooks
+		metricEngine.RecordModuleSuccessNooped(labels)
+		hookOutcome.Action = ActionNone
 	} else {
 		hookOutcome.Status = StatusExecutionFailure
 		metricEngine.RecordModuleExecutionError(labels)
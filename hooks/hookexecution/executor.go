@@ -19,6 +19,7 @@ import (
 const (
 	EndpointAuction = "/openrtb2/auction"
 	EndpointAmp     = "/openrtb2/amp"
+	EndpointVideo   = "/openrtb2/video"
 )
 
 // An entity specifies the type of object that was processed during the execution of the stage.
@@ -29,6 +30,7 @@ const (
 	entityAuctionRequest           entity = "auction-request"
 	entityAuctionResponse          entity = "auction_response"
 	entityAllProcessedBidResponses entity = "all_processed_bid_responses"
+	entityHttpResponse             entity = "http-response"
 )
 
 type StageExecutor interface {
@@ -39,6 +41,9 @@ type StageExecutor interface {
 	ExecuteRawBidderResponseStage(response *adapters.BidderResponse, bidder string) *RejectError
 	ExecuteAllProcessedBidResponsesStage(adapterBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid)
 	ExecuteAuctionResponseStage(response *openrtb2.BidResponse)
+	ExecuteExitpointStage(statusCode int, headers http.Header, body []byte) (int, http.Header, []byte)
+	// GetSeatNonBid returns the seat non bids reported by the hooks executed so far.
+	GetSeatNonBid() []openrtb_ext.SeatNonBid
 }
 
 type HookStageExecutor interface {
@@ -88,6 +93,21 @@ func (e *hookExecutor) GetOutcomes() []StageOutcome {
 	return e.stageOutcomes
 }
 
+func (e *hookExecutor) GetSeatNonBid() []openrtb_ext.SeatNonBid {
+	e.Lock()
+	defer e.Unlock()
+
+	var seatNonBid []openrtb_ext.SeatNonBid
+	for _, stageOutcome := range e.stageOutcomes {
+		for _, groupOutcome := range stageOutcome.Groups {
+			for _, hookOutcome := range groupOutcome.InvocationResults {
+				seatNonBid = append(seatNonBid, hookOutcome.SeatNonBid...)
+			}
+		}
+	}
+	return seatNonBid
+}
+
 func (e *hookExecutor) ExecuteEntrypointStage(req *http.Request, body []byte) ([]byte, *RejectError) {
 	plan := e.planBuilder.PlanForEntrypointStage(e.endpoint)
 	if len(plan) == 0 {
@@ -295,6 +315,35 @@ func (e *hookExecutor) ExecuteAuctionResponseStage(response *openrtb2.BidRespons
 	e.pushStageOutcome(outcome)
 }
 
+func (e *hookExecutor) ExecuteExitpointStage(statusCode int, headers http.Header, body []byte) (int, http.Header, []byte) {
+	plan := e.planBuilder.PlanForExitpointStage(e.endpoint, e.account)
+	if len(plan) == 0 {
+		return statusCode, headers, body
+	}
+
+	handler := func(
+		ctx context.Context,
+		moduleCtx hookstage.ModuleInvocationContext,
+		hook hookstage.Exitpoint,
+		payload hookstage.ExitpointPayload,
+	) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+		return hook.HandleExitpointHook(ctx, moduleCtx, payload)
+	}
+
+	stageName := hooks.StageExitpoint.String()
+	executionCtx := e.newContext(stageName)
+	payload := hookstage.ExitpointPayload{StatusCode: statusCode, Headers: headers, Body: body}
+
+	outcome, payload, contexts, _ := executeStage(executionCtx, plan, payload, handler, e.metricEngine)
+	outcome.Entity = entityHttpResponse
+	outcome.Stage = stageName
+
+	e.saveModuleContexts(contexts)
+	e.pushStageOutcome(outcome)
+
+	return payload.StatusCode, payload.Headers, payload.Body
+}
+
 func (e *hookExecutor) newContext(stage string) executionContext {
 	return executionContext{
 		account:         e.account,
@@ -330,6 +379,10 @@ func (executor EmptyHookExecutor) GetOutcomes() []StageOutcome {
 	return []StageOutcome{}
 }
 
+func (executor EmptyHookExecutor) GetSeatNonBid() []openrtb_ext.SeatNonBid {
+	return nil
+}
+
 func (executor EmptyHookExecutor) ExecuteEntrypointStage(_ *http.Request, body []byte) ([]byte, *RejectError) {
 	return body, nil
 }
@@ -354,3 +407,7 @@ func (executor EmptyHookExecutor) ExecuteAllProcessedBidResponsesStage(_ map[ope
 }
 
 func (executor EmptyHookExecutor) ExecuteAuctionResponseStage(_ *openrtb2.BidResponse) {}
+
+func (executor EmptyHookExecutor) ExecuteExitpointStage(statusCode int, headers http.Header, body []byte) (int, http.Header, []byte) {
+	return statusCode, headers, body
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/prebid/prebid-server/v3/util/ptrutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEmptyHookExecutor(t *testing.T) {
@@ -40,6 +41,7 @@ func TestEmptyHookExecutor(t *testing.T) {
 	processedAuctionRejectErr := executor.ExecuteProcessedAuctionStage(&openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{}})
 	bidderRequestRejectErr := executor.ExecuteBidderRequestStage(&openrtb_ext.RequestWrapper{BidRequest: bidderRequest}, "bidder-name")
 	executor.ExecuteAuctionResponseStage(&openrtb2.BidResponse{})
+	exitpointStatus, exitpointHeaders, exitpointBody := executor.ExecuteExitpointStage(http.StatusOK, http.Header{"Content-Type": {"application/json"}}, body)
 
 	outcomes := executor.GetOutcomes()
 	assert.Equal(t, EmptyHookExecutor{}, executor, "EmptyHookExecutor shouldn't be changed.")
@@ -54,6 +56,10 @@ func TestEmptyHookExecutor(t *testing.T) {
 	assert.Nil(t, processedAuctionRejectErr, "EmptyHookExecutor shouldn't return reject error at processed-auction stage.")
 	assert.Nil(t, bidderRequestRejectErr, "EmptyHookExecutor shouldn't return reject error at bidder-request stage.")
 	assert.Equal(t, expectedBidderRequest, bidderRequest, "EmptyHookExecutor shouldn't change payload at bidder-request stage.")
+
+	assert.Equal(t, http.StatusOK, exitpointStatus, "EmptyHookExecutor shouldn't change status at exitpoint stage.")
+	assert.Equal(t, http.Header{"Content-Type": {"application/json"}}, exitpointHeaders, "EmptyHookExecutor shouldn't change headers at exitpoint stage.")
+	assert.Equal(t, body, exitpointBody, "EmptyHookExecutor shouldn't change body at exitpoint stage.")
 }
 
 func TestExecuteEntrypointStage(t *testing.T) {
@@ -120,8 +126,11 @@ func TestExecuteEntrypointStage(t *testing.T) {
 									Action:        ActionUpdate,
 									Message:       "",
 									DebugMessages: nil,
-									Errors:        nil,
-									Warnings:      []string{"failed to apply hook mutation: key not found"},
+									Conflicts: []MutationConflict{
+										{Key: "header.foo", Type: ConflictWrite, With: HookID{ModuleCode: "foobar", HookImplCode: "foo"}, Applied: false},
+									},
+									Errors:   nil,
+									Warnings: []string{"failed to apply hook mutation: key not found"},
 								},
 								{
 									AnalyticsTags: hookanalytics.Analytics{},
@@ -174,7 +183,7 @@ func TestExecuteEntrypointStage(t *testing.T) {
 			expectedBody:           body,
 			expectedHeader:         http.Header{"Foo": []string{"bar"}},
 			expectedQuery:          url.Values{},
-			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "bar"}, hooks.StageEntrypoint.String()},
+			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "bar"}, hooks.StageEntrypoint.String(), nil},
 			expectedModuleContexts: foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -503,7 +512,7 @@ func TestExecuteRawAuctionStage(t *testing.T) {
 			givenUrl:               urlString,
 			givenPlanBuilder:       TestRejectPlanBuilder{},
 			expectedBody:           bodyUpdated,
-			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "bar"}, hooks.StageRawAuctionRequest.String()},
+			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "bar"}, hooks.StageRawAuctionRequest.String(), nil},
 			expectedModuleContexts: foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -750,7 +759,7 @@ func TestExecuteProcessedAuctionStage(t *testing.T) {
 			givenPlanBuilder:       TestRejectPlanBuilder{},
 			givenRequest:           openrtb_ext.RequestWrapper{BidRequest: &req},
 			expectedRequest:        req,
-			expectedErr:            &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageProcessedAuctionRequest.String()},
+			expectedErr:            &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageProcessedAuctionRequest.String(), nil},
 			expectedModuleContexts: foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -1002,7 +1011,7 @@ func TestExecuteBidderRequestStage(t *testing.T) {
 			givenBidderRequest:     &openrtb2.BidRequest{ID: "some-id", User: &openrtb2.User{ID: "user-id"}},
 			givenPlanBuilder:       TestRejectPlanBuilder{},
 			expectedBidderRequest:  expectedBidderRequest,
-			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageBidderRequest.String()},
+			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageBidderRequest.String(), nil},
 			expectedModuleContexts: foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -1277,7 +1286,7 @@ func TestExecuteRawBidderResponseStage(t *testing.T) {
 			givenPlanBuilder:       TestRejectPlanBuilder{},
 			givenBidderResponse:    resp,
 			expectedBidderResponse: resp,
-			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageRawBidderResponse.String()},
+			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageRawBidderResponse.String(), nil},
 			expectedModuleContexts: foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -1528,7 +1537,7 @@ func TestExecuteAllProcessedBidResponsesStage(t *testing.T) {
 			},
 			givenPlanBuilder:        TestRejectPlanBuilder{},
 			expectedBiddersResponse: expectedUpdatedAllProcBidResponses,
-			expectedReject:          &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageAllProcessedBidResponses.String()},
+			expectedReject:          &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageAllProcessedBidResponses.String(), nil},
 			expectedModuleContexts:  foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -1707,6 +1716,149 @@ func TestExecuteAllProcessedBidResponsesStage(t *testing.T) {
 	}
 }
 
+func TestGetSeatNonBid(t *testing.T) {
+	nonBid := openrtb_ext.SeatNonBid{Seat: "appnexus", NonBid: []openrtb_ext.NonBid{{ImpId: "imp-1", StatusCode: 300}}}
+	failedNonBid := openrtb_ext.SeatNonBid{Seat: "appnexus", NonBid: []openrtb_ext.NonBid{{ImpId: "imp-2", StatusCode: 300}}}
+
+	exec := NewHookExecutor(TestSeatNonBidPlanBuilder{nonBid: nonBid, failedNonBid: failedNonBid}, EndpointAuction, &metricsConfig.NilMetricsEngine{})
+	assert.Empty(t, exec.GetSeatNonBid(), "No seat non bid expected before any stage is executed.")
+
+	reject := exec.ExecuteRawBidderResponseStage(&adapters.BidderResponse{}, "appnexus")
+	assert.Nil(t, reject)
+	assert.Equal(t, []openrtb_ext.SeatNonBid{nonBid}, exec.GetSeatNonBid(), "Only the seat non bids of the hooks executed successfully expected.")
+}
+
+func TestExecuteGroupMergePolicy(t *testing.T) {
+	fooID := HookID{ModuleCode: "foobar", HookImplCode: "foo"}
+	barID := HookID{ModuleCode: "foobar", HookImplCode: "bar"}
+	bazID := HookID{ModuleCode: "foobar", HookImplCode: "baz"}
+
+	testCases := []struct {
+		description        string
+		givenPolicy        hooks.MergePolicy
+		expectedCustomData string
+		expectedBarOutcome HookOutcome
+	}{
+		{
+			description:        "Mutations of the last hook of the group win by default",
+			givenPolicy:        "",
+			expectedCustomData: "bar",
+			expectedBarOutcome: HookOutcome{
+				HookID: barID,
+				Status: StatusSuccess,
+				Action: ActionUpdate,
+				DebugMessages: []string{
+					fmt.Sprintf("Hook mutation successfully applied, affected key: bidresponse.customdata, mutation type: %s", hookstage.MutationUpdate),
+				},
+				Conflicts: []MutationConflict{
+					{Key: "bidresponse.customdata", Type: ConflictWrite, With: fooID, Applied: true},
+					{Key: "bidresponse.customdata", Type: ConflictRead, With: bazID, Applied: true},
+				},
+			},
+		},
+		{
+			description:        "Mutations of the first hook of the group win with the first_wins policy",
+			givenPolicy:        hooks.MergePolicyFirstWins,
+			expectedCustomData: "foo",
+			expectedBarOutcome: HookOutcome{
+				HookID: barID,
+				Status: StatusSuccess,
+				Action: ActionNone,
+				Conflicts: []MutationConflict{
+					{Key: "bidresponse.customdata", Type: ConflictWrite, With: fooID, Applied: false},
+				},
+				Warnings: []string{"mutation of key bidresponse.customdata discarded, the module (name: foobar, hook code: foo) of the same group already mutated it"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			exec := NewHookExecutor(TestMergePolicyPlanBuilder{policy: test.givenPolicy}, EndpointAuction, &metricsConfig.NilMetricsEngine{})
+			resp := &openrtb2.BidResponse{}
+
+			exec.ExecuteAuctionResponseStage(resp)
+
+			assert.Equal(t, test.expectedCustomData, resp.CustomData, "Incorrect response update.")
+
+			stageOutcomes := exec.GetOutcomes()
+			require.Len(t, stageOutcomes, 1)
+			require.Len(t, stageOutcomes[0].Groups, 1)
+			results := stageOutcomes[0].Groups[0].InvocationResults
+			require.Len(t, results, 3)
+
+			// the first hook completes last but its result is handled first
+			assert.Equal(t, []HookID{fooID, barID, bazID}, []HookID{results[0].HookID, results[1].HookID, results[2].HookID}, "Hook outcomes not in the order of the group.")
+			assert.Equal(t, []MutationConflict{{Key: "bidresponse.customdata", Type: ConflictRead, With: bazID, Applied: true}}, results[0].Conflicts, "Mutation of the first hook only conflicts with the reader.")
+
+			results[1].ExecutionTime = ExecutionTime{}
+			assert.Equal(t, test.expectedBarOutcome, results[1], "Incorrect outcome of the conflicting hook.")
+		})
+	}
+}
+
+func TestRejectResponse(t *testing.T) {
+	rateLimited := &hookstage.HTTPResponse{StatusCode: http.StatusTooManyRequests, Body: []byte("rate limit exceeded")}
+
+	testCases := []struct {
+		description      string
+		givenResponse    *hookstage.HTTPResponse
+		givenStage       hooks.Stage
+		expectedResponse *hookstage.HTTPResponse
+		expectedWarnings []string
+	}{
+		{
+			description:      "Response kept when whole request rejected",
+			givenResponse:    rateLimited,
+			givenStage:       hooks.StageEntrypoint,
+			expectedResponse: rateLimited,
+		},
+		{
+			description:      "Response ignored if status code invalid",
+			givenResponse:    &hookstage.HTTPResponse{StatusCode: 42},
+			givenStage:       hooks.StageEntrypoint,
+			expectedResponse: nil,
+			expectedWarnings: []string{"reject response ignored, invalid status code 42"},
+		},
+		{
+			description:      "Body dropped if status code doesn't allow it",
+			givenResponse:    &hookstage.HTTPResponse{StatusCode: http.StatusNoContent, Header: http.Header{"X-Reason": {"bot"}}, Body: []byte("bot")},
+			givenStage:       hooks.StageEntrypoint,
+			expectedResponse: &hookstage.HTTPResponse{StatusCode: http.StatusNoContent, Header: http.Header{"X-Reason": {"bot"}}},
+			expectedWarnings: []string{"reject response body dropped, the status code 204 doesn't allow a body"},
+		},
+		{
+			description:      "Response ignored when a bidder rejected",
+			givenResponse:    rateLimited,
+			givenStage:       hooks.StageBidderRequest,
+			expectedResponse: nil,
+			expectedWarnings: []string{"reject response ignored, the bidder_request stage rejects a single bidder"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			exec := NewHookExecutor(TestRejectResponsePlanBuilder{response: test.givenResponse}, EndpointAuction, &metricsConfig.NilMetricsEngine{})
+
+			var rejectErr *RejectError
+			if test.givenStage == hooks.StageEntrypoint {
+				req, err := http.NewRequest(http.MethodPost, "https://prebid.com/openrtb2/auction", nil)
+				require.NoError(t, err)
+				_, rejectErr = exec.ExecuteEntrypointStage(req, []byte(`{}`))
+			} else {
+				rejectErr = exec.ExecuteBidderRequestStage(&openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{}}, "appnexus")
+			}
+
+			require.NotNil(t, rejectErr, "Request not rejected.")
+			assert.Equal(t, test.expectedResponse, rejectErr.Response, "Incorrect reject response.")
+
+			outcomes := exec.GetOutcomes()
+			require.Len(t, outcomes, 1)
+			assert.Equal(t, test.expectedWarnings, outcomes[0].Groups[0].InvocationResults[0].Warnings, "Incorrect warnings.")
+		})
+	}
+}
+
 func TestExecuteAuctionResponseStage(t *testing.T) {
 	foobarModuleCtx := &moduleContexts{ctxs: map[string]hookstage.ModuleContext{"foobar": nil}}
 	resp := &openrtb2.BidResponse{CustomData: "some-custom-data"}
@@ -1767,7 +1919,7 @@ func TestExecuteAuctionResponseStage(t *testing.T) {
 			givenPlanBuilder:       TestRejectPlanBuilder{},
 			givenResponse:          resp,
 			expectedResponse:       expResp,
-			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageAuctionResponse.String()},
+			expectedReject:         &RejectError{0, HookID{ModuleCode: "foobar", HookImplCode: "foo"}, hooks.StageAuctionResponse.String(), nil},
 			expectedModuleContexts: foobarModuleCtx,
 			expectedStageOutcomes: []StageOutcome{
 				{
@@ -1952,6 +2104,120 @@ func TestExecuteAuctionResponseStage(t *testing.T) {
 	}
 }
 
+func TestExecuteExitpointStage(t *testing.T) {
+	body := []byte(`{"id":"some-id"}`)
+	header := http.Header{"Content-Type": {"application/json"}}
+
+	testCases := []struct {
+		description           string
+		givenPlanBuilder      hooks.ExecutionPlanBuilder
+		expectedStatusCode    int
+		expectedHeader        http.Header
+		expectedBody          []byte
+		expectedStageOutcomes []StageOutcome
+	}{
+		{
+			description:           "Response not changed if hook execution plan empty",
+			givenPlanBuilder:      hooks.EmptyPlanBuilder{},
+			expectedStatusCode:    http.StatusOK,
+			expectedHeader:        header,
+			expectedBody:          body,
+			expectedStageOutcomes: []StageOutcome{},
+		},
+		{
+			description:        "Response changed if hooks return mutations",
+			givenPlanBuilder:   TestApplyHookMutationsBuilder{},
+			expectedStatusCode: http.StatusAccepted,
+			expectedHeader:     http.Header{"Content-Type": {"application/json"}, "X-Foo": {"bar"}},
+			expectedBody:       []byte(`{"id":"new-id"}`),
+			expectedStageOutcomes: []StageOutcome{
+				{
+					Entity: entityHttpResponse,
+					Stage:  hooks.StageExitpoint.String(),
+					Groups: []GroupOutcome{
+						{
+							InvocationResults: []HookOutcome{
+								{
+									AnalyticsTags: hookanalytics.Analytics{},
+									HookID:        HookID{ModuleCode: "foobar", HookImplCode: "foo"},
+									Status:        StatusSuccess,
+									Action:        ActionUpdate,
+									DebugMessages: []string{
+										fmt.Sprintf("Hook mutation successfully applied, affected key: httpResponse.body, mutation type: %s", hookstage.MutationUpdate),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description:        "Stage execution can't be rejected - stage doesn't support rejection",
+			givenPlanBuilder:   TestRejectPlanBuilder{},
+			expectedStatusCode: http.StatusAccepted,
+			expectedHeader:     http.Header{"Content-Type": {"application/json"}, "X-Foo": {"bar"}},
+			expectedBody:       []byte(`{"id":"new-id"}`),
+			expectedStageOutcomes: []StageOutcome{
+				{
+					Entity: entityHttpResponse,
+					Stage:  hooks.StageExitpoint.String(),
+					Groups: []GroupOutcome{
+						{
+							InvocationResults: []HookOutcome{
+								{
+									AnalyticsTags: hookanalytics.Analytics{},
+									HookID:        HookID{ModuleCode: "foobar", HookImplCode: "foo"},
+									Status:        StatusExecutionFailure,
+									Errors: []string{
+										fmt.Sprintf("Module (name: foobar, hook code: foo) tried to reject request on the %s stage that does not support rejection", hooks.StageExitpoint),
+									},
+								},
+							},
+						},
+						{
+							InvocationResults: []HookOutcome{
+								{
+									AnalyticsTags: hookanalytics.Analytics{},
+									HookID:        HookID{ModuleCode: "foobar", HookImplCode: "bar"},
+									Status:        StatusSuccess,
+									Action:        ActionUpdate,
+									DebugMessages: []string{
+										fmt.Sprintf("Hook mutation successfully applied, affected key: httpResponse.body, mutation type: %s", hookstage.MutationUpdate),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			exec := NewHookExecutor(test.givenPlanBuilder, EndpointVideo, &metricsConfig.NilMetricsEngine{})
+
+			privacyConfig := getModuleActivities("foo", false, false)
+			ac := privacy.NewActivityControl(privacyConfig)
+			exec.SetActivityControl(ac)
+
+			statusCode, newHeader, newBody := exec.ExecuteExitpointStage(http.StatusOK, header.Clone(), body)
+
+			assert.Equal(t, test.expectedStatusCode, statusCode, "Incorrect status code.")
+			assert.Equal(t, test.expectedHeader, newHeader, "Incorrect headers.")
+			assert.Equal(t, test.expectedBody, newBody, "Incorrect body.")
+
+			stageOutcomes := exec.GetOutcomes()
+			if len(test.expectedStageOutcomes) == 0 {
+				assert.Empty(t, stageOutcomes, "Incorrect stage outcomes.")
+			} else {
+				assertEqualStageOutcomes(t, test.expectedStageOutcomes[0], stageOutcomes[0])
+			}
+		})
+	}
+}
+
 func TestInterStageContextCommunication(t *testing.T) {
 	body := []byte(`{"foo": "bar"}`)
 	reader := bytes.NewReader(body)
@@ -2169,6 +2435,17 @@ func (e TestApplyHookMutationsBuilder) PlanForAuctionResponseStage(_ string, _ *
 	}
 }
 
+func (e TestApplyHookMutationsBuilder) PlanForExitpointStage(_ string, _ *config.Account) hooks.Plan[hookstage.Exitpoint] {
+	return hooks.Plan[hookstage.Exitpoint]{
+		hooks.Group[hookstage.Exitpoint]{
+			Timeout: 1 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.Exitpoint]{
+				{Module: "foobar", Code: "foo", Hook: mockUpdateHttpResponseHook{}},
+			},
+		},
+	}
+}
+
 type TestRejectPlanBuilder struct {
 	hooks.EmptyPlanBuilder
 }
@@ -2333,6 +2610,25 @@ func (e TestRejectPlanBuilder) PlanForAuctionResponseStage(_ string, _ *config.A
 	}
 }
 
+func (e TestRejectPlanBuilder) PlanForExitpointStage(_ string, _ *config.Account) hooks.Plan[hookstage.Exitpoint] {
+	return hooks.Plan[hookstage.Exitpoint]{
+		// rejection ignored, stage doesn't support rejection
+		hooks.Group[hookstage.Exitpoint]{
+			Timeout: 1 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.Exitpoint]{
+				{Module: "foobar", Code: "foo", Hook: mockRejectHook{}},
+			},
+		},
+		// hook executed and payload updated because this stage doesn't support rejection
+		hooks.Group[hookstage.Exitpoint]{
+			Timeout: 1 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.Exitpoint]{
+				{Module: "foobar", Code: "bar", Hook: mockUpdateHttpResponseHook{}},
+			},
+		},
+	}
+}
+
 type TestWithTimeoutPlanBuilder struct {
 	hooks.EmptyPlanBuilder
 }
@@ -2585,6 +2881,70 @@ func (e TestWithModuleContextsPlanBuilder) PlanForAuctionResponseStage(_ string,
 	}
 }
 
+type TestSeatNonBidPlanBuilder struct {
+	hooks.EmptyPlanBuilder
+	nonBid       openrtb_ext.SeatNonBid
+	failedNonBid openrtb_ext.SeatNonBid
+}
+
+func (e TestSeatNonBidPlanBuilder) PlanForRawBidderResponseStage(_ string, _ *config.Account) hooks.Plan[hookstage.RawBidderResponse] {
+	return hooks.Plan[hookstage.RawBidderResponse]{
+		hooks.Group[hookstage.RawBidderResponse]{
+			Timeout: 10 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.RawBidderResponse]{
+				{Module: "foobar", Code: "foo", Hook: mockSeatNonBidHook{seatNonBid: []openrtb_ext.SeatNonBid{e.nonBid}}},
+				{Module: "foobar", Code: "bar", Hook: mockSeatNonBidHook{seatNonBid: []openrtb_ext.SeatNonBid{e.failedNonBid}, err: FailureError{Message: "attribute not found"}}},
+			},
+		},
+	}
+}
+
+type TestRejectResponsePlanBuilder struct {
+	hooks.EmptyPlanBuilder
+	response *hookstage.HTTPResponse
+}
+
+func (e TestRejectResponsePlanBuilder) PlanForEntrypointStage(_ string) hooks.Plan[hookstage.Entrypoint] {
+	return hooks.Plan[hookstage.Entrypoint]{
+		hooks.Group[hookstage.Entrypoint]{
+			Timeout: 10 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+				{Module: "foobar", Code: "foo", Hook: mockRejectResponseHook{response: e.response}},
+			},
+		},
+	}
+}
+
+func (e TestRejectResponsePlanBuilder) PlanForBidderRequestStage(_ string, _ *config.Account) hooks.Plan[hookstage.BidderRequest] {
+	return hooks.Plan[hookstage.BidderRequest]{
+		hooks.Group[hookstage.BidderRequest]{
+			Timeout: 10 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.BidderRequest]{
+				{Module: "foobar", Code: "foo", Hook: mockRejectResponseHook{response: e.response}},
+			},
+		},
+	}
+}
+
+type TestMergePolicyPlanBuilder struct {
+	hooks.EmptyPlanBuilder
+	policy hooks.MergePolicy
+}
+
+func (e TestMergePolicyPlanBuilder) PlanForAuctionResponseStage(_ string, _ *config.Account) hooks.Plan[hookstage.AuctionResponse] {
+	return hooks.Plan[hookstage.AuctionResponse]{
+		hooks.Group[hookstage.AuctionResponse]{
+			Timeout:     100 * time.Millisecond,
+			MergePolicy: e.policy,
+			Hooks: []hooks.HookWrapper[hookstage.AuctionResponse]{
+				{Module: "foobar", Code: "foo", Hook: mockPathsHook{customData: "foo", delay: 20 * time.Millisecond}},
+				{Module: "foobar", Code: "bar", Hook: mockPathsHook{customData: "bar", paths: hookstage.PayloadPaths{Writes: []string{"bidresponse.customdata"}}}},
+				{Module: "foobar", Code: "baz", Hook: mockPathsHook{paths: hookstage.PayloadPaths{Reads: []string{"bidresponse"}}}},
+			},
+		},
+	}
+}
+
 type TestAllHookResultsBuilder struct {
 	hooks.EmptyPlanBuilder
 }
@@ -0,0 +1,83 @@
+package hookexecution
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+)
+
+// mutationMerger tracks the payload paths accessed by the hooks of a group, whose results
+// are handled in the order of the group, to detect the mutations conflicting with other hooks
+// of the group and to decide which of them are applied according to the merge policy.
+type mutationMerger struct {
+	policy   hooks.MergePolicy
+	declared []hookstage.PayloadPaths // indexed by the position of the hooks in the group
+	reads    []hookPaths
+	writes   []hookPaths // paths written by the hooks whose mutations were applied
+}
+
+type hookPaths struct {
+	hookID   HookID
+	position int
+	paths    []string
+}
+
+func newMutationMerger[P any](policy hooks.MergePolicy, declared []hookstage.PayloadPaths, hookResponses []hookResponse[P]) *mutationMerger {
+	merger := &mutationMerger{policy: policy, declared: declared}
+	for _, r := range hookResponses {
+		// the reads of the hooks which failed don't matter, their results are dropped
+		if r.Err == nil && len(declared[r.Position].Reads) > 0 {
+			merger.reads = append(merger.reads, hookPaths{hookID: r.HookID, position: r.Position, paths: declared[r.Position].Reads})
+		}
+	}
+	return merger
+}
+
+// merge returns the conflicts of the mutation of the key by the hook at the given position
+// and whether the mutation has to be applied. The conflicts are returned as not applied,
+// the caller knows whether the mutation actually applies.
+func (m *mutationMerger) merge(position int, key string) ([]MutationConflict, bool) {
+	var conflicts []MutationConflict
+	for _, w := range m.writes {
+		if overlapsAny(key, w.paths) {
+			conflicts = append(conflicts, MutationConflict{Key: key, Type: ConflictWrite, With: w.hookID})
+		}
+	}
+	if len(conflicts) > 0 && m.policy == hooks.MergePolicyFirstWins {
+		return conflicts, false
+	}
+
+	// the readers ran on the payload without the mutation, whatever their position
+	for _, r := range m.reads {
+		if r.position != position && overlapsAny(key, r.paths) {
+			conflicts = append(conflicts, MutationConflict{Key: key, Type: ConflictRead, With: r.hookID})
+		}
+	}
+	return conflicts, true
+}
+
+// addWrites records the paths written by the hook at the given position: the keys of its applied
+// mutations and the paths it declared writing.
+func (m *mutationMerger) addWrites(hookID HookID, position int, keys []string) {
+	paths := append(slices.Clone(keys), m.declared[position].Writes...)
+	m.writes = append(m.writes, hookPaths{hookID: hookID, position: position, paths: paths})
+}
+
+// overlapsAny reports whether the path is one of the paths, nested under one of them or one of them is nested under it.
+func overlapsAny(path string, paths []string) bool {
+	return slices.ContainsFunc(paths, func(p string) bool {
+		return p == path || strings.HasPrefix(path, p+".") || strings.HasPrefix(p, path+".")
+	})
+}
+
+func discardedWarning(c MutationConflict) string {
+	return fmt.Sprintf(
+		"mutation of key %s discarded, the module (name: %s, hook code: %s) of the same group already mutated it",
+		c.Key,
+		c.With.ModuleCode,
+		c.With.HookImplCode,
+	)
+}
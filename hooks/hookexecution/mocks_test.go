@@ -3,6 +3,7 @@ package hookexecution
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
@@ -100,6 +101,10 @@ func (e mockRejectHook) HandleAuctionResponseHook(_ context.Context, _ hookstage
 	return hookstage.HookResult[hookstage.AuctionResponsePayload]{Reject: true}, nil
 }
 
+func (e mockRejectHook) HandleExitpointHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.ExitpointPayload) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+	return hookstage.HookResult[hookstage.ExitpointPayload]{Reject: true}, nil
+}
+
 type mockTimeoutHook struct{}
 
 func (e mockTimeoutHook) HandleEntrypointHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
@@ -180,6 +185,21 @@ func (e mockTimeoutHook) HandleAuctionResponseHook(_ context.Context, _ hookstag
 	return hookstage.HookResult[hookstage.AuctionResponsePayload]{ChangeSet: c}, nil
 }
 
+type mockUpdateHttpResponseHook struct{}
+
+func (e mockUpdateHttpResponseHook) HandleExitpointHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.ExitpointPayload) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+	c := hookstage.ChangeSet[hookstage.ExitpointPayload]{}
+	c.AddMutation(
+		func(payload hookstage.ExitpointPayload) (hookstage.ExitpointPayload, error) {
+			payload.StatusCode = http.StatusAccepted
+			payload.Headers.Set("X-Foo", "bar")
+			payload.Body = []byte(`{"id":"new-id"}`)
+			return payload, nil
+		}, hookstage.MutationUpdate, "httpResponse", "body")
+
+	return hookstage.HookResult[hookstage.ExitpointPayload]{ChangeSet: c}, nil
+}
+
 type mockModuleContextHook struct {
 	key, val string
 }
@@ -359,6 +379,55 @@ func (e mockUpdateBidderResponseHook) HandleRawBidderResponseHook(_ context.Cont
 	return hookstage.HookResult[hookstage.RawBidderResponsePayload]{ChangeSet: c}, nil
 }
 
+type mockSeatNonBidHook struct {
+	seatNonBid []openrtb_ext.SeatNonBid
+	err        error
+}
+
+func (e mockSeatNonBidHook) HandleRawBidderResponseHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.RawBidderResponsePayload) (hookstage.HookResult[hookstage.RawBidderResponsePayload], error) {
+	return hookstage.HookResult[hookstage.RawBidderResponsePayload]{SeatNonBid: e.seatNonBid}, e.err
+}
+
+// mockRejectResponseHook rejects the request with the given HTTP response.
+type mockRejectResponseHook struct {
+	response *hookstage.HTTPResponse
+}
+
+func (e mockRejectResponseHook) HandleEntrypointHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	return hookstage.HookResult[hookstage.EntrypointPayload]{Reject: true, RejectResponse: e.response}, nil
+}
+
+func (e mockRejectResponseHook) HandleBidderRequestHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.BidderRequestPayload) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
+	return hookstage.HookResult[hookstage.BidderRequestPayload]{Reject: true, RejectResponse: e.response}, nil
+}
+
+// mockPathsHook sets the custom data of the bid response, unless customData is empty,
+// after the delay and declares the given payload paths.
+type mockPathsHook struct {
+	customData string
+	delay      time.Duration
+	paths      hookstage.PayloadPaths
+}
+
+func (e mockPathsHook) HandleAuctionResponseHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.AuctionResponsePayload) (hookstage.HookResult[hookstage.AuctionResponsePayload], error) {
+	time.Sleep(e.delay)
+
+	c := hookstage.ChangeSet[hookstage.AuctionResponsePayload]{}
+	if e.customData != "" {
+		c.AddMutation(
+			func(payload hookstage.AuctionResponsePayload) (hookstage.AuctionResponsePayload, error) {
+				payload.BidResponse.CustomData = e.customData
+				return payload, nil
+			}, hookstage.MutationUpdate, "bidresponse", "customdata")
+	}
+
+	return hookstage.HookResult[hookstage.AuctionResponsePayload]{ChangeSet: c}, nil
+}
+
+func (e mockPathsHook) PayloadPaths(_ string) hookstage.PayloadPaths {
+	return e.paths
+}
+
 type mockUpdateBiddersResponsesHook struct{}
 
 func (e mockUpdateBiddersResponsesHook) HandleAllProcessedBidResponsesHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.AllProcessedBidResponsesPayload) (hookstage.HookResult[hookstage.AllProcessedBidResponsesPayload], error) {
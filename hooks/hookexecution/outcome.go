@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
 
 // Status indicates the result of hook execution.
@@ -77,14 +78,32 @@ type GroupOutcome struct {
 type HookOutcome struct {
 	// ExecutionTime is the execution time of a specific hook without applying its result.
 	ExecutionTime
-	AnalyticsTags hookanalytics.Analytics `json:"analytics_tags"`
-	HookID        HookID                  `json:"hook_id"`
-	Status        Status                  `json:"status"`
-	Action        Action                  `json:"action"`
-	Message       string                  `json:"message"` // arbitrary string value returned from hook execution
-	DebugMessages []string                `json:"debug_messages,omitempty"`
-	Errors        []string                `json:"-"`
-	Warnings      []string                `json:"-"`
+	AnalyticsTags hookanalytics.Analytics  `json:"analytics_tags"`
+	HookID        HookID                   `json:"hook_id"`
+	Status        Status                   `json:"status"`
+	Action        Action                   `json:"action"`
+	Message       string                   `json:"message"` // arbitrary string value returned from hook execution
+	DebugMessages []string                 `json:"debug_messages,omitempty"`
+	Conflicts     []MutationConflict       `json:"conflicts,omitempty"`
+	Errors        []string                 `json:"-"`
+	Warnings      []string                 `json:"-"`
+	SeatNonBid    []openrtb_ext.SeatNonBid `json:"-"`
+}
+
+// ConflictType indicates how a mutation conflicts with another hook of the same group.
+type ConflictType string
+
+const (
+	ConflictWrite ConflictType = "write" // another hook mutated an overlapping path
+	ConflictRead  ConflictType = "read"  // another hook declared reading an overlapping path
+)
+
+// MutationConflict describes a mutation conflicting with another hook executed in the same group.
+type MutationConflict struct {
+	Key     string       `json:"key"`
+	Type    ConflictType `json:"type"`
+	With    HookID       `json:"with"`
+	Applied bool         `json:"applied"` // false when the mutation was discarded by the merge policy or failed to apply
 }
 
 // HookID points to the specific hook defined by the hook execution plan.
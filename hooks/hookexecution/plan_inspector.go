@@ -0,0 +1,197 @@
+package hookexecution
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
+)
+
+// PlannedHook is a hook the plan builder would run
+type PlannedHook struct {
+	ModuleCode   string `json:"module_code"`
+	HookImplCode string `json:"hook_impl_code"`
+}
+
+// PlannedGroup is a group of hooks the plan builder would run in parallel
+type PlannedGroup struct {
+	TimeoutMS   int64             `json:"timeout_ms"`
+	MergePolicy hooks.MergePolicy `json:"merge_policy,omitempty"`
+	Hooks       []PlannedHook     `json:"hooks"`
+}
+
+// PlanDescription lists the groups of hooks the plan builder would run at every stage of an endpoint,
+// host execution plan included. Stages without hooks are left out.
+type PlanDescription map[hooks.Stage][]PlannedGroup
+
+// PlanInspector validates hook execution plans against the registered modules, and tells which hooks
+// would run for the requests of an account without running an auction.
+type PlanInspector struct {
+	repo       hooks.HookRepository
+	hooks      config.Hooks
+	getAccount func(ctx context.Context, accountID string) (*config.Account, []error)
+}
+
+func NewPlanInspector(hooksCfg config.Hooks, repo hooks.HookRepository, getAccount func(ctx context.Context, accountID string) (*config.Account, []error)) *PlanInspector {
+	return &PlanInspector{
+		repo:       repo,
+		hooks:      hooksCfg,
+		getAccount: getAccount,
+	}
+}
+
+var stagesByName = map[string]hooks.Stage{
+	hooks.StageEntrypoint.String():               hooks.StageEntrypoint,
+	hooks.StageRawAuctionRequest.String():        hooks.StageRawAuctionRequest,
+	hooks.StageProcessedAuctionRequest.String():  hooks.StageProcessedAuctionRequest,
+	hooks.StageBidderRequest.String():            hooks.StageBidderRequest,
+	hooks.StageRawBidderResponse.String():        hooks.StageRawBidderResponse,
+	hooks.StageAllProcessedBidResponses.String(): hooks.StageAllProcessedBidResponses,
+	hooks.StageAuctionResponse.String():          hooks.StageAuctionResponse,
+	hooks.StageExitpoint.String():                hooks.StageExitpoint,
+}
+
+// Validate returns the errors of the plan: unknown endpoints or stages, groups without timeout or with an
+// unknown merge policy, and hooks of modules which aren't registered or don't implement the stage they're
+// planned at. The plan builder skips such hooks silently when the auction runs.
+func (i *PlanInspector) Validate(plan config.HookExecutionPlan) []error {
+	var errs []error
+	for _, endpoint := range slices.Sorted(maps.Keys(plan.Endpoints)) {
+		if err := validateEndpoint(endpoint); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		stages := plan.Endpoints[endpoint].Stages
+		for _, stageName := range slices.Sorted(maps.Keys(stages)) {
+			stage, ok := stagesByName[stageName]
+			if !ok {
+				errs = append(errs, fmt.Errorf("endpoint %s: unknown stage %s", endpoint, stageName))
+				continue
+			}
+			if endpoint == EndpointVideo && stage != hooks.StageEntrypoint && stage != hooks.StageExitpoint {
+				errs = append(errs, fmt.Errorf("endpoint %s: stage %s is not run, only the %s and %s stages are", endpoint, stage, hooks.StageEntrypoint, hooks.StageExitpoint))
+				continue
+			}
+
+			for groupIndex, group := range stages[stageName].Groups {
+				if group.Timeout <= 0 {
+					errs = append(errs, fmt.Errorf("endpoint %s, stage %s, group %d: timeout must be > 0. Got %d", endpoint, stage, groupIndex, group.Timeout))
+				}
+				if !hooks.MergePolicy(group.MergePolicy).IsValid() {
+					errs = append(errs, fmt.Errorf(`endpoint %s, stage %s, group %d: merge_policy must be one of "%s" or "%s". Got "%s"`, endpoint, stage, groupIndex, hooks.MergePolicyLastWins, hooks.MergePolicyFirstWins, group.MergePolicy))
+				}
+				for _, hook := range group.HookSequence {
+					if err := i.validateHook(stage, hook.ModuleCode, hook.HookImplCode); err != nil {
+						errs = append(errs, fmt.Errorf("endpoint %s, stage %s, group %d: %v", endpoint, stage, groupIndex, err))
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func (i *PlanInspector) validateHook(stage hooks.Stage, moduleCode, hookImplCode string) error {
+	if hookImplCode == "" {
+		return fmt.Errorf("hook of module %s has no hook_impl_code", moduleCode)
+	}
+	if implementsStage(i.repo, stage, moduleCode) {
+		return nil
+	}
+	for _, otherStage := range stagesByName {
+		if implementsStage(i.repo, otherStage, moduleCode) {
+			return fmt.Errorf("module %s does not implement the %s stage", moduleCode, stage)
+		}
+	}
+	return fmt.Errorf("module %s is not registered", moduleCode)
+}
+
+func implementsStage(repo hooks.HookRepository, stage hooks.Stage, moduleCode string) bool {
+	var found bool
+	switch stage {
+	case hooks.StageEntrypoint:
+		_, found = repo.GetEntrypointHook(moduleCode)
+	case hooks.StageRawAuctionRequest:
+		_, found = repo.GetRawAuctionHook(moduleCode)
+	case hooks.StageProcessedAuctionRequest:
+		_, found = repo.GetProcessedAuctionHook(moduleCode)
+	case hooks.StageBidderRequest:
+		_, found = repo.GetBidderRequestHook(moduleCode)
+	case hooks.StageRawBidderResponse:
+		_, found = repo.GetRawBidderResponseHook(moduleCode)
+	case hooks.StageAllProcessedBidResponses:
+		_, found = repo.GetAllProcessedBidResponsesHook(moduleCode)
+	case hooks.StageAuctionResponse:
+		_, found = repo.GetAuctionResponseHook(moduleCode)
+	case hooks.StageExitpoint:
+		_, found = repo.GetExitpointHook(moduleCode)
+	}
+	return found
+}
+
+// DryRun describes the hooks the plan builder would run for the requests of the account to the
+// endpoint. The account execution plan is replaced by the given plan, if any, to preview a change.
+func (i *PlanInspector) DryRun(ctx context.Context, endpoint, accountID string, plan *config.HookExecutionPlan) (PlanDescription, []error) {
+	if err := validateEndpoint(endpoint); err != nil {
+		return nil, []error{err}
+	}
+
+	account := &config.Account{ID: accountID}
+	if accountID != "" {
+		var errs []error
+		if account, errs = i.getAccount(ctx, accountID); len(errs) > 0 {
+			return nil, errs
+		}
+	}
+	if plan != nil {
+		accountCopy := *account
+		accountCopy.Hooks.ExecutionPlan = *plan
+		account = &accountCopy
+	}
+
+	builder := hooks.NewExecutionPlanBuilder(i.hooks, i.repo)
+	description := make(PlanDescription)
+	describeStage(description, hooks.StageEntrypoint, builder.PlanForEntrypointStage(endpoint))
+	// the video endpoint only runs the entrypoint and exitpoint stages
+	if endpoint != EndpointVideo {
+		describeStage(description, hooks.StageRawAuctionRequest, builder.PlanForRawAuctionStage(endpoint, account))
+		describeStage(description, hooks.StageProcessedAuctionRequest, builder.PlanForProcessedAuctionStage(endpoint, account))
+		describeStage(description, hooks.StageBidderRequest, builder.PlanForBidderRequestStage(endpoint, account))
+		describeStage(description, hooks.StageRawBidderResponse, builder.PlanForRawBidderResponseStage(endpoint, account))
+		describeStage(description, hooks.StageAllProcessedBidResponses, builder.PlanForAllProcessedBidResponsesStage(endpoint, account))
+		describeStage(description, hooks.StageAuctionResponse, builder.PlanForAuctionResponseStage(endpoint, account))
+	}
+	describeStage(description, hooks.StageExitpoint, builder.PlanForExitpointStage(endpoint, account))
+	return description, nil
+}
+
+func validateEndpoint(endpoint string) error {
+	if endpoint != EndpointAuction && endpoint != EndpointAmp && endpoint != EndpointVideo {
+		return fmt.Errorf("endpoint %s is not supported, hooks run for %s, %s and %s only", endpoint, EndpointAuction, EndpointAmp, EndpointVideo)
+	}
+	return nil
+}
+
+func describeStage[T any](description PlanDescription, stage hooks.Stage, plan hooks.Plan[T]) {
+	if len(plan) == 0 {
+		return
+	}
+
+	groups := make([]PlannedGroup, 0, len(plan))
+	for _, group := range plan {
+		plannedGroup := PlannedGroup{
+			TimeoutMS:   group.Timeout.Milliseconds(),
+			MergePolicy: group.MergePolicy,
+			Hooks:       make([]PlannedHook, 0, len(group.Hooks)),
+		}
+		for _, hook := range group.Hooks {
+			plannedGroup.Hooks = append(plannedGroup.Hooks, PlannedHook{ModuleCode: hook.Module, HookImplCode: hook.Code})
+		}
+		groups = append(groups, plannedGroup)
+	}
+	description[stage] = groups
+}
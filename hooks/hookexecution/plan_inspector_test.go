@@ -0,0 +1,169 @@
+package hookexecution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPlanInspector(t *testing.T, hostPlan string, accounts map[string]*config.Account) *PlanInspector {
+	repo, err := hooks.NewHookRepository(map[string]interface{}{"foobar.body": mockUpdateBodyHook{}})
+	require.NoError(t, err)
+
+	hooksCfg := config.Hooks{Enabled: true}
+	if hostPlan != "" {
+		require.NoError(t, jsonutil.UnmarshalValid([]byte(hostPlan), &hooksCfg.HostExecutionPlan))
+	}
+	return NewPlanInspector(hooksCfg, repo, func(_ context.Context, accountID string) (*config.Account, []error) {
+		if account, ok := accounts[accountID]; ok {
+			return account, nil
+		}
+		return nil, []error{errors.New("account not found")}
+	})
+}
+
+func TestPlanInspectorValidate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		plan           string
+		expectedErrors []string
+	}{
+		{
+			name: "valid",
+			plan: `{"endpoints": {"/openrtb2/auction": {"stages": {"entrypoint": {"groups": [{"timeout": 5, "hook_sequence": [{"module_code": "foobar.body", "hook_impl_code": "code"}]}]}}}}}`,
+		},
+		{
+			name:           "unknown-endpoint",
+			plan:           `{"endpoints": {"/openrtb2/other": {"stages": {}}}}`,
+			expectedErrors: []string{"endpoint /openrtb2/other is not supported, hooks run for /openrtb2/auction, /openrtb2/amp and /openrtb2/video only"},
+		},
+		{
+			name:           "unknown-stage",
+			plan:           `{"endpoints": {"/openrtb2/amp": {"stages": {"exitpoint_typo": {"groups": []}}}}}`,
+			expectedErrors: []string{"endpoint /openrtb2/amp: unknown stage exitpoint_typo"},
+		},
+		{
+			name:           "video-stage-not-run",
+			plan:           `{"endpoints": {"/openrtb2/video": {"stages": {"entrypoint": {"groups": []}, "raw_auction_request": {"groups": []}, "exitpoint": {"groups": []}}}}}`,
+			expectedErrors: []string{"endpoint /openrtb2/video: stage raw_auction_request is not run, only the entrypoint and exitpoint stages are"},
+		},
+		{
+			name: "invalid-groups",
+			plan: `{"endpoints": {"/openrtb2/auction": {"stages": {"bidder_request": {"groups": [
+				{"timeout": 0, "hook_sequence": [{"module_code": "foobar.body", "hook_impl_code": "code"}]},
+				{"timeout": 5, "merge_policy": "random", "hook_sequence": [{"module_code": "foobar.unknown", "hook_impl_code": "code"}, {"module_code": "foobar.body", "hook_impl_code": ""}]}
+			]}}}}}`,
+			expectedErrors: []string{
+				"endpoint /openrtb2/auction, stage bidder_request, group 0: timeout must be > 0. Got 0",
+				"endpoint /openrtb2/auction, stage bidder_request, group 0: module foobar.body does not implement the bidder_request stage",
+				`endpoint /openrtb2/auction, stage bidder_request, group 1: merge_policy must be one of "last_wins" or "first_wins". Got "random"`,
+				"endpoint /openrtb2/auction, stage bidder_request, group 1: module foobar.unknown is not registered",
+				"endpoint /openrtb2/auction, stage bidder_request, group 1: hook of module foobar.body has no hook_impl_code",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var plan config.HookExecutionPlan
+			require.NoError(t, jsonutil.UnmarshalValid([]byte(test.plan), &plan))
+
+			errs := newTestPlanInspector(t, "", nil).Validate(plan)
+
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, test.expectedErrors, messages)
+		})
+	}
+}
+
+func TestPlanInspectorDryRun(t *testing.T) {
+	const hostPlan = `{"endpoints": {"/openrtb2/auction": {"stages": {"entrypoint": {"groups": [{"timeout": 5, "hook_sequence": [{"module_code": "foobar.body", "hook_impl_code": "host"}]}]}}}}}`
+	const accountPlan = `{"endpoints": {"/openrtb2/auction": {"stages": {"raw_auction_request": {"groups": [{"timeout": 10, "hook_sequence": [{"module_code": "foobar.body", "hook_impl_code": "account"}, {"module_code": "foobar.unknown", "hook_impl_code": "skipped"}]}]}}}}}`
+	const proposedPlan = `{"endpoints": {"/openrtb2/auction": {"stages": {"raw_auction_request": {"groups": [{"timeout": 20, "merge_policy": "first_wins", "hook_sequence": [{"module_code": "foobar.body", "hook_impl_code": "proposed"}]}]}}}}}`
+
+	account := &config.Account{ID: "1001"}
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(accountPlan), &account.Hooks.ExecutionPlan))
+	var proposed config.HookExecutionPlan
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(proposedPlan), &proposed))
+
+	hostStage := []PlannedGroup{{TimeoutMS: 5, Hooks: []PlannedHook{{ModuleCode: "foobar.body", HookImplCode: "host"}}}}
+
+	testCases := []struct {
+		name                string
+		endpoint            string
+		accountID           string
+		plan                *config.HookExecutionPlan
+		expectedDescription PlanDescription
+		expectedErr         string
+	}{
+		{
+			name:     "host-plan-only",
+			endpoint: EndpointAuction,
+			expectedDescription: PlanDescription{
+				hooks.StageEntrypoint: hostStage,
+			},
+		},
+		{
+			name:      "account-plan",
+			endpoint:  EndpointAuction,
+			accountID: "1001",
+			expectedDescription: PlanDescription{
+				hooks.StageEntrypoint:        hostStage,
+				hooks.StageRawAuctionRequest: {{TimeoutMS: 10, Hooks: []PlannedHook{{ModuleCode: "foobar.body", HookImplCode: "account"}}}},
+			},
+		},
+		{
+			name:      "proposed-plan",
+			endpoint:  EndpointAuction,
+			accountID: "1001",
+			plan:      &proposed,
+			expectedDescription: PlanDescription{
+				hooks.StageEntrypoint:        hostStage,
+				hooks.StageRawAuctionRequest: {{TimeoutMS: 20, MergePolicy: hooks.MergePolicyFirstWins, Hooks: []PlannedHook{{ModuleCode: "foobar.body", HookImplCode: "proposed"}}}},
+			},
+		},
+		{
+			name:                "other-endpoint",
+			endpoint:            EndpointAmp,
+			accountID:           "1001",
+			expectedDescription: PlanDescription{},
+		},
+		{
+			name:        "unknown-account",
+			endpoint:    EndpointAuction,
+			accountID:   "unknown",
+			expectedErr: "account not found",
+		},
+		{
+			name:        "unsupported-endpoint",
+			endpoint:    "/openrtb2/other",
+			expectedErr: "endpoint /openrtb2/other is not supported, hooks run for /openrtb2/auction, /openrtb2/amp and /openrtb2/video only",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			inspector := newTestPlanInspector(t, hostPlan, map[string]*config.Account{"1001": account})
+
+			description, errs := inspector.DryRun(context.Background(), test.endpoint, test.accountID, test.plan)
+
+			if test.expectedErr != "" {
+				require.Len(t, errs, 1)
+				assert.EqualError(t, errs[0], test.expectedErr)
+				return
+			}
+			assert.Empty(t, errs)
+			assert.Equal(t, test.expectedDescription, description)
+			assert.Equal(t, "account", account.Hooks.ExecutionPlan.Endpoints[EndpointAuction].Stages["raw_auction_request"].Groups[0].HookSequence[0].HookImplCode, "the account must not be modified")
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package hookstage
+
+import (
+	"context"
+	"net/http"
+)
+
+// Exitpoint hooks are invoked once the response is serialized, right before it's written.
+// They are run for the "/openrtb2/auction", "/openrtb2/amp" and "/openrtb2/video" endpoints,
+// the endpoint of the request is passed in the ModuleInvocationContext.
+//
+// At this stage, account config is available,
+// so it can be configured at the account-level execution plan,
+// the account-level module config is passed to hooks.
+//
+// The outcome of the stage only reaches the analytics modules,
+// since the response holding the hooks debug information is already serialized.
+//
+// Rejection has no effect and is completely ignored at this stage.
+type Exitpoint interface {
+	HandleExitpointHook(
+		context.Context,
+		ModuleInvocationContext,
+		ExitpointPayload,
+	) (HookResult[ExitpointPayload], error)
+}
+
+// ExitpointPayload consists of the HTTP status code, headers and body of the response
+// that will be sent back to the requester. The body is the OpenRTB BidResponse
+// for "/openrtb2/auction", the targeting JSON for "/openrtb2/amp"
+// and the pods response for "/openrtb2/video".
+// Hooks are allowed to modify this data using mutations.
+type ExitpointPayload struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+}
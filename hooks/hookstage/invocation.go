@@ -2,21 +2,32 @@ package hookstage
 
 import (
 	"encoding/json"
+	"net/http"
 
 	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
 
 // HookResult represents the result of execution the concrete hook instance.
 type HookResult[T any] struct {
-	Reject        bool         // true value indicates rejection of the program execution at the specific stage
-	NbrCode       int          // hook must provide NbrCode if the field Reject set to true
-	Message       string       // holds arbitrary message added by hook
-	ChangeSet     ChangeSet[T] // set of changes the module wants to apply to hook payload in case of successful execution
-	Errors        []string
-	Warnings      []string
-	DebugMessages []string
-	AnalyticsTags hookanalytics.Analytics
-	ModuleContext ModuleContext // holds values that the module wants to pass to itself at later stages
+	Reject         bool          // true value indicates rejection of the program execution at the specific stage
+	NbrCode        int           // hook must provide NbrCode if the field Reject set to true
+	RejectResponse *HTTPResponse // response sent instead of the no-bid response if the hook rejects the whole request
+	Message        string        // holds arbitrary message added by hook
+	ChangeSet      ChangeSet[T]  // set of changes the module wants to apply to hook payload in case of successful execution
+	Errors         []string
+	Warnings       []string
+	DebugMessages  []string
+	AnalyticsTags  hookanalytics.Analytics
+	ModuleContext  ModuleContext            // holds values that the module wants to pass to itself at later stages
+	SeatNonBid     []openrtb_ext.SeatNonBid // bids or imps rejected by the hook, added to the seat non bids of the auction response
+}
+
+// HTTPResponse describes the HTTP response a hook rejects the request with.
+type HTTPResponse struct {
+	StatusCode int
+	Header     http.Header // added to the headers of the endpoint, replacing the values of the same headers
+	Body       []byte
 }
 
 // ModuleInvocationContext holds data passed to the module hook during invocation.
@@ -0,0 +1,21 @@
+package hookstage
+
+// PayloadPaths lists the payload paths a hook reads and writes at a stage.
+// Paths use the dot notation of the mutation keys, e.g. "bidrequest.imp.banner.battr",
+// and a path covers all the paths nested under it.
+type PayloadPaths struct {
+	Reads  []string
+	Writes []string
+}
+
+// PayloadPathsDeclarer may be implemented by modules to declare the payload paths
+// their hooks access at the given stage.
+//
+// Hooks of the same group run concurrently on the same payload, so a hook doesn't see
+// the mutations of the other hooks of its group. The declared paths let the executor
+// detect such conflicts and report them in the trace: the mutation of a path read
+// by another hook, and the mutations of overlapping paths, merged according to the
+// merge policy of the group. The keys of the applied mutations always count as written.
+type PayloadPathsDeclarer interface {
+	PayloadPaths(stage string) PayloadPaths
+}
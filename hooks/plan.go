@@ -19,6 +19,7 @@ const (
 	StageRawBidderResponse        Stage = "raw_bidder_response"
 	StageAllProcessedBidResponses Stage = "all_processed_bid_responses"
 	StageAuctionResponse          Stage = "auction_response"
+	StageExitpoint                Stage = "exitpoint"
 )
 
 func (s Stage) String() string {
@@ -27,7 +28,16 @@ func (s Stage) String() string {
 
 func (s Stage) IsRejectable() bool {
 	return s != StageAllProcessedBidResponses &&
-		s != StageAuctionResponse
+		s != StageAuctionResponse &&
+		s != StageExitpoint
+}
+
+// RejectsRequest reports whether a rejection at the stage ends the processing of the whole request,
+// rather than the processing of a single bidder.
+func (s Stage) RejectsRequest() bool {
+	return s == StageEntrypoint ||
+		s == StageRawAuctionRequest ||
+		s == StageProcessedAuctionRequest
 }
 
 // ExecutionPlanBuilder is the interface that provides methods
@@ -41,15 +51,36 @@ type ExecutionPlanBuilder interface {
 	PlanForRawBidderResponseStage(endpoint string, account *config.Account) Plan[hookstage.RawBidderResponse]
 	PlanForAllProcessedBidResponsesStage(endpoint string, account *config.Account) Plan[hookstage.AllProcessedBidResponses]
 	PlanForAuctionResponseStage(endpoint string, account *config.Account) Plan[hookstage.AuctionResponse]
+	PlanForExitpointStage(endpoint string, account *config.Account) Plan[hookstage.Exitpoint]
 }
 
 // Plan represents a slice of groups of hooks of a specific type grouped in the established order.
 type Plan[T any] []Group[T]
 
+// MergePolicy defines how the mutations of the hooks of a group are merged when
+// several hooks mutate the same payload path.
+type MergePolicy string
+
+const (
+	// MergePolicyLastWins applies all mutations in the order of the hooks in the group,
+	// so the last hook mutating a path wins. It is the default policy.
+	MergePolicyLastWins MergePolicy = config.MergePolicyLastWins
+	// MergePolicyFirstWins discards the mutations of the paths already mutated
+	// by a previous hook of the group.
+	MergePolicyFirstWins MergePolicy = config.MergePolicyFirstWins
+)
+
+// IsValid reports whether the policy is known, the empty policy standing for MergePolicyLastWins.
+func (p MergePolicy) IsValid() bool {
+	return p == "" || p == MergePolicyLastWins || p == MergePolicyFirstWins
+}
+
 // Group represents a slice of hooks sorted in the established order.
 type Group[T any] struct {
 	// Timeout specifies the max duration in milliseconds that a group of hooks is allowed to run.
 	Timeout time.Duration
+	// MergePolicy specifies how the conflicting mutations of the hooks are merged.
+	MergePolicy MergePolicy
 	// Hooks holds a slice of HookWrapper of a specific type.
 	Hooks []HookWrapper[T]
 }
@@ -156,6 +187,16 @@ func (p PlanBuilder) PlanForAuctionResponseStage(endpoint string, account *confi
 	)
 }
 
+func (p PlanBuilder) PlanForExitpointStage(endpoint string, account *config.Account) Plan[hookstage.Exitpoint] {
+	return getMergedPlan(
+		p.hooks,
+		account,
+		endpoint,
+		StageExitpoint,
+		p.repo.GetExitpointHook,
+	)
+}
+
 type hookFn[T any] func(moduleName string) (T, bool)
 
 func getMergedPlan[T any](
@@ -194,6 +235,11 @@ func getGroup[T any](getHookFn hookFn[T], cfg config.HookExecutionGroup) Group[T
 		Hooks:   make([]HookWrapper[T], 0, len(cfg.HookSequence)),
 	}
 
+	// the config validation rejects the unknown policies at startup and when the accounts are fetched
+	if policy := MergePolicy(cfg.MergePolicy); policy.IsValid() {
+		group.MergePolicy = policy
+	}
+
 	for _, hookCfg := range cfg.HookSequence {
 		if h, ok := getHookFn(hookCfg.ModuleCode); ok {
 			group.Hooks = append(group.Hooks, HookWrapper[T]{Module: hookCfg.ModuleCode, Code: hookCfg.HookImplCode, Hook: h})
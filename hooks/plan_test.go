@@ -2,6 +2,7 @@ package hooks
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -770,6 +771,95 @@ func TestPlanForAuctionResponseStage(t *testing.T) {
 	}
 }
 
+func TestPlanForExitpointStage(t *testing.T) {
+	const group1 string = `{"timeout":  5, "hook_sequence": [{"module_code": "foobar", "hook_impl_code": "foo"}]}`
+	const group2 string = `{"timeout": 10, "hook_sequence": [{"module_code": "prebid", "hook_impl_code": "baz"}]}`
+	const hostPlanData string = `{"endpoints": {"/openrtb2/video": {"stages": {"exitpoint": {"groups": [` + group1 + `]}}}}}`
+	const accountPlanData string = `{"execution_plan": {"endpoints": {"/openrtb2/video": {"stages": {"exitpoint": {"groups": [` + group2 + `]}}}}}}`
+
+	hooks := map[string]interface{}{
+		"foobar": fakeExitpointHook{},
+		"prebid": fakeExitpointHook{},
+	}
+
+	testCases := map[string]struct {
+		givenEndpoint string
+		expectedPlan  Plan[hookstage.Exitpoint]
+	}{
+		"Host and account execution plans are merged": {
+			givenEndpoint: "/openrtb2/video",
+			expectedPlan: Plan[hookstage.Exitpoint]{
+				Group[hookstage.Exitpoint]{
+					Timeout: 5 * time.Millisecond,
+					Hooks: []HookWrapper[hookstage.Exitpoint]{
+						{Module: "foobar", Code: "foo", Hook: fakeExitpointHook{}},
+					},
+				},
+				Group[hookstage.Exitpoint]{
+					Timeout: 10 * time.Millisecond,
+					Hooks: []HookWrapper[hookstage.Exitpoint]{
+						{Module: "prebid", Code: "baz", Hook: fakeExitpointHook{}},
+					},
+				},
+			},
+		},
+		"Empty plan for other endpoints": {
+			givenEndpoint: "/openrtb2/auction",
+			expectedPlan:  Plan[hookstage.Exitpoint]{},
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			account := new(config.Account)
+			if err := jsonutil.UnmarshalValid([]byte(accountPlanData), &account.Hooks); err != nil {
+				t.Fatal(err)
+			}
+
+			planBuilder, err := getPlanBuilder(hooks, []byte(hostPlanData), []byte(`{}`))
+			if assert.NoError(t, err, "Failed to init hook execution plan builder") {
+				plan := planBuilder.PlanForExitpointStage(test.givenEndpoint, account)
+				assert.Equal(t, test.expectedPlan, plan)
+			}
+		})
+	}
+}
+
+func TestPlanMergePolicy(t *testing.T) {
+	const group string = `{"timeout": 5, "merge_policy": %q, "hook_sequence": [{"module_code": "foobar", "hook_impl_code": "foo"}]}`
+	const planData string = `{"endpoints": {"/openrtb2/auction": {"stages": {"entrypoint": {"groups": [` + group + `]}}}}}`
+
+	testCases := map[string]struct {
+		givenPolicy    string
+		expectedPolicy MergePolicy
+	}{
+		"Default policy kept empty": {
+			givenPolicy:    "",
+			expectedPolicy: "",
+		},
+		"Known policy set": {
+			givenPolicy:    "first_wins",
+			expectedPolicy: MergePolicyFirstWins,
+		},
+		"Unknown policy replaced with the default one": {
+			givenPolicy:    "random",
+			expectedPolicy: "",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			planBuilder, err := getPlanBuilder(map[string]interface{}{"foobar": fakeEntrypointHook{}}, []byte(fmt.Sprintf(planData, test.givenPolicy)), []byte(`{}`))
+			if assert.NoError(t, err, "Failed to init hook execution plan builder") {
+				plan := planBuilder.PlanForEntrypointStage("/openrtb2/auction")
+				if assert.Len(t, plan, 1) {
+					assert.Equal(t, test.expectedPolicy, plan[0].MergePolicy)
+				}
+			}
+		})
+	}
+}
+
 func getPlanBuilder(
 	moduleHooks map[string]interface{},
 	hostPlanData, accountPlanData []byte,
@@ -870,3 +960,13 @@ func (f fakeAuctionResponseHook) HandleAuctionResponseHook(
 ) (hookstage.HookResult[hookstage.AuctionResponsePayload], error) {
 	return hookstage.HookResult[hookstage.AuctionResponsePayload]{}, nil
 }
+
+type fakeExitpointHook struct{}
+
+func (f fakeExitpointHook) HandleExitpointHook(
+	_ context.Context,
+	_ hookstage.ModuleInvocationContext,
+	_ hookstage.ExitpointPayload,
+) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+	return hookstage.HookResult[hookstage.ExitpointPayload]{}, nil
+}
@@ -0,0 +1,59 @@
+package macros
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OpenRTB substitution macros of the win and loss notification urls
+const (
+	AuctionIDMacro       = "${AUCTION_ID}"
+	AuctionBidIDMacro    = "${AUCTION_BID_ID}"
+	AuctionImpIDMacro    = "${AUCTION_IMP_ID}"
+	AuctionSeatIDMacro   = "${AUCTION_SEAT_ID}"
+	AuctionAdIDMacro     = "${AUCTION_AD_ID}"
+	AuctionPriceMacro    = "${AUCTION_PRICE}"
+	AuctionCurrencyMacro = "${AUCTION_CURRENCY}"
+	AuctionLossMacro     = "${AUCTION_LOSS}"
+)
+
+// AuctionMacros are the values of the OpenRTB substitution macros for a bid
+type AuctionMacros struct {
+	AuctionID string
+	BidID     string
+	ImpID     string
+	SeatID    string
+	AdID      string
+	// Price is the clearing price in Currency, it's left out of the loss notifications
+	Price    float64
+	Currency string
+	// Loss is the OpenRTB loss reason code, 0 for the winning bid
+	Loss int
+}
+
+// ResolveAuctionMacros replaces the OpenRTB substitution macros of notificationURL with the query escaped values of
+// params. The macros without a value are replaced with an empty string.
+func ResolveAuctionMacros(notificationURL string, params AuctionMacros) string {
+	if !strings.Contains(notificationURL, "${AUCTION_") {
+		return notificationURL
+	}
+
+	var price, loss string
+	if params.Loss == 0 {
+		price = strconv.FormatFloat(params.Price, 'f', -1, 64)
+	} else {
+		loss = strconv.Itoa(params.Loss)
+	}
+
+	return strings.NewReplacer(
+		AuctionIDMacro, url.QueryEscape(params.AuctionID),
+		AuctionBidIDMacro, url.QueryEscape(params.BidID),
+		AuctionImpIDMacro, url.QueryEscape(params.ImpID),
+		AuctionSeatIDMacro, url.QueryEscape(params.SeatID),
+		AuctionAdIDMacro, url.QueryEscape(params.AdID),
+		AuctionPriceMacro, price,
+		AuctionCurrencyMacro, url.QueryEscape(params.Currency),
+		AuctionLossMacro, loss,
+	).Replace(notificationURL)
+}
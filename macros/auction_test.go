@@ -0,0 +1,61 @@
+package macros
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAuctionMacros(t *testing.T) {
+	params := AuctionMacros{
+		AuctionID: "auction-1",
+		BidID:     "bid 1",
+		ImpID:     "imp-1",
+		SeatID:    "appnexus",
+		AdID:      "ad-1",
+		Price:     1.25,
+		Currency:  "EUR",
+	}
+
+	testCases := []struct {
+		name     string
+		url      string
+		params   AuctionMacros
+		expected string
+	}{
+		{
+			name:     "no_macros",
+			url:      "https://bidder.com/win?id=1",
+			params:   params,
+			expected: "https://bidder.com/win?id=1",
+		},
+		{
+			name:     "win",
+			url:      "https://bidder.com/win?a=${AUCTION_ID}&b=${AUCTION_BID_ID}&i=${AUCTION_IMP_ID}&s=${AUCTION_SEAT_ID}&ad=${AUCTION_AD_ID}&p=${AUCTION_PRICE}&c=${AUCTION_CURRENCY}&l=${AUCTION_LOSS}",
+			params:   params,
+			expected: "https://bidder.com/win?a=auction-1&b=bid+1&i=imp-1&s=appnexus&ad=ad-1&p=1.25&c=EUR&l=",
+		},
+		{
+			name: "loss",
+			url:  "https://bidder.com/loss?b=${AUCTION_BID_ID}&p=${AUCTION_PRICE}&l=${AUCTION_LOSS}",
+			params: AuctionMacros{
+				BidID: "bid-2",
+				Price: 0.5,
+				Loss:  102,
+			},
+			expected: "https://bidder.com/loss?b=bid-2&p=&l=102",
+		},
+		{
+			name:     "unknown_macro",
+			url:      "https://bidder.com/win?p=${AUCTION_PRICE}&x=${AUCTION_MBR}",
+			params:   params,
+			expected: "https://bidder.com/win?p=1.25&x=${AUCTION_MBR}",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ResolveAuctionMacros(test.url, test.params))
+		})
+	}
+}
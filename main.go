@@ -49,7 +49,7 @@ func main() {
 	garbageCollectionThreshold := make([]byte, cfg.GarbageCollectorThreshold)
 	defer runtime.KeepAlive(garbageCollectionThreshold)
 
-	err = serve(cfg)
+	err = serve(cfg, bidderInfoPath)
 	if err != nil {
 		glog.Exitf("prebid-server failed: %v", err)
 	}
@@ -64,24 +64,64 @@ func loadConfig(bidderInfos config.BidderInfos) (*config.Configuration, error) {
 	return config.New(v, bidderInfos, openrtb_ext.NormalizeBidderName)
 }
 
-func serve(cfg *config.Configuration) error {
+func serve(cfg *config.Configuration, bidderInfoPath string) error {
 	fetchingInterval := time.Duration(cfg.CurrencyConverter.FetchIntervalSeconds) * time.Second
-	staleRatesThreshold := time.Duration(cfg.CurrencyConverter.StaleRatesSeconds) * time.Second
-	currencyConverter := currency.NewRateConverter(&http.Client{}, cfg.CurrencyConverter.FetchURL, staleRatesThreshold)
-
-	currencyConverterTickerTask := task.NewTickerTask(fetchingInterval, currencyConverter)
-	currencyConverterTickerTask.Start()
+	currencyConverter := newCurrencyConverter(cfg.CurrencyConverter)
 
 	r, err := router.New(cfg, currencyConverter)
 	if err != nil {
 		return err
 	}
 
+	currencyConverter.SetMetricsEngine(r.MetricsEngine)
+	currencyConverterTickerTask := task.NewTickerTask(fetchingInterval, currencyConverter)
+	currencyConverterTickerTask.Start()
+
+	loadBidderInfos := func() (config.BidderInfos, error) {
+		return config.ParseBidderInfoFromDisk(bidderInfoPath)
+	}
+
 	corsRouter := router.SupportCORS(r)
-	if err := server.Listen(cfg, router.NoCache{Handler: corsRouter}, router.Admin(currencyConverter, fetchingInterval), r.MetricsEngine); err != nil {
+	if err := server.Listen(cfg, router.NoCache{Handler: corsRouter}, router.Admin(currencyConverter, fetchingInterval, r.BidderRegistry, loadBidderInfos, r.DebugCapturer, r.HookPlanInspector, r.ModuleManager), r.MetricsEngine); err != nil {
 		glog.Fatalf("prebid-server returned an error: %v", err)
 	}
 
 	r.Shutdown()
 	return nil
 }
+
+// newCurrencyConverter builds a rate converter querying the configured sources in order, falling back to
+// the single fetch_url source when none are configured
+func newCurrencyConverter(cfg config.CurrencyConverter) *currency.RateConverter {
+	httpClient := &http.Client{}
+	staleRatesThreshold := time.Duration(cfg.StaleRatesSeconds) * time.Second
+	hardExpiry := time.Duration(cfg.HardExpirySeconds) * time.Second
+	if hardExpiry == 0 {
+		hardExpiry = staleRatesThreshold
+	}
+
+	sources := make([]currency.RateSource, 0, len(cfg.Sources))
+	for _, source := range cfg.Sources {
+		switch source.Type {
+		case config.CurrencyConverterSourceJSON:
+			sources = append(sources, currency.NewHTTPRateSource(source.Name, httpClient, source.URL))
+		case config.CurrencyConverterSourceECB:
+			sources = append(sources, currency.NewECBRateSource(source.Name, httpClient, source.URL))
+		case config.CurrencyConverterSourceFile:
+			sources = append(sources, currency.NewFileRateSource(source.Name, source.Path))
+		}
+	}
+	if len(sources) == 0 {
+		sources = append(sources, currency.NewHTTPRateSource(currency.DefaultRateSourceName, httpClient, cfg.FetchURL))
+	}
+
+	currencyConverter := currency.NewMultiSourceRateConverter(sources, staleRatesThreshold, hardExpiry)
+	if cfg.History.MaxEntries > 0 {
+		history, err := currency.NewRatesHistory(cfg.History.MaxEntries, cfg.History.File)
+		if err != nil {
+			glog.Errorf("Unable to load currency rates history, starting with an empty history: %v", err)
+		}
+		currencyConverter.SetHistory(history)
+	}
+	return currencyConverter
+}
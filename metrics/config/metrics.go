@@ -63,6 +63,36 @@ type DetailedMetricsEngine struct {
 	PrometheusMetrics *prometheusmetrics.Metrics
 }
 
+// RegisterModules adds the metrics of hook modules built after the engine to the underlying metrics engines.
+func (e *DetailedMetricsEngine) RegisterModules(moduleStageNames map[string][]string) {
+	if e.GoMetrics != nil {
+		e.GoMetrics.RegisterModules(moduleStageNames)
+	}
+	if e.PrometheusMetrics != nil {
+		e.PrometheusMetrics.RegisterModules(moduleStageNames)
+	}
+}
+
+// RecordModuleCounter across the underlying metrics engines
+func (e *DetailedMetricsEngine) RecordModuleCounter(module, name string) {
+	if e.GoMetrics != nil {
+		e.GoMetrics.RecordModuleCounter(module, name)
+	}
+	if e.PrometheusMetrics != nil {
+		e.PrometheusMetrics.RecordModuleCounter(module, name)
+	}
+}
+
+// RecordModuleTime across the underlying metrics engines
+func (e *DetailedMetricsEngine) RecordModuleTime(module, name string, duration time.Duration) {
+	if e.GoMetrics != nil {
+		e.GoMetrics.RecordModuleTime(module, name, duration)
+	}
+	if e.PrometheusMetrics != nil {
+		e.PrometheusMetrics.RecordModuleTime(module, name, duration)
+	}
+}
+
 // MultiMetricsEngine logs metrics to multiple metrics databases The can be useful in transitioning
 // an instance from one engine to another, you can run both in parallel to verify stats match up.
 type MultiMetricsEngine []metrics.MetricsEngine
@@ -182,6 +212,13 @@ func (me *MultiMetricsEngine) RecordAdapterTime(labels metrics.AdapterLabels, le
 	}
 }
 
+// RecordAdapterWin across all engines
+func (me *MultiMetricsEngine) RecordAdapterWin(labels metrics.AdapterWinLabels, cpm float64) {
+	for _, thisME := range *me {
+		thisME.RecordAdapterWin(labels, cpm)
+	}
+}
+
 // RecordOverheadTime across all engines
 func (me *MultiMetricsEngine) RecordOverheadTime(overhead metrics.OverheadType, length time.Duration) {
 	for _, thisME := range *me {
@@ -371,6 +408,18 @@ func (me *MultiMetricsEngine) RecordModuleTimeout(labels metrics.ModuleLabels) {
 	}
 }
 
+func (me *MultiMetricsEngine) RecordCurrencyRatesFetch(source string, success bool) {
+	for _, thisME := range *me {
+		thisME.RecordCurrencyRatesFetch(source, success)
+	}
+}
+
+func (me *MultiMetricsEngine) RecordAnalyticsEventDropped(module string, eventType string) {
+	for _, thisME := range *me {
+		thisME.RecordAnalyticsEventDropped(module, eventType)
+	}
+}
+
 // NilMetricsEngine implements the MetricsEngine interface where no metrics are actually captured. This is
 // used if no metric backend is configured and also for tests.
 type NilMetricsEngine struct{}
@@ -443,6 +492,10 @@ func (me *NilMetricsEngine) RecordAdapterPrice(labels metrics.AdapterLabels, cpm
 func (me *NilMetricsEngine) RecordAdapterTime(labels metrics.AdapterLabels, length time.Duration) {
 }
 
+// RecordAdapterWin as a noop
+func (me *NilMetricsEngine) RecordAdapterWin(labels metrics.AdapterWinLabels, cpm float64) {
+}
+
 // RecordOverheadTime as a noop
 func (me *NilMetricsEngine) RecordOverheadTime(overhead metrics.OverheadType, length time.Duration) {
 }
@@ -546,3 +599,9 @@ func (me *NilMetricsEngine) RecordModuleExecutionError(labels metrics.ModuleLabe
 
 func (me *NilMetricsEngine) RecordModuleTimeout(labels metrics.ModuleLabels) {
 }
+
+func (me *NilMetricsEngine) RecordCurrencyRatesFetch(source string, success bool) {
+}
+
+func (me *NilMetricsEngine) RecordAnalyticsEventDropped(module string, eventType string) {
+}
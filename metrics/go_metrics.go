@@ -96,6 +96,8 @@ type AdapterMetrics struct {
 	BidsReceivedMeter  metrics.Meter
 	PanicMeter         metrics.Meter
 	MarkupMetrics      map[openrtb_ext.BidType]*MarkupDeliveryMetrics
+	WinMetrics         map[openrtb_ext.BidType]*WinMetrics
+	WinPriceHistogram  metrics.Histogram
 	ConnCreated        metrics.Counter
 	ConnReused         metrics.Counter
 	ConnWaitTime       metrics.Timer
@@ -114,6 +116,12 @@ type MarkupDeliveryMetrics struct {
 	NurlMeter metrics.Meter
 }
 
+// WinMetrics counts the bids winning the auction of their imp, for deals and for the open market
+type WinMetrics struct {
+	DealMeter       metrics.Meter
+	OpenMarketMeter metrics.Meter
+}
+
 type accountMetrics struct {
 	requestMeter      metrics.Meter
 	debugRequestMeter metrics.Meter
@@ -379,6 +387,16 @@ func NewMetrics(registry metrics.Registry, exchanges []openrtb_ext.BidderName, d
 	return newMetrics
 }
 
+// RegisterModules adds the stage metrics of hook modules built after the metrics, it must be called
+// before any module metric is recorded.
+func (me *Metrics) RegisterModules(moduleStageNames map[string][]string) {
+	for module, stages := range moduleStageNames {
+		me.modules = append(me.modules, module)
+		me.ModuleMetrics[module] = makeBlankModuleStageMetrics(stages)
+		registerModuleMetrics(me.MetricsRegistry, module, stages, me.ModuleMetrics[module])
+	}
+}
+
 func makeBlankOverheadTimerMetrics() map[OverheadType]metrics.Timer {
 	m := make(map[OverheadType]metrics.Timer)
 	overheads := OverheadTypes()
@@ -401,6 +419,8 @@ func makeBlankAdapterMetrics(disabledMetrics config.DisabledMetrics) *AdapterMet
 		BidsReceivedMeter: blankMeter,
 		PanicMeter:        blankMeter,
 		MarkupMetrics:     makeBlankBidMarkupMetrics(),
+		WinMetrics:        makeBlankWinMetrics(),
+		WinPriceHistogram: &metrics.NilHistogram{},
 	}
 	if !disabledMetrics.AdapterConnectionMetrics {
 		newAdapter.ConnCreated = metrics.NilCounter{}
@@ -457,6 +477,15 @@ func makeBlankMarkupDeliveryMetrics() *MarkupDeliveryMetrics {
 	}
 }
 
+func makeBlankWinMetrics() map[openrtb_ext.BidType]*WinMetrics {
+	return map[openrtb_ext.BidType]*WinMetrics{
+		openrtb_ext.BidTypeAudio:  {DealMeter: &metrics.NilMeter{}, OpenMarketMeter: &metrics.NilMeter{}},
+		openrtb_ext.BidTypeBanner: {DealMeter: &metrics.NilMeter{}, OpenMarketMeter: &metrics.NilMeter{}},
+		openrtb_ext.BidTypeNative: {DealMeter: &metrics.NilMeter{}, OpenMarketMeter: &metrics.NilMeter{}},
+		openrtb_ext.BidTypeVideo:  {DealMeter: &metrics.NilMeter{}, OpenMarketMeter: &metrics.NilMeter{}},
+	}
+}
+
 func makeOverheadTimerMetrics(registry metrics.Registry) map[OverheadType]metrics.Timer {
 	m := make(map[OverheadType]metrics.Timer)
 	overheads := OverheadTypes()
@@ -486,6 +515,15 @@ func registerAdapterMetrics(registry metrics.Registry, adapterOrAccount string,
 	}
 	if adapterOrAccount != "adapter" {
 		am.BidsReceivedMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.bids_received", adapterOrAccount, exchange), registry)
+	} else {
+		// the wins aren't recorded per account
+		am.WinMetrics = map[openrtb_ext.BidType]*WinMetrics{
+			openrtb_ext.BidTypeBanner: makeWinMetrics(registry, adapterOrAccount+"."+exchange, openrtb_ext.BidTypeBanner),
+			openrtb_ext.BidTypeVideo:  makeWinMetrics(registry, adapterOrAccount+"."+exchange, openrtb_ext.BidTypeVideo),
+			openrtb_ext.BidTypeAudio:  makeWinMetrics(registry, adapterOrAccount+"."+exchange, openrtb_ext.BidTypeAudio),
+			openrtb_ext.BidTypeNative: makeWinMetrics(registry, adapterOrAccount+"."+exchange, openrtb_ext.BidTypeNative),
+		}
+		am.WinPriceHistogram = metrics.GetOrRegisterHistogram(fmt.Sprintf("%[1]s.%[2]s.win_prices", adapterOrAccount, exchange), registry, metrics.NewExpDecaySample(1028, 0.015))
 	}
 	am.PanicMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.requests.panic", adapterOrAccount, exchange), registry)
 	am.BuyerUIDScrubbed = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.buyeruid_scrubbed", adapterOrAccount, exchange), registry)
@@ -529,6 +567,13 @@ func makeDeliveryMetrics(registry metrics.Registry, prefix string, bidType openr
 	}
 }
 
+func makeWinMetrics(registry metrics.Registry, prefix string, bidType openrtb_ext.BidType) *WinMetrics {
+	return &WinMetrics{
+		DealMeter:       metrics.GetOrRegisterMeter(prefix+"."+string(bidType)+".deal_wins", registry),
+		OpenMarketMeter: metrics.GetOrRegisterMeter(prefix+"."+string(bidType)+".open_market_wins", registry),
+	}
+}
+
 // getAccountMetrics gets or registers the account metrics for account "id".
 // There is no getBlankAccountMetrics() as all metrics are generated dynamically.
 func (me *Metrics) getAccountMetrics(id string) *accountMetrics {
@@ -807,6 +852,29 @@ func (me *Metrics) RecordAdapterPrice(labels AdapterLabels, cpm float64) {
 	}
 }
 
+// RecordAdapterWin implements a part of the MetricsEngine interface. Records the bids winning the auction of their
+// imp, the ratio to the adm and nurl bids received gives the win rate of the adapter.
+func (me *Metrics) RecordAdapterWin(labels AdapterWinLabels, cpm float64) {
+	adapterStr := string(labels.Adapter)
+	lowercaseAdapter := strings.ToLower(adapterStr)
+	am, ok := me.AdapterMetrics[lowercaseAdapter]
+	if !ok {
+		glog.Errorf("Trying to run adapter win metrics on %s: adapter metrics not found", adapterStr)
+		return
+	}
+
+	am.WinPriceHistogram.Update(int64(cpm))
+	if metricsForType, ok := am.WinMetrics[labels.BidType]; ok {
+		if labels.Deal {
+			metricsForType.DealMeter.Mark(1)
+		} else {
+			metricsForType.OpenMarketMeter.Mark(1)
+		}
+	} else {
+		glog.Errorf("win metrics map entry does not exist for type %s. This is a bug, and should be reported.", labels.BidType)
+	}
+}
+
 // RecordAdapterTime implements a part of the MetricsEngine interface. Records the adapter response time
 func (me *Metrics) RecordAdapterTime(labels AdapterLabels, length time.Duration) {
 	adapterStr := string(labels.Adapter)
@@ -1154,6 +1222,20 @@ func (me *Metrics) RecordModuleTimeout(labels ModuleLabels) {
 	}
 }
 
+// RecordCurrencyRatesFetch records the outcome of a currency rates fetch from the given source
+func (me *Metrics) RecordCurrencyRatesFetch(source string, success bool) {
+	if success {
+		metrics.GetOrRegisterMeter(fmt.Sprintf("currency_rates.%s.ok", source), me.MetricsRegistry).Mark(1)
+	} else {
+		metrics.GetOrRegisterMeter(fmt.Sprintf("currency_rates.%s.failed", source), me.MetricsRegistry).Mark(1)
+	}
+}
+
+// RecordAnalyticsEventDropped records an event an analytics module couldn't keep up with
+func (me *Metrics) RecordAnalyticsEventDropped(module string, eventType string) {
+	metrics.GetOrRegisterMeter(fmt.Sprintf("analytics.%s.%s.dropped", module, eventType), me.MetricsRegistry).Mark(1)
+}
+
 func (me *Metrics) getModuleMetric(labels ModuleLabels) (*ModuleMetrics, error) {
 	mm, ok := me.ModuleMetrics[labels.Module][labels.Stage]
 	if !ok {
@@ -1164,3 +1246,11 @@ func (me *Metrics) getModuleMetric(labels ModuleLabels) (*ModuleMetrics, error)
 
 	return mm, nil
 }
+
+func (me *Metrics) RecordModuleCounter(module, name string) {
+	metrics.GetOrRegisterCounter(fmt.Sprintf("modules.module.%s.custom.%s", module, name), me.MetricsRegistry).Inc(1)
+}
+
+func (me *Metrics) RecordModuleTime(module, name string, duration time.Duration) {
+	metrics.GetOrRegisterTimer(fmt.Sprintf("modules.module.%s.custom.%s", module, name), me.MetricsRegistry).Update(duration)
+}
@@ -1123,6 +1123,31 @@ func TestRecordModuleAccountMetrics(t *testing.T) {
 	}
 }
 
+func TestRegisterModules(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, nil, config.DisabledMetrics{}, nil, nil)
+
+	m.RegisterModules(map[string][]string{"foobar": {"entrypoint", "raw_auction"}})
+	m.RecordModuleCalled(ModuleLabels{Module: "foobar", Stage: "raw_auction", AccountID: "acc-1"}, time.Microsecond)
+
+	ensureContains(t, registry, "modules.module.foobar.stage.entrypoint.call", m.ModuleMetrics["foobar"]["entrypoint"].CallCounter)
+	ensureContains(t, registry, "modules.module.foobar.stage.raw_auction.call", m.ModuleMetrics["foobar"]["raw_auction"].CallCounter)
+	assert.Equal(t, int64(1), m.ModuleMetrics["foobar"]["raw_auction"].CallCounter.Count())
+	assert.Equal(t, int64(1), m.getAccountMetrics("acc-1").moduleMetrics["foobar"].CallCounter.Count())
+}
+
+func TestRecordModuleCustomMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, nil, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordModuleCounter("foobar", "cache_misses")
+	m.RecordModuleCounter("foobar", "cache_misses")
+	m.RecordModuleTime("foobar", "lookup", time.Millisecond)
+
+	assert.Equal(t, int64(2), metrics.GetOrRegisterCounter("modules.module.foobar.custom.cache_misses", registry).Count())
+	assert.Equal(t, int64(1), metrics.GetOrRegisterTimer("modules.module.foobar.custom.lookup", registry).Count())
+}
+
 func TestRecordOverheadTime(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -1210,6 +1235,24 @@ func TestRecordAdapterPrice(t *testing.T) {
 	assert.Equal(t, m.getAccountMetrics(pubID).adapterMetrics[lowerCaseAdapterName].PriceHistogram.Max(), int64(1000))
 }
 
+func TestRecordAdapterWin(t *testing.T) {
+	registry := metrics.NewRegistry()
+	adapter := "AnyName"
+	lowerCaseAdapterName := "anyname"
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderName(adapter), openrtb_ext.BidderAppnexus}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordAdapterWin(AdapterWinLabels{Adapter: openrtb_ext.BidderName(adapter), Seat: "seat", BidType: openrtb_ext.BidTypeVideo, Deal: true}, 2000)
+	m.RecordAdapterWin(AdapterWinLabels{Adapter: openrtb_ext.BidderName(adapter), Seat: "seat", BidType: openrtb_ext.BidTypeVideo}, 1000)
+	m.RecordAdapterWin(AdapterWinLabels{Adapter: openrtb_ext.BidderName(adapter), Seat: "seat", BidType: openrtb_ext.BidTypeVideo}, 1000)
+
+	am := m.AdapterMetrics[lowerCaseAdapterName]
+	assert.Equal(t, int64(1), am.WinMetrics[openrtb_ext.BidTypeVideo].DealMeter.Count())
+	assert.Equal(t, int64(2), am.WinMetrics[openrtb_ext.BidTypeVideo].OpenMarketMeter.Count())
+	assert.Equal(t, int64(0), am.WinMetrics[openrtb_ext.BidTypeBanner].OpenMarketMeter.Count())
+	assert.Equal(t, int64(3), am.WinPriceHistogram.Count())
+	assert.Equal(t, int64(2000), am.WinPriceHistogram.Max())
+}
+
 func TestRecordAdapterTime(t *testing.T) {
 	registry := metrics.NewRegistry()
 	syncerKeys := []string{"foo"}
@@ -1312,3 +1355,25 @@ func TestRecordAdapterRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordAnalyticsEventDropped(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderAppnexus}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordAnalyticsEventDropped("filelogger", "auction")
+	m.RecordAnalyticsEventDropped("filelogger", "auction")
+
+	assert.Equal(t, int64(2), metrics.GetOrRegisterMeter("analytics.filelogger.auction.dropped", registry).Count())
+}
+
+func TestRecordCurrencyRatesFetch(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderAppnexus}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordCurrencyRatesFetch("primary", true)
+	m.RecordCurrencyRatesFetch("primary", false)
+	m.RecordCurrencyRatesFetch("primary", false)
+
+	assert.Equal(t, int64(1), metrics.GetOrRegisterMeter("currency_rates.primary.ok", registry).Count())
+	assert.Equal(t, int64(2), metrics.GetOrRegisterMeter("currency_rates.primary.failed", registry).Count())
+}
@@ -13,6 +13,7 @@ type Labels struct {
 	PubID         string // exchange specific ID, so we cannot compile in values
 	CookieFlag    CookieFlag
 	RequestStatus RequestStatus
+	RequestID     string // only attached to the observations as an exemplar, never used as a label value
 }
 
 // AdapterLabels defines the labels that can be attached to the adapter metrics.
@@ -24,6 +25,15 @@ type AdapterLabels struct {
 	CookieFlag    CookieFlag
 	AdapterBids   AdapterBid
 	AdapterErrors map[AdapterError]struct{}
+	RequestID     string // only attached to the observations as an exemplar, never used as a label value
+}
+
+// AdapterWinLabels defines the labels of the bids winning the auction of their imp.
+type AdapterWinLabels struct {
+	Adapter openrtb_ext.BidderName
+	Seat    string
+	BidType openrtb_ext.BidType
+	Deal    bool
 }
 
 // OverheadType: overhead type enumeration
@@ -442,6 +452,7 @@ type MetricsEngine interface {
 	RecordAdapterBidReceived(labels AdapterLabels, bidType openrtb_ext.BidType, hasAdm bool)
 	RecordAdapterPrice(labels AdapterLabels, cpm float64)
 	RecordAdapterTime(labels AdapterLabels, length time.Duration)
+	RecordAdapterWin(labels AdapterWinLabels, cpm float64)
 	RecordCookieSync(status CookieSyncStatus)
 	RecordSyncerRequest(key string, status SyncerCookieSyncStatus)
 	RecordSetUid(status SetUidStatus)
@@ -472,4 +483,13 @@ type MetricsEngine interface {
 	RecordModuleSuccessRejected(labels ModuleLabels)
 	RecordModuleExecutionError(labels ModuleLabels)
 	RecordModuleTimeout(labels ModuleLabels)
+	RecordCurrencyRatesFetch(source string, success bool)
+	RecordAnalyticsEventDropped(module string, eventType string)
+}
+
+// ModuleMetricsEngine records the metrics hook modules define for themselves. Names are scoped to
+// the module so that they can't clash with another module or with the metrics above.
+type ModuleMetricsEngine interface {
+	RecordModuleCounter(module, name string)
+	RecordModuleTime(module, name string, duration time.Duration)
 }
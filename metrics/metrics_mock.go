@@ -96,6 +96,11 @@ func (me *MetricsEngineMock) RecordAdapterTime(labels AdapterLabels, length time
 	me.Called(labels, length)
 }
 
+// RecordAdapterWin mock
+func (me *MetricsEngineMock) RecordAdapterWin(labels AdapterWinLabels, cpm float64) {
+	me.Called(labels, cpm)
+}
+
 // RecordOverheadTime mock
 func (me *MetricsEngineMock) RecordOverheadTime(overhead OverheadType, length time.Duration) {
 	me.Called(overhead, length)
@@ -226,3 +231,11 @@ func (me *MetricsEngineMock) RecordModuleExecutionError(labels ModuleLabels) {
 func (me *MetricsEngineMock) RecordModuleTimeout(labels ModuleLabels) {
 	me.Called(labels)
 }
+
+func (me *MetricsEngineMock) RecordCurrencyRatesFetch(source string, success bool) {
+	me.Called(source, success)
+}
+
+func (me *MetricsEngineMock) RecordAnalyticsEventDropped(module string, eventType string) {
+	me.Called(module, eventType)
+}
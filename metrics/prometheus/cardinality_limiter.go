@@ -0,0 +1,49 @@
+package prometheusmetrics
+
+import "sync"
+
+// otherAccountLabel is the account label value of the accounts beyond the limit
+const otherAccountLabel = "other"
+
+// cardinalityLimiter bounds the number of label values of the per account histograms. The first accounts seen keep
+// their own label value, which stays stable for the lifetime of the process, and the long tail is collapsed into
+// a single "other" value.
+type cardinalityLimiter struct {
+	mutex       sync.RWMutex
+	maxAccounts int
+	accounts    map[string]struct{}
+}
+
+func newCardinalityLimiter(maxAccounts int) *cardinalityLimiter {
+	return &cardinalityLimiter{
+		maxAccounts: maxAccounts,
+		accounts:    make(map[string]struct{}, maxAccounts),
+	}
+}
+
+// label returns the label value to record the account with
+func (l *cardinalityLimiter) label(account string) string {
+	l.mutex.RLock()
+	_, known := l.accounts[account]
+	full := len(l.accounts) >= l.maxAccounts
+	l.mutex.RUnlock()
+
+	if known {
+		return account
+	}
+	if full {
+		return otherAccountLabel
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, known := l.accounts[account]; known {
+		return account
+	}
+	if len(l.accounts) >= l.maxAccounts {
+		return otherAccountLabel
+	}
+	l.accounts[account] = struct{}{}
+	return account
+}
@@ -0,0 +1,17 @@
+package prometheusmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardinalityLimiterLabel(t *testing.T) {
+	limiter := newCardinalityLimiter(2)
+
+	assert.Equal(t, "account-1", limiter.label("account-1"))
+	assert.Equal(t, "account-2", limiter.label("account-2"))
+	assert.Equal(t, otherAccountLabel, limiter.label("account-3"), "account beyond the limit")
+	assert.Equal(t, "account-1", limiter.label("account-1"), "known account once the limit is reached")
+	assert.Equal(t, otherAccountLabel, limiter.label("account-3"), "account beyond the limit stays collapsed")
+}
@@ -241,6 +241,10 @@ func preloadLabelValues(m *Metrics, syncerKeys []string, moduleStageNames map[st
 		})
 	}
 
+	preloadModuleLabelValues(m, moduleStageNames)
+}
+
+func preloadModuleLabelValues(m *Metrics, moduleStageNames map[string][]string) {
 	for module, stageValues := range moduleStageNames {
 		preloadLabelValuesForHistogram(m.moduleDuration[module], map[string][]string{
 			stageLabel: stageValues,
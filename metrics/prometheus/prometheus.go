@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
+	"github.com/golang/glog"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/metrics"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
@@ -58,12 +61,16 @@ type Metrics struct {
 	adsCertRequests              *prometheus.CounterVec
 	adsCertSignTimer             prometheus.Histogram
 	bidderServerResponseTimer    prometheus.Histogram
+	currencyRatesFetches         *prometheus.CounterVec
+	analyticsEventsDropped       *prometheus.CounterVec
 
 	// Adapter Metrics
 	adapterBids                           *prometheus.CounterVec
 	adapterErrors                         *prometheus.CounterVec
 	adapterPanics                         *prometheus.CounterVec
 	adapterPrices                         *prometheus.HistogramVec
+	adapterWins                           *prometheus.CounterVec
+	adapterWinPrices                      *prometheus.HistogramVec
 	adapterRequests                       *prometheus.CounterVec
 	overheadTimer                         *prometheus.HistogramVec
 	adapterRequestsTimer                  *prometheus.HistogramVec
@@ -100,7 +107,22 @@ type Metrics struct {
 	moduleExecutionErrors map[string]*prometheus.CounterVec
 	moduleTimeouts        map[string]*prometheus.CounterVec
 
+	// Metrics defined by the modules themselves, registered on first use
+	moduleCustomLock     sync.RWMutex
+	moduleCustomCounters map[moduleCustomMetric]prometheus.Counter
+	moduleCustomTimers   map[moduleCustomMetric]prometheus.Histogram
+
+	// Account Histograms, only created when enabled
+	accountRequestsTimer        *prometheus.HistogramVec
+	accountAdapterRequestsTimer *prometheus.HistogramVec
+	accountAdapterPrices        *prometheus.HistogramVec
+	accountLimiter              *cardinalityLimiter
+
+	exemplars       bool
 	metricsDisabled config.DisabledMetrics
+
+	cfg               config.PrometheusMetrics
+	moduleTimeBuckets []float64
 }
 
 const (
@@ -112,6 +134,7 @@ const (
 	cacheResultLabel     = "cache_result"
 	connectionErrorLabel = "connection_error"
 	cookieLabel          = "cookie"
+	dealLabel            = "deal"
 	hasBidsLabel         = "has_bids"
 	isAudioLabel         = "audio"
 	isBannerLabel        = "banner"
@@ -123,6 +146,7 @@ const (
 	privacyBlockedLabel  = "privacy_blocked"
 	requestStatusLabel   = "request_status"
 	requestTypeLabel     = "request_type"
+	seatLabel            = "seat"
 	stageLabel           = "stage"
 	statusLabel          = "status"
 	successLabel         = "success"
@@ -155,6 +179,13 @@ const (
 	sourceRequest = "request"
 )
 
+const requestIDExemplarLabel = "request_id"
+
+const (
+	analyticsModuleLabel = "module"
+	eventTypeLabel       = "event_type"
+)
+
 const (
 	storedDataFetchTypeLabel = "stored_data_fetch_type"
 	storedDataErrorLabel     = "stored_data_error"
@@ -168,9 +199,20 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 	queuedRequestTimeBuckets := []float64{0, 1, 5, 30, 60, 120, 180, 240, 300}
 	overheadTimeBuckets := []float64{0.05, 0.06, 0.07, 0.08, 0.09, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1}
 
+	requestTimeBuckets := bucketsOrDefault(cfg.Buckets.RequestTime, standardTimeBuckets)
+	adapterTimeBuckets := bucketsOrDefault(cfg.Buckets.AdapterTime, standardTimeBuckets)
+	moduleTimeBuckets := bucketsOrDefault(cfg.Buckets.ModuleTime, standardTimeBuckets)
+	cacheWriteTimeBuckets = bucketsOrDefault(cfg.Buckets.CacheWriteTime, cacheWriteTimeBuckets)
+	priceBuckets = bucketsOrDefault(cfg.Buckets.AdapterPrice, priceBuckets)
+	queuedRequestTimeBuckets = bucketsOrDefault(cfg.Buckets.QueuedRequestTime, queuedRequestTimeBuckets)
+	overheadTimeBuckets = bucketsOrDefault(cfg.Buckets.OverheadTime, overheadTimeBuckets)
+
 	metrics := Metrics{}
 	reg := prometheus.NewRegistry()
 	metrics.metricsDisabled = disabledMetrics
+	metrics.exemplars = cfg.Exemplars
+	metrics.cfg = cfg
+	metrics.moduleTimeBuckets = moduleTimeBuckets
 
 	metrics.connectionsClosed = newCounterWithoutLabels(cfg, reg,
 		"connections_closed",
@@ -223,7 +265,7 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"request_time_seconds",
 		"Seconds to resolve successful Prebid Server requests labeled by type.",
 		[]string{requestTypeLabel},
-		standardTimeBuckets)
+		requestTimeBuckets)
 
 	metrics.requestsWithoutCookie = newCounter(cfg, reg,
 		"requests_without_cookie",
@@ -384,6 +426,17 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		[]string{adapterLabel},
 		priceBuckets)
 
+	metrics.adapterWins = newCounter(cfg, reg,
+		"adapter_wins",
+		"Count of bids winning the auction of their imp labeled by adapter, seat, bid type and if the bid is for a deal. The ratio to adapter_bids is the win rate of the adapter.",
+		[]string{adapterLabel, seatLabel, bidTypeLabel, dealLabel})
+
+	metrics.adapterWinPrices = newHistogramVec(cfg, reg,
+		"adapter_win_prices",
+		"Monetary value of the bids winning the auction of their imp labeled by adapter.",
+		[]string{adapterLabel},
+		priceBuckets)
+
 	metrics.adapterRequests = newCounter(cfg, reg,
 		"adapter_requests",
 		"Count of requests labeled by adapter, if has a cookie, and if it resulted in bids.",
@@ -437,7 +490,7 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"adapter_request_time_seconds",
 		"Seconds to resolve each successful request labeled by adapter.",
 		[]string{adapterLabel},
-		standardTimeBuckets)
+		adapterTimeBuckets)
 
 	metrics.bidderServerResponseTimer = newHistogram(cfg, reg,
 		"bidder_server_response_time_seconds",
@@ -505,7 +558,39 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of AdsCert request, and if they were successfully sent.",
 		[]string{successLabel})
 
-	createModulesMetrics(cfg, reg, &metrics, moduleStageNames, standardTimeBuckets)
+	metrics.currencyRatesFetches = newCounter(cfg, reg,
+		"currency_rates_fetches",
+		"Count of currency rates fetches labeled by source, and if they were successful.",
+		[]string{sourceLabel, successLabel})
+
+	metrics.analyticsEventsDropped = newCounter(cfg, reg,
+		"analytics_events_dropped",
+		"Count of analytics events dropped by a module labeled by module and event type.",
+		[]string{analyticsModuleLabel, eventTypeLabel})
+
+	if cfg.AccountHistograms.Enabled {
+		metrics.accountLimiter = newCardinalityLimiter(cfg.AccountHistograms.MaxAccounts)
+
+		metrics.accountRequestsTimer = newHistogramVec(cfg, reg,
+			"account_request_time_seconds",
+			"Seconds to resolve successful Prebid Server requests labeled by account and type.",
+			[]string{accountLabel, requestTypeLabel},
+			requestTimeBuckets)
+
+		metrics.accountAdapterRequestsTimer = newHistogramVec(cfg, reg,
+			"account_adapter_request_time_seconds",
+			"Seconds to resolve each successful request labeled by account and adapter.",
+			[]string{accountLabel, adapterLabel},
+			adapterTimeBuckets)
+
+		metrics.accountAdapterPrices = newHistogramVec(cfg, reg,
+			"account_adapter_prices",
+			"Monetary value of the bids labeled by account and adapter.",
+			[]string{accountLabel, adapterLabel},
+			priceBuckets)
+	}
+
+	createModulesMetrics(cfg, reg, &metrics, moduleStageNames, moduleTimeBuckets)
 
 	metrics.Gatherer = reg
 
@@ -525,16 +610,27 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 	return &metrics
 }
 
-func createModulesMetrics(cfg config.PrometheusMetrics, registry *prometheus.Registry, m *Metrics, moduleStageNames map[string][]string, standardTimeBuckets []float64) {
-	l := len(moduleStageNames)
-	m.moduleDuration = make(map[string]*prometheus.HistogramVec, l)
-	m.moduleCalls = make(map[string]*prometheus.CounterVec, l)
-	m.moduleFailures = make(map[string]*prometheus.CounterVec, l)
-	m.moduleSuccessNoops = make(map[string]*prometheus.CounterVec, l)
-	m.moduleSuccessUpdates = make(map[string]*prometheus.CounterVec, l)
-	m.moduleSuccessRejects = make(map[string]*prometheus.CounterVec, l)
-	m.moduleExecutionErrors = make(map[string]*prometheus.CounterVec, l)
-	m.moduleTimeouts = make(map[string]*prometheus.CounterVec, l)
+// RegisterModules creates the metrics of hook modules built after the metrics, it must be called
+// before any module metric is recorded.
+func (m *Metrics) RegisterModules(moduleStageNames map[string][]string) {
+	createModulesMetrics(m.cfg, m.Gatherer, m, moduleStageNames, m.moduleTimeBuckets)
+	preloadModuleLabelValues(m, moduleStageNames)
+}
+
+func createModulesMetrics(cfg config.PrometheusMetrics, registry *prometheus.Registry, m *Metrics, moduleStageNames map[string][]string, moduleTimeBuckets []float64) {
+	if m.moduleDuration == nil {
+		l := len(moduleStageNames)
+		m.moduleDuration = make(map[string]*prometheus.HistogramVec, l)
+		m.moduleCalls = make(map[string]*prometheus.CounterVec, l)
+		m.moduleFailures = make(map[string]*prometheus.CounterVec, l)
+		m.moduleSuccessNoops = make(map[string]*prometheus.CounterVec, l)
+		m.moduleSuccessUpdates = make(map[string]*prometheus.CounterVec, l)
+		m.moduleSuccessRejects = make(map[string]*prometheus.CounterVec, l)
+		m.moduleExecutionErrors = make(map[string]*prometheus.CounterVec, l)
+		m.moduleTimeouts = make(map[string]*prometheus.CounterVec, l)
+		m.moduleCustomCounters = make(map[moduleCustomMetric]prometheus.Counter)
+		m.moduleCustomTimers = make(map[moduleCustomMetric]prometheus.Histogram)
+	}
 
 	// create for each registered module its own metric
 	for module := range moduleStageNames {
@@ -542,7 +638,7 @@ func createModulesMetrics(cfg config.PrometheusMetrics, registry *prometheus.Reg
 			fmt.Sprintf("modules_%s_duration", module),
 			"Amount of seconds a module processed a hook labeled by stage name.",
 			[]string{stageLabel},
-			standardTimeBuckets)
+			moduleTimeBuckets)
 
 		m.moduleCalls[module] = newCounter(cfg, registry,
 			fmt.Sprintf("modules_%s_called", module),
@@ -618,6 +714,27 @@ func newHistogramVec(cfg config.PrometheusMetrics, registry *prometheus.Registry
 	return histogram
 }
 
+// bucketsOrDefault returns the buckets configured for a metric family, or its built-in buckets when none are
+func bucketsOrDefault(configured, defaultBuckets []float64) []float64 {
+	if len(configured) > 0 {
+		return configured
+	}
+	return defaultBuckets
+}
+
+// observe records the value, with the request id as an exemplar when they're enabled. Exemplars exceeding the
+// length allowed by Prometheus are dropped rather than truncated since a partial id is of no use.
+func (m *Metrics) observe(observer prometheus.Observer, value float64, requestID string) {
+	if m.exemplars && requestID != "" && utf8.ValidString(requestID) &&
+		utf8.RuneCountInString(requestIDExemplarLabel)+utf8.RuneCountInString(requestID) <= prometheus.ExemplarMaxRunes {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{requestIDExemplarLabel: requestID})
+			return
+		}
+	}
+	observer.Observe(value)
+}
+
 func newHistogram(cfg config.PrometheusMetrics, registry *prometheus.Registry, name, help string, buckets []float64) prometheus.Histogram {
 	opts := prometheus.HistogramOpts{
 		Namespace: cfg.Namespace,
@@ -705,9 +822,16 @@ func (m *Metrics) RecordImps(labels metrics.ImpLabels) {
 
 func (m *Metrics) RecordRequestTime(labels metrics.Labels, length time.Duration) {
 	if labels.RequestStatus == metrics.RequestStatusOK {
-		m.requestsTimer.With(prometheus.Labels{
+		m.observe(m.requestsTimer.With(prometheus.Labels{
 			requestTypeLabel: string(labels.RType),
-		}).Observe(length.Seconds())
+		}), length.Seconds(), labels.RequestID)
+
+		if m.accountRequestsTimer != nil {
+			m.observe(m.accountRequestsTimer.With(prometheus.Labels{
+				accountLabel:     m.accountLimiter.label(labels.PubID),
+				requestTypeLabel: string(labels.RType),
+			}), length.Seconds(), labels.RequestID)
+		}
 	}
 }
 
@@ -839,8 +963,32 @@ func (m *Metrics) RecordAdapterBidReceived(labels metrics.AdapterLabels, bidType
 }
 
 func (m *Metrics) RecordAdapterPrice(labels metrics.AdapterLabels, cpm float64) {
-	m.adapterPrices.With(prometheus.Labels{
-		adapterLabel: strings.ToLower(string(labels.Adapter)),
+	adapter := strings.ToLower(string(labels.Adapter))
+
+	m.observe(m.adapterPrices.With(prometheus.Labels{
+		adapterLabel: adapter,
+	}), cpm, labels.RequestID)
+
+	if m.accountAdapterPrices != nil {
+		m.observe(m.accountAdapterPrices.With(prometheus.Labels{
+			accountLabel: m.accountLimiter.label(labels.PubID),
+			adapterLabel: adapter,
+		}), cpm, labels.RequestID)
+	}
+}
+
+func (m *Metrics) RecordAdapterWin(labels metrics.AdapterWinLabels, cpm float64) {
+	adapter := strings.ToLower(string(labels.Adapter))
+
+	m.adapterWins.With(prometheus.Labels{
+		adapterLabel: adapter,
+		seatLabel:    labels.Seat,
+		bidTypeLabel: string(labels.BidType),
+		dealLabel:    strconv.FormatBool(labels.Deal),
+	}).Inc()
+
+	m.adapterWinPrices.With(prometheus.Labels{
+		adapterLabel: adapter,
 	}).Observe(cpm)
 }
 
@@ -852,9 +1000,18 @@ func (m *Metrics) RecordOverheadTime(overhead metrics.OverheadType, duration tim
 
 func (m *Metrics) RecordAdapterTime(labels metrics.AdapterLabels, length time.Duration) {
 	if len(labels.AdapterErrors) == 0 {
-		m.adapterRequestsTimer.With(prometheus.Labels{
-			adapterLabel: strings.ToLower(string(labels.Adapter)),
-		}).Observe(length.Seconds())
+		adapter := strings.ToLower(string(labels.Adapter))
+
+		m.observe(m.adapterRequestsTimer.With(prometheus.Labels{
+			adapterLabel: adapter,
+		}), length.Seconds(), labels.RequestID)
+
+		if m.accountAdapterRequestsTimer != nil {
+			m.observe(m.accountAdapterRequestsTimer.With(prometheus.Labels{
+				accountLabel: m.accountLimiter.label(labels.PubID),
+				adapterLabel: adapter,
+			}), length.Seconds(), labels.RequestID)
+		}
 	}
 }
 
@@ -1089,3 +1246,111 @@ func (m *Metrics) RecordModuleTimeout(labels metrics.ModuleLabels) {
 		stageLabel: labels.Stage,
 	}).Inc()
 }
+
+func (m *Metrics) RecordCurrencyRatesFetch(source string, success bool) {
+	if success {
+		m.currencyRatesFetches.With(prometheus.Labels{
+			sourceLabel:  source,
+			successLabel: requestSuccessful,
+		}).Inc()
+	} else {
+		m.currencyRatesFetches.With(prometheus.Labels{
+			sourceLabel:  source,
+			successLabel: requestFailed,
+		}).Inc()
+	}
+}
+
+func (m *Metrics) RecordAnalyticsEventDropped(module string, eventType string) {
+	m.analyticsEventsDropped.With(prometheus.Labels{
+		analyticsModuleLabel: module,
+		eventTypeLabel:       eventType,
+	}).Inc()
+}
+
+// moduleCustomMetric identifies a metric defined by a module.
+type moduleCustomMetric struct {
+	module string
+	name   string
+}
+
+func (k moduleCustomMetric) metricName() string {
+	return fmt.Sprintf("modules_%s_custom_%s", k.module, k.name)
+}
+
+func (m *Metrics) RecordModuleCounter(module, name string) {
+	key := moduleCustomMetric{module: module, name: name}
+
+	m.moduleCustomLock.RLock()
+	counter, ok := m.moduleCustomCounters[key]
+	m.moduleCustomLock.RUnlock()
+
+	if !ok {
+		counter = m.registerModuleCounter(key)
+	}
+	if counter != nil {
+		counter.Inc()
+	}
+}
+
+func (m *Metrics) registerModuleCounter(key moduleCustomMetric) prometheus.Counter {
+	m.moduleCustomLock.Lock()
+	defer m.moduleCustomLock.Unlock()
+
+	// another goroutine may have registered it in the meantime
+	if counter, ok := m.moduleCustomCounters[key]; ok {
+		return counter
+	}
+
+	var counter prometheus.Counter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: m.cfg.Namespace,
+		Subsystem: m.cfg.Subsystem,
+		Name:      key.metricName(),
+		Help:      fmt.Sprintf("Count of %s recorded by the %s module.", key.name, key.module),
+	})
+	if err := m.Gatherer.Register(counter); err != nil {
+		glog.Errorf("Failed to register the %s counter of the %s module: %v", key.name, key.module, err)
+		counter = nil
+	}
+	// a failed registration is remembered as well so that it is only logged once
+	m.moduleCustomCounters[key] = counter
+	return counter
+}
+
+func (m *Metrics) RecordModuleTime(module, name string, duration time.Duration) {
+	key := moduleCustomMetric{module: module, name: name}
+
+	m.moduleCustomLock.RLock()
+	timer, ok := m.moduleCustomTimers[key]
+	m.moduleCustomLock.RUnlock()
+
+	if !ok {
+		timer = m.registerModuleTimer(key)
+	}
+	if timer != nil {
+		timer.Observe(duration.Seconds())
+	}
+}
+
+func (m *Metrics) registerModuleTimer(key moduleCustomMetric) prometheus.Histogram {
+	m.moduleCustomLock.Lock()
+	defer m.moduleCustomLock.Unlock()
+
+	if timer, ok := m.moduleCustomTimers[key]; ok {
+		return timer
+	}
+
+	var timer prometheus.Histogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: m.cfg.Namespace,
+		Subsystem: m.cfg.Subsystem,
+		Name:      key.metricName(),
+		Help:      fmt.Sprintf("Seconds of %s recorded by the %s module.", key.name, key.module),
+		Buckets:   m.moduleTimeBuckets,
+	})
+	if err := m.Gatherer.Register(timer); err != nil {
+		glog.Errorf("Failed to register the %s timer of the %s module: %v", key.name, key.module, err)
+		timer = nil
+	}
+	m.moduleCustomTimers[key] = timer
+	return timer
+}
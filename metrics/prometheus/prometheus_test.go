@@ -2,6 +2,8 @@ package prometheusmetrics
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -841,6 +843,137 @@ func TestRecordAdapterPriceMetric(t *testing.T) {
 	assertHistogram(t, "adapterPrices", result, expectedCount, expectedSum)
 }
 
+func TestRecordAdapterWinMetric(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordAdapterWin(metrics.AdapterWinLabels{
+		Adapter: openrtb_ext.BidderName("AnyName"),
+		Seat:    "seat",
+		BidType: openrtb_ext.BidTypeBanner,
+		Deal:    true,
+	}, 1500)
+
+	assertCounterVecValue(t, "", "adapterWins", m.adapterWins, 1, prometheus.Labels{
+		adapterLabel: "anyname",
+		seatLabel:    "seat",
+		bidTypeLabel: string(openrtb_ext.BidTypeBanner),
+		dealLabel:    "true",
+	})
+	result := getHistogramFromHistogramVec(m.adapterWinPrices, adapterLabel, "anyname")
+	assertHistogram(t, "adapterWinPrices", result, 1, 1500)
+}
+
+func TestConfiguredBuckets(t *testing.T) {
+	m := NewMetrics(config.PrometheusMetrics{
+		Buckets: config.PrometheusBuckets{
+			RequestTime:  []float64{0.5, 1},
+			AdapterPrice: []float64{1, 2, 3},
+		},
+	}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordRequestTime(metrics.Labels{RType: metrics.ReqTypeORTB2Web, RequestStatus: metrics.RequestStatusOK}, time.Second)
+	m.RecordAdapterPrice(metrics.AdapterLabels{Adapter: openrtb_ext.BidderAppnexus}, 2)
+	m.RecordAdapterTime(metrics.AdapterLabels{Adapter: openrtb_ext.BidderAppnexus}, time.Second)
+
+	requestTime := getHistogramFromHistogramVec(m.requestsTimer, requestTypeLabel, string(metrics.ReqTypeORTB2Web))
+	assert.Len(t, requestTime.GetBucket(), 2, "configured request time buckets")
+
+	adapterPrice := getHistogramFromHistogramVec(m.adapterPrices, adapterLabel, "appnexus")
+	assert.Len(t, adapterPrice.GetBucket(), 3, "configured adapter price buckets")
+
+	adapterTime := getHistogramFromHistogramVec(m.adapterRequestsTimer, adapterLabel, "appnexus")
+	assert.Len(t, adapterTime.GetBucket(), 10, "built-in adapter time buckets")
+}
+
+func TestAccountHistograms(t *testing.T) {
+	m := NewMetrics(config.PrometheusMetrics{
+		AccountHistograms: config.PrometheusAccountHistograms{Enabled: true, MaxAccounts: 1},
+	}, config.DisabledMetrics{}, nil, nil)
+
+	for _, account := range []string{"account-1", "account-2", "account-3"} {
+		m.RecordRequestTime(metrics.Labels{PubID: account, RType: metrics.ReqTypeORTB2Web, RequestStatus: metrics.RequestStatusOK}, time.Second)
+		m.RecordAdapterTime(metrics.AdapterLabels{PubID: account, Adapter: openrtb_ext.BidderAppnexus}, time.Second)
+		m.RecordAdapterPrice(metrics.AdapterLabels{PubID: account, Adapter: openrtb_ext.BidderAppnexus}, 1)
+	}
+
+	requestTime := getHistogramFromHistogramVecByTwoKeys(m.accountRequestsTimer, accountLabel, "account-1", requestTypeLabel, string(metrics.ReqTypeORTB2Web))
+	assertHistogram(t, "accountRequestsTimer:account-1", requestTime, 1, 1)
+	requestTime = getHistogramFromHistogramVecByTwoKeys(m.accountRequestsTimer, accountLabel, otherAccountLabel, requestTypeLabel, string(metrics.ReqTypeORTB2Web))
+	assertHistogram(t, "accountRequestsTimer:other", requestTime, 2, 2)
+
+	adapterTime := getHistogramFromHistogramVecByTwoKeys(m.accountAdapterRequestsTimer, accountLabel, otherAccountLabel, adapterLabel, "appnexus")
+	assertHistogram(t, "accountAdapterRequestsTimer:other", adapterTime, 2, 2)
+
+	adapterPrice := getHistogramFromHistogramVecByTwoKeys(m.accountAdapterPrices, accountLabel, "account-1", adapterLabel, "appnexus")
+	assertHistogram(t, "accountAdapterPrices:account-1", adapterPrice, 1, 1)
+}
+
+func TestAccountHistogramsDisabled(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordRequestTime(metrics.Labels{PubID: "account", RequestStatus: metrics.RequestStatusOK}, time.Second)
+	m.RecordAdapterTime(metrics.AdapterLabels{PubID: "account", Adapter: openrtb_ext.BidderAppnexus}, time.Second)
+	m.RecordAdapterPrice(metrics.AdapterLabels{PubID: "account", Adapter: openrtb_ext.BidderAppnexus}, 1)
+
+	assert.Nil(t, m.accountRequestsTimer)
+	assert.Nil(t, m.accountAdapterRequestsTimer)
+	assert.Nil(t, m.accountAdapterPrices)
+}
+
+func TestExemplars(t *testing.T) {
+	testCases := []struct {
+		name              string
+		exemplars         bool
+		requestID         string
+		expectedRequestID string
+	}{
+		{
+			name:              "enabled",
+			exemplars:         true,
+			requestID:         "request",
+			expectedRequestID: "request",
+		},
+		{
+			name:              "disabled",
+			exemplars:         false,
+			requestID:         "request",
+			expectedRequestID: "",
+		},
+		{
+			name:              "enabled-without-request-id",
+			exemplars:         true,
+			requestID:         "",
+			expectedRequestID: "",
+		},
+		{
+			name:              "enabled-request-id-too-long",
+			exemplars:         true,
+			requestID:         strings.Repeat("a", prometheus.ExemplarMaxRunes),
+			expectedRequestID: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMetrics(config.PrometheusMetrics{Exemplars: test.exemplars}, config.DisabledMetrics{}, nil, nil)
+
+			m.RecordAdapterTime(metrics.AdapterLabels{Adapter: openrtb_ext.BidderAppnexus, RequestID: test.requestID}, 10*time.Millisecond)
+
+			histogram := getHistogramFromHistogramVec(m.adapterRequestsTimer, adapterLabel, "appnexus")
+
+			var requestID string
+			for _, bucket := range histogram.GetBucket() {
+				for _, label := range bucket.GetExemplar().GetLabel() {
+					if label.GetName() == requestIDExemplarLabel {
+						requestID = label.GetValue()
+					}
+				}
+			}
+			assert.Equal(t, test.expectedRequestID, requestID)
+		})
+	}
+}
+
 func TestAdapterRequestMetrics(t *testing.T) {
 	adapterName := "anyName"
 	lowerCasedAdapterName := "anyname"
@@ -2028,3 +2161,90 @@ func TestRecordModuleMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterModules(t *testing.T) {
+	m := NewMetrics(config.PrometheusMetrics{Port: 8080, Namespace: "prebid", Subsystem: "server"}, config.DisabledMetrics{}, []string{}, nil)
+
+	m.RegisterModules(modulesStages)
+
+	for module, stages := range modulesStages {
+		for _, stage := range stages {
+			labels := metrics.ModuleLabels{Module: module, Stage: stage}
+			m.RecordModuleCalled(labels, time.Millisecond*1)
+			m.RecordModuleTimeout(labels)
+
+			result := getHistogramFromHistogramVec(m.moduleDuration[module], stageLabel, stage)
+			assertHistogram(t, fmt.Sprintf("module_%s_duration", module), result, 1, 0.001)
+			assertCounterVecValue(t, "Module calls performed", fmt.Sprintf("%s metric recorded during %s stage", module, stage), m.moduleCalls[module], 1, prometheus.Labels{stageLabel: stage})
+			assertCounterVecValue(t, "Module timeout", fmt.Sprintf("%s metric recorded during %s stage", module, stage), m.moduleTimeouts[module], 1, prometheus.Labels{stageLabel: stage})
+		}
+	}
+}
+
+func TestRecordModuleCustomMetrics(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordModuleCounter("foobar", "cache_misses")
+	m.RecordModuleCounter("foobar", "cache_misses")
+	m.RecordModuleCounter("another_module", "cache_misses")
+	m.RecordModuleTime("foobar", "lookup", time.Millisecond*5)
+
+	// a name already used by a counter can't be reused for a timer, it is ignored
+	m.RecordModuleTime("foobar", "cache_misses", time.Millisecond*5)
+	m.RecordModuleTime("foobar", "cache_misses", time.Millisecond*5)
+
+	assertCounterValue(t, "", "foobar cache misses", m.moduleCustomCounters[moduleCustomMetric{module: "foobar", name: "cache_misses"}], 2)
+	assertCounterValue(t, "", "another_module cache misses", m.moduleCustomCounters[moduleCustomMetric{module: "another_module", name: "cache_misses"}], 1)
+
+	var lookup dto.Metric
+	m.moduleCustomTimers[moduleCustomMetric{module: "foobar", name: "lookup"}].Write(&lookup)
+	assertHistogram(t, "foobar lookup", *lookup.GetHistogram(), 1, 0.005)
+
+	assert.Nil(t, m.moduleCustomTimers[moduleCustomMetric{module: "foobar", name: "cache_misses"}])
+}
+
+func TestRecordModuleCustomMetricsConcurrently(t *testing.T) {
+	m := createMetricsForTesting()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.RecordModuleCounter("foobar", "blocked_bids")
+				m.RecordModuleTime("foobar", "lookup", time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertCounterValue(t, "", "foobar blocked bids", m.moduleCustomCounters[moduleCustomMetric{module: "foobar", name: "blocked_bids"}], 1000)
+
+	var lookup dto.Metric
+	m.moduleCustomTimers[moduleCustomMetric{module: "foobar", name: "lookup"}].Write(&lookup)
+	assert.Equal(t, uint64(1000), lookup.GetHistogram().GetSampleCount())
+}
+
+func TestRecordAnalyticsEventDropped(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordAnalyticsEventDropped("filelogger", "auction")
+	m.RecordAnalyticsEventDropped("filelogger", "auction")
+	m.RecordAnalyticsEventDropped("filelogger", "amp")
+
+	assertCounterVecValue(t, "", "auction events dropped", m.analyticsEventsDropped, 2, prometheus.Labels{analyticsModuleLabel: "filelogger", eventTypeLabel: "auction"})
+	assertCounterVecValue(t, "", "amp events dropped", m.analyticsEventsDropped, 1, prometheus.Labels{analyticsModuleLabel: "filelogger", eventTypeLabel: "amp"})
+}
+
+func TestRecordCurrencyRatesFetch(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordCurrencyRatesFetch("https://currency.prebid.org", true)
+	m.RecordCurrencyRatesFetch("https://currency.prebid.org", false)
+	m.RecordCurrencyRatesFetch("file:///etc/pbs/rates.json", true)
+
+	assertCounterVecValue(t, "", "primary source successful fetches", m.currencyRatesFetches, 1, prometheus.Labels{sourceLabel: "https://currency.prebid.org", successLabel: requestSuccessful})
+	assertCounterVecValue(t, "", "primary source failed fetches", m.currencyRatesFetches, 1, prometheus.Labels{sourceLabel: "https://currency.prebid.org", successLabel: requestFailed})
+	assertCounterVecValue(t, "", "fallback source successful fetches", m.currencyRatesFetches, 1, prometheus.Labels{sourceLabel: "file:///etc/pbs/rates.json", successLabel: requestSuccessful})
+}
@@ -2,7 +2,10 @@ package modules
 
 import (
 	fiftyonedegreesDevicedetection "github.com/prebid/prebid-server/v3/modules/fiftyonedegrees/devicedetection"
+	prebidBlocklist "github.com/prebid/prebid-server/v3/modules/prebid/blocklist"
+	prebidCreativescanner "github.com/prebid/prebid-server/v3/modules/prebid/creativescanner"
 	prebidOrtb2blocking "github.com/prebid/prebid-server/v3/modules/prebid/ortb2blocking"
+	prebidRules "github.com/prebid/prebid-server/v3/modules/prebid/rules"
 )
 
 // builders returns mapping between module name and its builder
@@ -13,7 +16,10 @@ func builders() ModuleBuilders {
 			"devicedetection": fiftyonedegreesDevicedetection.Builder,
 		},
 		"prebid": {
-			"ortb2blocking": prebidOrtb2blocking.Builder,
+			"blocklist":       prebidBlocklist.Builder,
+			"creativescanner": prebidCreativescanner.Builder,
+			"ortb2blocking":   prebidOrtb2blocking.Builder,
+			"rules":           prebidRules.Builder,
 		},
 	}
 }
@@ -10,6 +10,7 @@ import (
 type engine interface {
 	Process(evidences []onpremise.Evidence) (*dd.ResultsHash, error)
 	GetHttpHeaderKeys() []dd.EvidenceKey
+	Stop()
 }
 
 type extractor interface {
@@ -145,6 +146,11 @@ func (x defaultDeviceDetector) getSupportedHeaders() []dd.EvidenceKey {
 	return x.engine.GetHttpHeaderKeys()
 }
 
+// stop stops the data file updates of the engine and releases its resources.
+func (x defaultDeviceDetector) stop() {
+	x.engine.Stop()
+}
+
 func (x defaultDeviceDetector) getDeviceInfo(evidence []onpremise.Evidence, ua string) (*deviceInfo, error) {
 	results, err := x.engine.Process(evidence)
 	if err != nil {
@@ -91,6 +91,10 @@ func (e *engineMock) GetHttpHeaderKeys() []dd.EvidenceKey {
 	return args.Get(0).([]dd.EvidenceKey)
 }
 
+func (e *engineMock) Stop() {
+	e.Called()
+}
+
 type extractorMock struct {
 	mock.Mock
 }
@@ -188,3 +192,13 @@ func TestGetSupportedHeaders(t *testing.T) {
 	assert.Equal(t, result[0].Key, "key")
 
 }
+
+func TestStopDeviceDetector(t *testing.T) {
+	engineM := &engineMock{}
+	engineM.On("Stop").Return()
+
+	deviceDetector := defaultDeviceDetector{engine: engineM}
+	deviceDetector.stop()
+
+	engineM.AssertCalled(t, "Stop")
+}
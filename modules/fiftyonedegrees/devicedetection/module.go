@@ -72,6 +72,7 @@ type Module struct {
 type deviceDetector interface {
 	getSupportedHeaders() []dd.EvidenceKey
 	getDeviceInfo(evidence []onpremise.Evidence, ua string) (*deviceInfo, error)
+	stop()
 }
 
 type accountValidator interface {
@@ -84,6 +85,17 @@ type evidenceExtractor interface {
 	extract(ctx hookstage.ModuleContext) ([]onpremise.Evidence, string, error)
 }
 
+// Start has nothing to do, the device detector is ready once the module is built.
+func (m Module) Start(_ context.Context) error {
+	return nil
+}
+
+// Shutdown stops the device detector, including the data file updates it may run in the background.
+func (m Module) Shutdown(_ context.Context) error {
+	m.deviceDetector.stop()
+	return nil
+}
+
 func (m Module) HandleEntrypointHook(
 	_ context.Context,
 	_ hookstage.ModuleInvocationContext,
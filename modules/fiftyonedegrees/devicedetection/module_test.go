@@ -75,6 +75,21 @@ func (m *mockDeviceDetector) getDeviceInfo(evidence []onpremise.Evidence, ua str
 	return res.(*deviceInfo), args.Error(1)
 }
 
+func (m *mockDeviceDetector) stop() {
+	m.Called()
+}
+
+func TestModuleLifecycle(t *testing.T) {
+	var mockDeviceDetector mockDeviceDetector
+	mockDeviceDetector.On("stop").Return()
+
+	module := Module{deviceDetector: &mockDeviceDetector}
+
+	assert.NoError(t, module.Start(context.Background()))
+	assert.NoError(t, module.Shutdown(context.Background()))
+	mockDeviceDetector.AssertCalled(t, "stop")
+}
+
 func TestHandleEntrypointHookAccountNotAllowed(t *testing.T) {
 	var mockValidator mockAccValidator
 
@@ -2,6 +2,8 @@ package modules
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/prebid/prebid-server/v3/hooks"
@@ -10,6 +12,12 @@ import (
 
 var moduleReplacer = strings.NewReplacer(".", "_", "-", "_")
 
+// StageNamesKey returns the key of the module with the given "vendor.module_name" ID
+// in the map of modules to stage names returned by Builder.Build.
+func StageNamesKey(id string) string {
+	return moduleReplacer.Replace(id)
+}
+
 func createModuleStageNamesCollection(modules map[string]interface{}) (map[string][]string, error) {
 	moduleStageNameCollector := make(map[string][]string)
 	var added bool
@@ -57,6 +65,12 @@ func createModuleStageNamesCollection(modules map[string]interface{}) (map[strin
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
+		if _, ok := hook.(hookstage.Exitpoint); ok {
+			added = true
+			stageName := hooks.StageExitpoint.String()
+			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
+		}
+
 		if !added {
 			return nil, fmt.Errorf(`hook "%s" does not implement any supported hook interface`, id)
 		}
@@ -71,3 +85,26 @@ func addModuleStageName(moduleStageNameCollector map[string][]string, id string,
 
 	return moduleStageNameCollector
 }
+
+func isModuleEnabled(data interface{}) bool {
+	if values, ok := data.(map[string]interface{}); ok {
+		if value, ok := values["enabled"].(bool); ok {
+			return value
+		}
+	}
+	return false
+}
+
+// externalModuleKind returns the key of the external builder the module config has an object for.
+func externalModuleKind(data interface{}) string {
+	values, ok := data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	for _, kind := range slices.Sorted(maps.Keys(externalBuilders)) {
+		if _, ok := values[kind]; ok {
+			return kind
+		}
+	}
+	return ""
+}
@@ -0,0 +1,237 @@
+package modules
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/buger/jsonparser"
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/stored_requests"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// Lifecycle is an optional interface for modules holding resources or running background tasks,
+// such as data refresh loops, that have to be started and stopped along with the server.
+type Lifecycle interface {
+	// Start is called once all modules are built, before the server accepts requests.
+	// The server doesn't start if a module fails to start.
+	Start(ctx context.Context) error
+	// Shutdown is called when the server stops. The module should stop its background tasks
+	// and release its resources before the context is done.
+	Shutdown(ctx context.Context) error
+}
+
+// ConfigUpdater is an optional interface for modules able to apply a config change without a restart.
+type ConfigUpdater interface {
+	// OnConfigUpdate is called with the new host config of the module when accountID is empty,
+	// otherwise with the new config of the module for that account, nil if the account dropped it.
+	//
+	// Account configs are compared when accounts are fetched, the call for an account happens
+	// while serving one of its requests and should return quickly.
+	OnConfigUpdate(ctx context.Context, accountID string, cfg json.RawMessage) error
+}
+
+var (
+	ErrUnknownModule            = errors.New("unknown module")
+	ErrConfigUpdateNotSupported = errors.New("module does not support config updates")
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// maxWatchedAccounts caps the accounts whose module configs are remembered. The least recently fetched
+// ones are forgotten first, their next fetch is handled like a first one.
+const maxWatchedAccounts = 10000
+
+// Manager calls the lifecycle methods of the built modules.
+type Manager struct {
+	// modules by ID, in the "vendor.module_name" format
+	modules  map[string]interface{}
+	updaters map[string]ConfigUpdater
+
+	accountsLock sync.Mutex
+	maxAccounts  int
+	accounts     map[string]*list.Element
+	accountsLRU  *list.List // of *watchedAccount, the most recently fetched last
+}
+
+// watchedAccount is the last seen version of the module configs of an account, kept as hashes.
+type watchedAccount struct {
+	accountID   string
+	modulesHash [sha256.Size]byte
+	// configHashes are the hashes of the configs of the modules supporting config updates, by module ID
+	configHashes map[string][sha256.Size]byte
+}
+
+// NewManager returns a manager of the given modules, keyed by their "vendor.module_name" ID.
+func NewManager(modules map[string]interface{}) *Manager {
+	updaters := make(map[string]ConfigUpdater)
+	for id, module := range modules {
+		if updater, ok := module.(ConfigUpdater); ok {
+			updaters[id] = updater
+		}
+	}
+	return &Manager{
+		modules:     modules,
+		updaters:    updaters,
+		maxAccounts: maxWatchedAccounts,
+		accounts:    make(map[string]*list.Element),
+		accountsLRU: list.New(),
+	}
+}
+
+// Start starts the modules implementing Lifecycle, stopping the ones already started if one fails.
+func (m *Manager) Start(ctx context.Context) error {
+	var started []string
+	for _, id := range slices.Sorted(maps.Keys(m.modules)) {
+		module, ok := m.modules[id].(Lifecycle)
+		if !ok {
+			continue
+		}
+		if err := module.Start(ctx); err != nil {
+			m.shutdown(started)
+			return fmt.Errorf(`failed to start "%s" module: %s`, id, err)
+		}
+		started = append(started, id)
+	}
+	return nil
+}
+
+// Shutdown stops the modules implementing Lifecycle, giving them 10 seconds in total.
+func (m *Manager) Shutdown() {
+	m.shutdown(slices.Sorted(maps.Keys(m.modules)))
+}
+
+func (m *Manager) shutdown(ids []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	for _, id := range ids {
+		if module, ok := m.modules[id].(Lifecycle); ok {
+			if err := module.Shutdown(ctx); err != nil {
+				glog.Errorf("Failed to shut down %s module: %v", id, err)
+			}
+		}
+	}
+}
+
+// UpdateHostConfig passes a new host config to the module with the given ID.
+func (m *Manager) UpdateHostConfig(ctx context.Context, id string, cfg json.RawMessage) error {
+	if _, ok := m.modules[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownModule, id)
+	}
+	updater, ok := m.updaters[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrConfigUpdateNotSupported, id)
+	}
+	return updater.OnConfigUpdate(ctx, "", cfg)
+}
+
+// WatchAccounts returns a fetcher passing the module configs of the accounts it fetches to the modules
+// whenever they change. The fetcher is returned as is if no module supports config updates.
+func (m *Manager) WatchAccounts(fetcher stored_requests.AccountFetcher) stored_requests.AccountFetcher {
+	if len(m.updaters) == 0 {
+		return fetcher
+	}
+	return &accountWatcher{AccountFetcher: fetcher, manager: m}
+}
+
+type accountWatcher struct {
+	stored_requests.AccountFetcher
+	manager *Manager
+}
+
+func (w *accountWatcher) FetchAccount(ctx context.Context, accountDefaultJSON json.RawMessage, accountID string) (json.RawMessage, []error) {
+	account, errs := w.AccountFetcher.FetchAccount(ctx, accountDefaultJSON, accountID)
+	if len(errs) == 0 {
+		w.manager.observeAccount(ctx, accountID, account)
+	}
+	return account, errs
+}
+
+// CheckHealth checks the health of the watched fetcher, which the wrapping would hide otherwise.
+func (w *accountWatcher) CheckHealth(ctx context.Context) error {
+	return stored_requests.CheckHealth(ctx, w.AccountFetcher)
+}
+
+// observeAccount notifies the modules whose config changed since the account was last fetched.
+// The first time an account is fetched there is nothing to compare with, modules get its config
+// with the hook invocations.
+func (m *Manager) observeAccount(ctx context.Context, accountID string, account json.RawMessage) {
+	modulesJSON, _, _, err := jsonparser.Get(account, "hooks", "modules")
+	if err != nil && err != jsonparser.KeyPathNotFoundError {
+		// the account fetch reports malformed accounts
+		return
+	}
+	modulesHash := sha256.Sum256(modulesJSON)
+
+	m.accountsLock.Lock()
+	element, seen := m.accounts[accountID]
+	if seen {
+		m.accountsLRU.MoveToBack(element)
+		if element.Value.(*watchedAccount).modulesHash == modulesHash {
+			m.accountsLock.Unlock()
+			return
+		}
+	}
+	m.accountsLock.Unlock()
+
+	var modules config.AccountModules
+	if len(modulesJSON) > 0 {
+		if err := jsonutil.UnmarshalValid(modulesJSON, &modules); err != nil {
+			return
+		}
+	}
+	watched := &watchedAccount{
+		accountID:    accountID,
+		modulesHash:  modulesHash,
+		configHashes: make(map[string][sha256.Size]byte, len(m.updaters)),
+	}
+	configs := make(map[string]json.RawMessage, len(m.updaters))
+	for id := range m.updaters {
+		configs[id], _ = modules.ModuleConfig(id)
+		watched.configHashes[id] = sha256.Sum256(configs[id])
+	}
+
+	previous := m.watch(watched)
+	if previous == nil {
+		return
+	}
+	for _, id := range slices.Sorted(maps.Keys(m.updaters)) {
+		if previous.configHashes[id] == watched.configHashes[id] {
+			continue
+		}
+		if err := m.updaters[id].OnConfigUpdate(ctx, accountID, configs[id]); err != nil {
+			glog.Errorf("Failed to update %s module config of account %s: %v", id, accountID, err)
+		}
+	}
+}
+
+// watch remembers the module configs of the account, forgetting the least recently fetched accounts
+// beyond the cap, and returns the module configs it replaces, nil if the account wasn't known.
+func (m *Manager) watch(watched *watchedAccount) *watchedAccount {
+	m.accountsLock.Lock()
+	defer m.accountsLock.Unlock()
+
+	if element, ok := m.accounts[watched.accountID]; ok {
+		previous := element.Value.(*watchedAccount)
+		element.Value = watched
+		m.accountsLRU.MoveToBack(element)
+		return previous
+	}
+
+	for m.accountsLRU.Len() >= m.maxAccounts {
+		oldest := m.accountsLRU.Remove(m.accountsLRU.Front()).(*watchedAccount)
+		delete(m.accounts, oldest.accountID)
+	}
+	m.accounts[watched.accountID] = m.accountsLRU.PushBack(watched)
+	return nil
+}
@@ -0,0 +1,152 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/stored_requests"
+	"github.com/stretchr/testify/assert"
+)
+
+type lifecycleModule struct {
+	name     string
+	startErr error
+	events   *[]string
+}
+
+func (m lifecycleModule) Start(_ context.Context) error {
+	*m.events = append(*m.events, "start "+m.name)
+	return m.startErr
+}
+
+func (m lifecycleModule) Shutdown(_ context.Context) error {
+	*m.events = append(*m.events, "shutdown "+m.name)
+	return nil
+}
+
+type configUpdaterModule struct {
+	updates []string
+}
+
+func (m *configUpdaterModule) OnConfigUpdate(_ context.Context, accountID string, cfg json.RawMessage) error {
+	m.updates = append(m.updates, accountID+" "+string(cfg))
+	return nil
+}
+
+func TestManagerStartAndShutdown(t *testing.T) {
+	var events []string
+	manager := NewManager(map[string]interface{}{
+		"acme.a":   lifecycleModule{name: "a", events: &events},
+		"acme.b":   lifecycleModule{name: "b", events: &events},
+		"acme.foo": module{},
+	})
+
+	assert.NoError(t, manager.Start(context.Background()))
+	manager.Shutdown()
+
+	assert.Equal(t, []string{"start a", "start b", "shutdown a", "shutdown b"}, events)
+}
+
+func TestManagerStartFailure(t *testing.T) {
+	var events []string
+	manager := NewManager(map[string]interface{}{
+		"acme.a": lifecycleModule{name: "a", events: &events},
+		"acme.b": lifecycleModule{name: "b", events: &events, startErr: errors.New("data file not found")},
+		"acme.c": lifecycleModule{name: "c", events: &events},
+	})
+
+	err := manager.Start(context.Background())
+
+	assert.EqualError(t, err, `failed to start "acme.b" module: data file not found`)
+	assert.Equal(t, []string{"start a", "start b", "shutdown a"}, events, "Only the modules already started should be shut down.")
+}
+
+func TestManagerUpdateHostConfig(t *testing.T) {
+	updater := &configUpdaterModule{}
+	manager := NewManager(map[string]interface{}{"acme.updater": updater, "acme.foo": module{}})
+
+	assert.NoError(t, manager.UpdateHostConfig(context.Background(), "acme.updater", json.RawMessage(`{"refresh":60}`)))
+	assert.ErrorIs(t, manager.UpdateHostConfig(context.Background(), "acme.foo", json.RawMessage(`{}`)), ErrConfigUpdateNotSupported)
+	assert.ErrorIs(t, manager.UpdateHostConfig(context.Background(), "acme.bar", json.RawMessage(`{}`)), ErrUnknownModule)
+
+	assert.Equal(t, []string{` {"refresh":60}`}, updater.updates)
+}
+
+type fakeAccountFetcher struct {
+	accounts map[string]json.RawMessage
+}
+
+func (f *fakeAccountFetcher) FetchAccount(_ context.Context, _ json.RawMessage, accountID string) (json.RawMessage, []error) {
+	if account, ok := f.accounts[accountID]; ok {
+		return account, nil
+	}
+	return nil, []error{stored_requests.NotFoundError{ID: accountID, DataType: "Account"}}
+}
+
+func TestManagerWatchAccounts(t *testing.T) {
+	updater := &configUpdaterModule{}
+	manager := NewManager(map[string]interface{}{"acme.updater": updater})
+	fetcher := &fakeAccountFetcher{accounts: map[string]json.RawMessage{
+		"1001": json.RawMessage(`{"id":"1001","hooks":{"modules":{"acme":{"updater":{"refresh":60}}}}}`),
+	}}
+	watcher := manager.WatchAccounts(fetcher)
+
+	fetch := func(accountID string) {
+		watcher.FetchAccount(context.Background(), nil, accountID)
+	}
+
+	fetch("1001")
+	assert.Empty(t, updater.updates, "The first fetch of an account is not a change.")
+
+	fetcher.accounts["1001"] = json.RawMessage(`{"id":"1001","disabled":false,"hooks":{"modules":{"acme":{"updater":{"refresh":60}}}}}`)
+	fetch("1001")
+	assert.Empty(t, updater.updates, "Changes of the account outside of the module config should be ignored.")
+
+	fetcher.accounts["1001"] = json.RawMessage(`{"id":"1001","hooks":{"modules":{"acme":{"updater":{"refresh":30}}}}}`)
+	fetch("1001")
+	fetch("1001")
+	fetcher.accounts["1001"] = json.RawMessage(`{"id":"1001"}`)
+	fetch("1001")
+	fetch("unknown")
+
+	assert.Equal(t, []string{`1001 {"refresh":30}`, `1001 `}, updater.updates)
+}
+
+func TestManagerWatchAccountsEviction(t *testing.T) {
+	updater := &configUpdaterModule{}
+	manager := NewManager(map[string]interface{}{"acme.updater": updater})
+	manager.maxAccounts = 2
+	fetcher := &fakeAccountFetcher{accounts: map[string]json.RawMessage{
+		"1001": json.RawMessage(`{"hooks":{"modules":{"acme":{"updater":{"refresh":60}}}}}`),
+		"1002": json.RawMessage(`{"hooks":{"modules":{"acme":{"updater":{"refresh":60}}}}}`),
+		"1003": json.RawMessage(`{"hooks":{"modules":{"acme":{"updater":{"refresh":60}}}}}`),
+	}}
+	watcher := manager.WatchAccounts(fetcher)
+
+	fetch := func(accountID string) {
+		watcher.FetchAccount(context.Background(), nil, accountID)
+	}
+
+	fetch("1001")
+	fetch("1002")
+	fetch("1001")
+	fetch("1003")
+	assert.Len(t, manager.accounts, 2)
+	assert.NotContains(t, manager.accounts, "1002", "The least recently fetched account should be forgotten.")
+
+	fetcher.accounts["1001"] = json.RawMessage(`{"hooks":{"modules":{"acme":{"updater":{"refresh":30}}}}}`)
+	fetcher.accounts["1002"] = json.RawMessage(`{"hooks":{"modules":{"acme":{"updater":{"refresh":30}}}}}`)
+	fetch("1001")
+	fetch("1002")
+
+	assert.Equal(t, []string{`1001 {"refresh":30}`}, updater.updates, "A forgotten account is fetched again like a new one.")
+}
+
+func TestManagerWatchAccountsWithoutConfigUpdaters(t *testing.T) {
+	fetcher := &fakeAccountFetcher{}
+	manager := NewManager(map[string]interface{}{"acme.foo": module{}})
+
+	assert.Same(t, fetcher, manager.WatchAccounts(fetcher))
+}
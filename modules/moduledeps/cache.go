@@ -0,0 +1,53 @@
+package moduledeps
+
+import (
+	"time"
+
+	"github.com/coocood/freecache"
+)
+
+// Cache is an in-memory key/value cache evicting the least recently used entries once it is full.
+//
+// The zero value caches nothing, Get always misses and Set is a no-op.
+type Cache struct {
+	cache  *freecache.Cache
+	prefix string
+}
+
+// NewCache returns a cache holding up to sizeMB megabytes, shared by all the modules it is scoped to.
+// A size of 0 returns the zero value.
+func NewCache(sizeMB int) Cache {
+	if sizeMB <= 0 {
+		return Cache{}
+	}
+	return Cache{cache: freecache.NewCache(sizeMB * 1024 * 1024)}
+}
+
+func (c Cache) forModule(module string) Cache {
+	c.prefix = module + ":"
+	return c
+}
+
+// Get returns the value stored for the key and whether it was found.
+func (c Cache) Get(key string) ([]byte, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+	value, err := c.cache.Get([]byte(c.prefix + key))
+	return value, err == nil
+}
+
+// Set stores the value for the key. A ttl below one second keeps the entry until it is evicted.
+func (c Cache) Set(key string, value []byte, ttl time.Duration) error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.Set([]byte(c.prefix+key), value, int(ttl.Seconds()))
+}
+
+// Delete removes the value stored for the key.
+func (c Cache) Delete(key string) {
+	if c.cache != nil {
+		c.cache.Del([]byte(c.prefix + key))
+	}
+}
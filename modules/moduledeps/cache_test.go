@@ -0,0 +1,42 @@
+package moduledeps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	cache := NewCache(1)
+	foo := cache.forModule("foo")
+	bar := cache.forModule("bar")
+
+	assert.NoError(t, foo.Set("key", []byte("foo-value"), time.Minute))
+	assert.NoError(t, bar.Set("key", []byte("bar-value"), 0))
+
+	value, found := foo.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("foo-value"), value)
+
+	value, found = bar.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("bar-value"), value)
+
+	foo.Delete("key")
+	_, found = foo.Get("key")
+	assert.False(t, found, "Deleted key should not be found.")
+	_, found = bar.Get("key")
+	assert.True(t, found, "Key of another module should not be deleted.")
+}
+
+func TestCacheDisabled(t *testing.T) {
+	for name, cache := range map[string]Cache{"zero-value": {}, "zero-size": NewCache(0)} {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, cache.Set("key", []byte("value"), time.Minute))
+			_, found := cache.Get("key")
+			assert.False(t, found)
+			cache.Delete("key")
+		})
+	}
+}
@@ -4,11 +4,41 @@ import (
 	"net/http"
 
 	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/gdpr"
+	"github.com/prebid/prebid-server/v3/metrics"
+	pbc "github.com/prebid/prebid-server/v3/prebid_cache_client"
+	"github.com/prebid/prebid-server/v3/stored_requests"
 )
 
+// Version identifies the ModuleDeps API modules are built against. It is bumped when a dependency
+// is removed or changes its type, adding a dependency leaves it unchanged.
+const Version = 2
+
 // ModuleDeps provides dependencies that custom modules may need for hooks execution.
 // Additional dependencies can be added here if modules need something more.
+//
+// Any dependency may be nil when the host doesn't provide it, modules must check the ones they use.
 type ModuleDeps struct {
 	HTTPClient    *http.Client
 	RateConvertor *currency.RateConverter
+
+	// MetricsEngine is the engine recording the core metrics, modules record their own metrics with Metrics.
+	MetricsEngine metrics.MetricsEngine
+	// Metrics records the metrics a module defines for itself, named under the module's namespace.
+	Metrics Metrics
+
+	AccountFetcher       stored_requests.AccountFetcher
+	StoredRequestFetcher stored_requests.Fetcher
+	CacheClient          pbc.Client
+	GDPRPermsBuilder     gdpr.PermissionsBuilder
+
+	// Cache is a key/value cache shared by all modules, each module's keys are kept apart from the others.
+	Cache Cache
+}
+
+// ForModule returns the dependencies with the metrics and the cache scoped to the given module.
+func (d ModuleDeps) ForModule(module string) ModuleDeps {
+	d.Metrics = d.Metrics.forModule(module)
+	d.Cache = d.Cache.forModule(module)
+	return d
 }
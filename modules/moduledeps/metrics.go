@@ -0,0 +1,40 @@
+package moduledeps
+
+import (
+	"time"
+
+	"github.com/prebid/prebid-server/v3/metrics"
+)
+
+// Metrics records metrics defined by a single module. Metric names are prefixed with the module name
+// by the metrics backends, so modules only need names unique among their own metrics.
+//
+// The zero value records nothing.
+type Metrics struct {
+	engine metrics.ModuleMetricsEngine
+	module string
+}
+
+// NewMetrics returns metrics recorded into the given engine, they are scoped to a module by ModuleDeps.ForModule.
+func NewMetrics(engine metrics.ModuleMetricsEngine) Metrics {
+	return Metrics{engine: engine}
+}
+
+func (m Metrics) forModule(module string) Metrics {
+	m.module = module
+	return m
+}
+
+// IncCounter increments the counter with the given name.
+func (m Metrics) IncCounter(name string) {
+	if m.engine != nil && m.module != "" {
+		m.engine.RecordModuleCounter(m.module, name)
+	}
+}
+
+// RecordTime records a duration in the timer with the given name.
+func (m Metrics) RecordTime(name string, duration time.Duration) {
+	if m.engine != nil && m.module != "" {
+		m.engine.RecordModuleTime(m.module, name, duration)
+	}
+}
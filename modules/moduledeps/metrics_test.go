@@ -0,0 +1,32 @@
+package moduledeps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	engine := &fakeModuleMetricsEngine{}
+	metrics := NewMetrics(engine)
+
+	metrics.IncCounter("unscoped")
+	metrics.forModule("foo").IncCounter("hits")
+	metrics.forModule("foo").RecordTime("lookup", time.Second)
+	Metrics{}.IncCounter("no-engine")
+
+	assert.Equal(t, []string{"foo.hits", "foo.lookup"}, engine.recorded, "Only metrics scoped to a module should be recorded.")
+}
+
+type fakeModuleMetricsEngine struct {
+	recorded []string
+}
+
+func (e *fakeModuleMetricsEngine) RecordModuleCounter(module, name string) {
+	e.recorded = append(e.recorded, module+"."+name)
+}
+
+func (e *fakeModuleMetricsEngine) RecordModuleTime(module, name string, _ time.Duration) {
+	e.recorded = append(e.recorded, module+"."+name)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/hooks"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/modules/remote"
+	"github.com/prebid/prebid-server/v3/modules/wasm"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 )
 
@@ -22,10 +24,10 @@ func NewBuilder() Builder {
 // implementing hook interfaces [github.com/prebid/prebid-server/hooks/hookstage].
 type Builder interface {
 	// Build initializes existing hook modules passing them config and other dependencies.
-	// It returns hook repository created based on the implemented hook interfaces by modules
-	// and a map of modules to a list of stage names for which module provides hooks
-	// or an error encountered during module initialization.
-	Build(cfg config.Modules, client moduledeps.ModuleDeps) (hooks.HookRepository, map[string][]string, error)
+	// It returns hook repository created based on the implemented hook interfaces by modules,
+	// a map of modules to a list of stage names for which module provides hooks
+	// and the manager of the modules lifecycle or an error encountered during module initialization.
+	Build(cfg config.Modules, client moduledeps.ModuleDeps) (hooks.HookRepository, map[string][]string, *Manager, error)
 }
 
 type (
@@ -35,6 +37,19 @@ type (
 	ModuleBuilderFn func(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error)
 )
 
+// externalModuleBuilderFn builds a module which isn't compiled into the server from its config.
+type externalModuleBuilderFn func(id string, cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error)
+
+// externalBuilders maps the config object of the modules which aren't compiled into the server to their builder.
+var externalBuilders = map[string]externalModuleBuilderFn{
+	"remote": func(id string, cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
+		return remote.NewModule(id, cfg, deps)
+	},
+	"wasm": func(id string, cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
+		return wasm.NewModule(id, cfg, deps)
+	},
+}
+
 type builder struct {
 	builders ModuleBuilders
 }
@@ -44,12 +59,19 @@ type builder struct {
 // The ID chosen for the module's hooks represents a fully qualified module path in the format
 // "vendor.module_name" and should be used to retrieve module hooks from the hooks.HookRepository.
 //
-// Method returns a hooks.HookRepository and a map of modules to a list of stage names
-// for which module provides hooks or an error occurred during modules initialization.
+// Modules without a compiled package are built as remote modules when their config has a "remote" object,
+// see [remote.Module], and as WebAssembly modules when it has a "wasm" object, see [wasm.Module].
+//
+// Every module gets the dependencies scoped to it, so that the metrics it records and the keys it caches
+// can't clash with those of another module.
+//
+// Method returns a hooks.HookRepository, a map of modules to a list of stage names
+// for which module provides hooks and a Manager of the modules lifecycle
+// or an error occurred during modules initialization.
 func (m *builder) Build(
 	cfg config.Modules,
 	deps moduledeps.ModuleDeps,
-) (hooks.HookRepository, map[string][]string, error) {
+) (hooks.HookRepository, map[string][]string, *Manager, error) {
 	modules := make(map[string]interface{})
 	for vendor, moduleBuilders := range m.builders {
 		for moduleName, builder := range moduleBuilders {
@@ -60,14 +82,9 @@ func (m *builder) Build(
 			id := fmt.Sprintf("%s.%s", vendor, moduleName)
 			if data, ok := cfg[vendor][moduleName]; ok {
 				if conf, err = jsonutil.Marshal(data); err != nil {
-					return nil, nil, fmt.Errorf(`failed to marshal "%s" module config: %s`, id, err)
-				}
-
-				if values, ok := data.(map[string]interface{}); ok {
-					if value, ok := values["enabled"].(bool); ok {
-						isEnabled = value
-					}
+					return nil, nil, nil, fmt.Errorf(`failed to marshal "%s" module config: %s`, id, err)
 				}
+				isEnabled = isModuleEnabled(data)
 			}
 
 			if !isEnabled {
@@ -75,9 +92,41 @@ func (m *builder) Build(
 				continue
 			}
 
-			module, err := builder(conf, deps)
+			// metrics and cache keys of the module are scoped the same way as its stage metrics
+			module, err := builder(conf, deps.ForModule(moduleReplacer.Replace(id)))
 			if err != nil {
-				return nil, nil, fmt.Errorf(`failed to init "%s" module: %s`, id, err)
+				return nil, nil, nil, fmt.Errorf(`failed to init "%s" module: %s`, id, err)
+			}
+
+			modules[id] = module
+		}
+	}
+
+	// modules configured with a remote service or a WebAssembly binary instead of a compiled package
+	for vendor, moduleConfigs := range cfg {
+		for moduleName, data := range moduleConfigs {
+			if _, ok := m.builders[vendor][moduleName]; ok {
+				continue
+			}
+			kind := externalModuleKind(data)
+			if kind == "" {
+				continue
+			}
+
+			id := fmt.Sprintf("%s.%s", vendor, moduleName)
+			if !isModuleEnabled(data) {
+				glog.Infof("Skip %s %s module, disabled.", id, kind)
+				continue
+			}
+
+			conf, err := jsonutil.Marshal(data)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf(`failed to marshal "%s" module config: %s`, id, err)
+			}
+
+			module, err := externalBuilders[kind](id, conf, deps.ForModule(moduleReplacer.Replace(id)))
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf(`failed to init "%s" %s module: %s`, id, kind, err)
 			}
 
 			modules[id] = module
@@ -86,10 +135,13 @@ func (m *builder) Build(
 
 	collection, err := createModuleStageNamesCollection(modules)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	repo, err := hooks.NewHookRepository(modules)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	return repo, collection, err
+	return repo, collection, NewManager(modules), nil
 }
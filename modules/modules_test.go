@@ -8,6 +8,7 @@ import (
 	"math"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/hooks"
@@ -101,7 +102,7 @@ func TestModuleBuilderBuild(t *testing.T) {
 				},
 			}
 
-			repo, modulesStages, err := builder.Build(test.givenConfig, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient})
+			repo, modulesStages, _, err := builder.Build(test.givenConfig, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient})
 			assert.Equal(t, test.expectedErr, err)
 			assert.Equal(t, test.expectedModulesStages, modulesStages)
 			assert.Equal(t, test.expectedHookRepo, repo)
@@ -109,6 +110,127 @@ func TestModuleBuilderBuild(t *testing.T) {
 	}
 }
 
+func TestModuleBuilderBuildScopesDeps(t *testing.T) {
+	engine := &fakeModuleMetricsEngine{}
+	deps := moduledeps.ModuleDeps{
+		HTTPClient: http.DefaultClient,
+		Metrics:    moduledeps.NewMetrics(engine),
+		Cache:      moduledeps.NewCache(1),
+	}
+
+	buildModule := func(value string) ModuleBuilderFn {
+		return func(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
+			deps.Metrics.IncCounter("builds")
+			return module{}, deps.Cache.Set("key", []byte(value), 0)
+		}
+	}
+	builder := &builder{
+		builders: ModuleBuilders{
+			"acme":  {"foo-bar": buildModule("foo-bar")},
+			"other": {"baz": buildModule("baz")},
+		},
+	}
+	cfg := map[string]map[string]interface{}{
+		"acme":  {"foo-bar": map[string]interface{}{"enabled": true}},
+		"other": {"baz": map[string]interface{}{"enabled": true}},
+	}
+
+	_, _, _, err := builder.Build(cfg, deps)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"acme_foo_bar", "other_baz"}, engine.counted, "Module metrics should be scoped to the module.")
+
+	value, found := deps.ForModule("acme_foo_bar").Cache.Get("key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("foo-bar"), value, "Modules should not share cache keys.")
+}
+
+func TestModuleBuilderBuildExternalModule(t *testing.T) {
+	remoteConfig := func(enabled bool, endpoint string) map[string]interface{} {
+		return map[string]interface{}{"enabled": enabled, "remote": map[string]interface{}{"endpoint": endpoint}}
+	}
+
+	testCases := map[string]struct {
+		givenConfig           config.Modules
+		expectedModulesStages map[string][]string
+		expectedErr           error
+	}{
+		"Remote module is built from config": {
+			givenConfig: map[string]map[string]interface{}{"acme": {"remote": remoteConfig(true, "http://localhost/hooks")}},
+			expectedModulesStages: map[string][]string{"acme_remote": {
+				hooks.StageEntrypoint.String(),
+				hooks.StageRawAuctionRequest.String(),
+				hooks.StageProcessedAuctionRequest.String(),
+				hooks.StageBidderRequest.String(),
+				hooks.StageAuctionResponse.String(),
+				hooks.StageExitpoint.String(),
+			}},
+		},
+		"WebAssembly module is built from config": {
+			givenConfig: map[string]map[string]interface{}{"acme": {"sandboxed": map[string]interface{}{
+				"enabled": true,
+				"wasm":    map[string]interface{}{"path": "wasm/testdata/guest.wasm"},
+			}}},
+			expectedModulesStages: map[string][]string{"acme_sandboxed": {
+				hooks.StageRawAuctionRequest.String(),
+				hooks.StageBidderRequest.String(),
+				hooks.StageRawBidderResponse.String(),
+				hooks.StageAuctionResponse.String(),
+			}},
+		},
+		"Fails if WebAssembly module config is invalid": {
+			givenConfig: map[string]map[string]interface{}{"acme": {"sandboxed": map[string]interface{}{"enabled": true, "wasm": map[string]interface{}{}}}},
+			expectedErr: errors.New(`failed to init "acme.sandboxed" wasm module: wasm.path is required`),
+		},
+		"Remote module is skipped if it's disabled": {
+			givenConfig:           map[string]map[string]interface{}{"acme": {"remote": remoteConfig(false, "http://localhost/hooks")}},
+			expectedModulesStages: map[string][]string{},
+		},
+		"Compiled module is built even if its config has remote object": {
+			givenConfig:           map[string]map[string]interface{}{"acme": {"foobar": remoteConfig(true, "http://localhost/hooks")}},
+			expectedModulesStages: map[string][]string{"acme_foobar": {hooks.StageEntrypoint.String(), hooks.StageAuctionResponse.String()}},
+		},
+		"Unknown module without remote object is ignored": {
+			givenConfig:           map[string]map[string]interface{}{"acme": {"other": map[string]interface{}{"enabled": true}}},
+			expectedModulesStages: map[string][]string{},
+		},
+		"Fails if remote module config is invalid": {
+			givenConfig: map[string]map[string]interface{}{"acme": {"remote": remoteConfig(true, "")}},
+			expectedErr: errors.New(`failed to init "acme.remote" remote module: remote.endpoint is required`),
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			builder := &builder{
+				builders: ModuleBuilders{
+					"acme": {
+						"foobar": func(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
+							return module{}, nil
+						},
+					},
+				},
+			}
+
+			_, modulesStages, _, err := builder.Build(test.givenConfig, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient})
+			assert.Equal(t, test.expectedErr, err)
+			if test.expectedErr == nil {
+				assert.Equal(t, test.expectedModulesStages, modulesStages)
+			}
+		})
+	}
+}
+
+type fakeModuleMetricsEngine struct {
+	counted []string
+}
+
+func (e *fakeModuleMetricsEngine) RecordModuleCounter(module, _ string) {
+	e.counted = append(e.counted, module)
+}
+
+func (e *fakeModuleMetricsEngine) RecordModuleTime(_, _ string, _ time.Duration) {}
+
 type module struct{}
 
 func (h module) HandleEntrypointHook(_ context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
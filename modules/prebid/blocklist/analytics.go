@@ -0,0 +1,45 @@
+package blocklist
+
+import (
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+)
+
+const enforceBlocklistsTag = "enforce_blocklists"
+
+const (
+	cridAnalyticKey    = "crid"
+	adomainAnalyticKey = "adomain"
+)
+
+// blocklist module has only 1 activity: `enforce_blocklists`, with a result for every blocked bid
+func newEnforceBlocklistsTags(results []hookanalytics.Result) hookanalytics.Analytics {
+	return hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:    enforceBlocklistsTag,
+				Status:  hookanalytics.ActivityStatusSuccess,
+				Results: results,
+			},
+		},
+	}
+}
+
+func newBlockedResult(bidder, bidID, impID, blockedCRID string, blockedADomains []string) hookanalytics.Result {
+	values := make(map[string]interface{})
+	if blockedCRID != "" {
+		values[cridAnalyticKey] = blockedCRID
+	}
+	if len(blockedADomains) > 0 {
+		values[adomainAnalyticKey] = blockedADomains
+	}
+
+	return hookanalytics.Result{
+		Status: hookanalytics.ResultStatusBlock,
+		Values: values,
+		AppliedTo: hookanalytics.AppliedTo{
+			Bidder: bidder,
+			BidIds: []string{bidID},
+			ImpIds: []string{impID},
+		},
+	}
+}
@@ -0,0 +1,168 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// document is the format of the blocklists source.
+type document struct {
+	ADomains       []string                    `json:"adomains"`
+	CRIDs          []string                    `json:"crids"`
+	DealExceptions map[string]exceptionEntries `json:"deal_exceptions"`
+}
+
+// exceptionEntries are the advertiser domains and creative IDs a deal is allowed to bid with, even if blocked.
+type exceptionEntries struct {
+	ADomains []string `json:"adomains"`
+	CRIDs    []string `json:"crids"`
+}
+
+type blocklists struct {
+	adomains       domainSet
+	crids          stringSet
+	dealExceptions map[string]exceptions
+}
+
+type exceptions struct {
+	adomains domainSet
+	crids    stringSet
+}
+
+func newBlocklists(data json.RawMessage) (*blocklists, error) {
+	var doc document
+	if err := jsonutil.UnmarshalValid(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse blocklists: %s", err)
+	}
+
+	lists := &blocklists{
+		adomains:       newDomainSet(doc.ADomains),
+		crids:          newStringSet(doc.CRIDs),
+		dealExceptions: newDealExceptions(doc.DealExceptions),
+	}
+	return lists, nil
+}
+
+func newDealExceptions(entries map[string]exceptionEntries) map[string]exceptions {
+	dealExceptions := make(map[string]exceptions, len(entries))
+	for dealID, e := range entries {
+		dealExceptions[dealID] = newExceptions(e)
+	}
+	return dealExceptions
+}
+
+func newExceptions(entries exceptionEntries) exceptions {
+	return exceptions{
+		adomains: newDomainSet(entries.ADomains),
+		crids:    newStringSet(entries.CRIDs),
+	}
+}
+
+// blockedEntries returns the blocked creative ID and advertiser domains of a bid, skipping
+// the ones allowed for its deal by the blocklists or by the account.
+func (l *blocklists) blockedEntries(crid string, adomains []string, dealID string, accountExceptions map[string]exceptions) (string, []string) {
+	var allowed []exceptions
+	if dealID != "" {
+		if e, ok := l.dealExceptions[dealID]; ok {
+			allowed = append(allowed, e)
+		}
+		if e, ok := accountExceptions[dealID]; ok {
+			allowed = append(allowed, e)
+		}
+	}
+
+	var blockedCRID string
+	if crid != "" && l.crids.contains(crid) && !allowsCRID(allowed, crid) {
+		blockedCRID = crid
+	}
+
+	var blockedADomains []string
+	for _, adomain := range adomains {
+		if l.adomains.contains(adomain) && !allowsADomain(allowed, adomain) {
+			blockedADomains = append(blockedADomains, adomain)
+		}
+	}
+
+	return blockedCRID, blockedADomains
+}
+
+func allowsCRID(allowed []exceptions, crid string) bool {
+	for _, e := range allowed {
+		if e.crids.contains(crid) {
+			return true
+		}
+	}
+	return false
+}
+
+func allowsADomain(allowed []exceptions, adomain string) bool {
+	for _, e := range allowed {
+		if e.adomains.contains(adomain) {
+			return true
+		}
+	}
+	return false
+}
+
+type stringSet map[string]struct{}
+
+func newStringSet(values []string) stringSet {
+	set := make(stringSet, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
+
+func (s stringSet) contains(value string) bool {
+	_, ok := s[value]
+	return ok
+}
+
+// domainSet matches domains and their subdomains, a lookup costs one map access per label of the domain.
+type domainSet map[string]struct{}
+
+func newDomainSet(domains []string) domainSet {
+	set := make(domainSet, len(domains))
+	for _, domain := range domains {
+		if domain = normalizeDomain(domain); domain != "" {
+			set[domain] = struct{}{}
+		}
+	}
+	return set
+}
+
+func (s domainSet) contains(domain string) bool {
+	if len(s) == 0 {
+		return false
+	}
+
+	domain = normalizeDomain(domain)
+	for domain != "" {
+		if _, ok := s[domain]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(domain, ".")
+		if !found {
+			return false
+		}
+		domain = parent
+	}
+	return false
+}
+
+// normalizeDomain lowercases the domain, dropping a scheme, a path and a leading wildcard.
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if _, host, found := strings.Cut(domain, "://"); found {
+		domain = host
+	}
+	if host, _, found := strings.Cut(domain, "/"); found {
+		domain = host
+	}
+	domain = strings.TrimPrefix(domain, "*")
+	return strings.Trim(domain, ".")
+}
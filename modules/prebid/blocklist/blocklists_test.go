@@ -0,0 +1,99 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainSetContains(t *testing.T) {
+	set := newDomainSet([]string{"Evil.com", "*.ads.example.org", "https://tracker.net/path", " "})
+
+	testCases := map[string]bool{
+		"evil.com":             true,
+		"EVIL.COM":             true,
+		"cdn.evil.com":         true,
+		"a.b.evil.com":         true,
+		"notevil.com":          false,
+		"evil.com.org":         false,
+		"ads.example.org":      true,
+		"x.ads.example.org":    true,
+		"example.org":          false,
+		"http://tracker.net/a": true,
+		"tracker.net.":         true,
+		"com":                  false,
+		"":                     false,
+	}
+	for domain, expected := range testCases {
+		assert.Equal(t, expected, set.contains(domain), domain)
+	}
+	assert.Len(t, set, 3, "Blank domains should be skipped.")
+}
+
+func TestNewBlocklists(t *testing.T) {
+	_, err := newBlocklists(json.RawMessage(`{"adomains":`))
+	assert.ErrorContains(t, err, "failed to parse blocklists")
+
+	lists, err := newBlocklists(json.RawMessage(`{"adomains":["evil.com"],"crids":["crid-1"],"deal_exceptions":{"deal-1":{"crids":["crid-1"]}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, domainSet{"evil.com": {}}, lists.adomains)
+	assert.Equal(t, stringSet{"crid-1": {}}, lists.crids)
+	assert.Equal(t, map[string]exceptions{"deal-1": {adomains: domainSet{}, crids: stringSet{"crid-1": {}}}}, lists.dealExceptions)
+}
+
+func TestBlockedEntries(t *testing.T) {
+	lists, err := newBlocklists(json.RawMessage(`{
+		"adomains": ["evil.com", "bad.net"],
+		"crids": ["crid-1", "crid-2"],
+		"deal_exceptions": {"deal-1": {"adomains": ["cdn.evil.com"], "crids": ["crid-1"]}}
+	}`))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		givenCRID              string
+		givenADomains          []string
+		givenDealID            string
+		givenAccountExceptions map[string]exceptionEntries
+		expectedCRID           string
+		expectedADomains       []string
+	}{
+		"Not blocked": {
+			givenCRID:     "crid-3",
+			givenADomains: []string{"good.com"},
+		},
+		"Blocked crid and adomains": {
+			givenCRID:        "crid-1",
+			givenADomains:    []string{"good.com", "ads.evil.com", "bad.net"},
+			expectedCRID:     "crid-1",
+			expectedADomains: []string{"ads.evil.com", "bad.net"},
+		},
+		"Deal exceptions of the blocklists": {
+			givenCRID:        "crid-1",
+			givenADomains:    []string{"x.cdn.evil.com", "evil.com"},
+			givenDealID:      "deal-1",
+			expectedADomains: []string{"evil.com"},
+		},
+		"Deal exceptions of the account": {
+			givenCRID:              "crid-2",
+			givenADomains:          []string{"bad.net"},
+			givenDealID:            "deal-2",
+			givenAccountExceptions: map[string]exceptionEntries{"deal-2": {ADomains: []string{"bad.net"}, CRIDs: []string{"crid-2"}}},
+		},
+		"Deal exceptions of other deal": {
+			givenCRID:              "crid-2",
+			givenDealID:            "deal-1",
+			givenAccountExceptions: map[string]exceptionEntries{"deal-2": {CRIDs: []string{"crid-2"}}},
+			expectedCRID:           "crid-2",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			crid, adomains := lists.blockedEntries(test.givenCRID, test.givenADomains, test.givenDealID, newDealExceptions(test.givenAccountExceptions))
+			assert.Equal(t, test.expectedCRID, crid)
+			assert.Equal(t, test.expectedADomains, adomains)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const defaultRefreshInterval = 5 * time.Minute
+
+// config is the host config of the module, it sets where the blocklists are loaded from.
+type config struct {
+	Source source `json:"source"`
+}
+
+type source struct {
+	// File is the path of a file holding the blocklists.
+	File string `json:"file"`
+	// StoredRequestID is the ID of a stored request holding the blocklists, fetched with the stored requests fetcher.
+	StoredRequestID string `json:"stored_request_id"`
+	// RefreshIntervalSeconds is how often the blocklists are reloaded, the file is only reloaded when it changed.
+	RefreshIntervalSeconds *int `json:"refresh_interval_seconds"`
+}
+
+func newConfig(data json.RawMessage) (config, error) {
+	var cfg config
+	if err := jsonutil.UnmarshalValid(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config: %s", err)
+	}
+
+	if cfg.Source.File == "" && cfg.Source.StoredRequestID == "" {
+		return cfg, errors.New("source.file or source.stored_request_id is required")
+	}
+	if cfg.Source.File != "" && cfg.Source.StoredRequestID != "" {
+		return cfg, errors.New("only one of source.file or source.stored_request_id can be set")
+	}
+	if cfg.Source.RefreshIntervalSeconds != nil && *cfg.Source.RefreshIntervalSeconds < 0 {
+		return cfg, fmt.Errorf("source.refresh_interval_seconds must be >= 0. Got %d", *cfg.Source.RefreshIntervalSeconds)
+	}
+
+	return cfg, nil
+}
+
+// refreshInterval returns 0 when the blocklists are only loaded at startup.
+func (s source) refreshInterval() time.Duration {
+	if s.RefreshIntervalSeconds == nil {
+		return defaultRefreshInterval
+	}
+	return time.Duration(*s.RefreshIntervalSeconds) * time.Second
+}
+
+// accountConfig holds the deal exceptions of an account, added to the ones of the blocklists.
+type accountConfig struct {
+	DealExceptions map[string]exceptionEntries `json:"deal_exceptions"`
+}
+
+func newAccountConfig(data json.RawMessage) (accountConfig, error) {
+	var cfg accountConfig
+	if len(data) == 0 {
+		return cfg, nil
+	}
+	if err := jsonutil.UnmarshalValid(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse account config: %s", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,68 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig             string
+		expectedRefreshInterval time.Duration
+		expectedErr             string
+	}{
+		"File source with default refresh interval": {
+			givenConfig:             `{"enabled":true,"source":{"file":"/tmp/blocklists.json"}}`,
+			expectedRefreshInterval: defaultRefreshInterval,
+		},
+		"Stored request source without refresh": {
+			givenConfig:             `{"source":{"stored_request_id":"blocklists","refresh_interval_seconds":0}}`,
+			expectedRefreshInterval: 0,
+		},
+		"Malformed config": {
+			givenConfig: `{"source":`,
+			expectedErr: "failed to parse config",
+		},
+		"No source": {
+			givenConfig: `{"source":{}}`,
+			expectedErr: "source.file or source.stored_request_id is required",
+		},
+		"Both sources": {
+			givenConfig: `{"source":{"file":"/tmp/blocklists.json","stored_request_id":"blocklists"}}`,
+			expectedErr: "only one of source.file or source.stored_request_id can be set",
+		},
+		"Negative refresh interval": {
+			givenConfig: `{"source":{"file":"/tmp/blocklists.json","refresh_interval_seconds":-1}}`,
+			expectedErr: "source.refresh_interval_seconds must be >= 0. Got -1",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := newConfig(json.RawMessage(test.givenConfig))
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRefreshInterval, cfg.Source.refreshInterval())
+		})
+	}
+}
+
+func TestNewAccountConfig(t *testing.T) {
+	cfg, err := newAccountConfig(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.DealExceptions)
+
+	cfg, err = newAccountConfig(json.RawMessage(`{"deal_exceptions":{"deal-1":{"adomains":["evil.com"]}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]exceptionEntries{"deal-1": {ADomains: []string{"evil.com"}}}, cfg.DealExceptions)
+
+	_, err = newAccountConfig(json.RawMessage(`{"deal_exceptions":[]}`))
+	assert.ErrorContains(t, err, "failed to parse account config")
+}
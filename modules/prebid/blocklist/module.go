@@ -0,0 +1,221 @@
+package blocklist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/exchange"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/lruutil"
+	"github.com/prebid/prebid-server/v3/util/task"
+)
+
+const loadTimeout = 30 * time.Second
+
+// maxCachedAccountConfigs bounds the parsed account configs kept by the module,
+// the least recently used ones are parsed again when they're needed.
+const maxCachedAccountConfigs = 10000
+
+const (
+	reloadsMetric      = "reloads"
+	reloadErrorsMetric = "reload_errors"
+	blockedBidsMetric  = "blocked_bids"
+)
+
+func Builder(data json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
+	cfg, err := newConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Module{
+		cfg:               cfg,
+		metrics:           deps.Metrics,
+		accountExceptions: lruutil.New[string, map[string]exceptions](maxCachedAccountConfigs),
+	}
+	if cfg.Source.File != "" {
+		m.loader = &fileLoader{path: cfg.Source.File}
+	} else {
+		if deps.StoredRequestFetcher == nil {
+			return nil, errors.New("source.stored_request_id requires the stored requests fetcher")
+		}
+		m.loader = &storedRequestLoader{fetcher: deps.StoredRequestFetcher, id: cfg.Source.StoredRequestID}
+	}
+
+	return m, nil
+}
+
+// Module rejects the bids whose creative ID or advertiser domains are in the blocklists,
+// which are loaded from a file or a stored request and reloaded periodically.
+type Module struct {
+	cfg     config
+	loader  loader
+	lists   atomic.Pointer[blocklists]
+	metrics moduledeps.Metrics
+	task    *task.TickerTask
+
+	// accountExceptions are the deal exceptions parsed from the account configs, keyed by the account config
+	accountExceptions *lruutil.Cache[string, map[string]exceptions]
+}
+
+// Start loads the blocklists, failing if they can't be loaded, and starts reloading them.
+func (m *Module) Start(ctx context.Context) error {
+	if err := m.load(ctx); err != nil {
+		return fmt.Errorf("failed to load blocklists: %s", err)
+	}
+
+	if interval := m.cfg.Source.refreshInterval(); interval > 0 {
+		m.task = task.NewTickerTaskFromFunc(interval, m.reload)
+		m.task.Start()
+	}
+	return nil
+}
+
+// Shutdown stops reloading the blocklists.
+func (m *Module) Shutdown(_ context.Context) error {
+	if m.task != nil {
+		m.task.Stop()
+	}
+	return nil
+}
+
+func (m *Module) load(ctx context.Context) error {
+	lists, err := m.loader.load(ctx)
+	if err != nil {
+		return err
+	}
+	if lists != nil {
+		m.lists.Store(lists)
+		m.metrics.IncCounter(reloadsMetric)
+	}
+	return nil
+}
+
+// reload keeps the current blocklists when the new ones can't be loaded.
+func (m *Module) reload() error {
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+
+	if err := m.load(ctx); err != nil {
+		m.metrics.IncCounter(reloadErrorsMetric)
+		glog.Errorf("Failed to reload blocklists, keeping the current ones: %v", err)
+		return err
+	}
+	return nil
+}
+
+// HandleRawBidderResponseHook rejects the blocked bids, reporting them as seat non bids.
+func (m *Module) HandleRawBidderResponseHook(
+	_ context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawBidderResponsePayload,
+) (hookstage.HookResult[hookstage.RawBidderResponsePayload], error) {
+	result := hookstage.HookResult[hookstage.RawBidderResponsePayload]{}
+	lists := m.lists.Load()
+	if lists == nil || payload.BidderResponse == nil {
+		return result, nil
+	}
+
+	accountExceptions, err := m.dealExceptions(miCtx.AccountConfig)
+	if err != nil {
+		return result, err
+	}
+
+	var results []hookanalytics.Result
+	nonBids := make(map[string][]openrtb_ext.NonBid)
+	allowedBids := make([]*adapters.TypedBid, 0, len(payload.BidderResponse.Bids))
+	for _, bid := range payload.BidderResponse.Bids {
+		if bid == nil || bid.Bid == nil {
+			continue
+		}
+
+		blockedCRID, blockedADomains := lists.blockedEntries(bid.Bid.CrID, bid.Bid.ADomain, bid.Bid.DealID, accountExceptions)
+		if blockedCRID == "" && len(blockedADomains) == 0 {
+			allowedBids = append(allowedBids, bid)
+			continue
+		}
+
+		seat := payload.Bidder
+		if bid.Seat != "" {
+			seat = bid.Seat.String()
+		}
+		nonBids[seat] = append(nonBids[seat], newNonBid(bid))
+		results = append(results, newBlockedResult(payload.Bidder, bid.Bid.ID, bid.Bid.ImpID, blockedCRID, blockedADomains))
+		result.DebugMessages = append(result.DebugMessages, blockedMessage(bid, payload.Bidder, blockedCRID, blockedADomains))
+		m.metrics.IncCounter(blockedBidsMetric)
+	}
+
+	if len(results) == 0 {
+		return result, nil
+	}
+
+	result.AnalyticsTags = newEnforceBlocklistsTags(results)
+	for _, seat := range slices.Sorted(maps.Keys(nonBids)) {
+		result.SeatNonBid = append(result.SeatNonBid, openrtb_ext.SeatNonBid{Seat: seat, NonBid: nonBids[seat]})
+	}
+	result.ChangeSet.RawBidderResponse().Bids().UpdateBids(allowedBids)
+
+	return result, nil
+}
+
+// dealExceptions returns the deal exceptions of the account config, which is only parsed
+// when it isn't cached.
+func (m *Module) dealExceptions(accountConfig json.RawMessage) (map[string]exceptions, error) {
+	if len(accountConfig) == 0 {
+		return nil, nil
+	}
+	if e, ok := m.accountExceptions.Get(string(accountConfig)); ok {
+		return e, nil
+	}
+
+	cfg, err := newAccountConfig(accountConfig)
+	if err != nil {
+		return nil, err
+	}
+	e := newDealExceptions(cfg.DealExceptions)
+	m.accountExceptions.Add(string(accountConfig), e)
+	return e, nil
+}
+
+func newNonBid(bid *adapters.TypedBid) openrtb_ext.NonBid {
+	return openrtb_ext.NonBid{
+		ImpId:      bid.Bid.ImpID,
+		StatusCode: int(exchange.ResponseRejectedGeneral),
+		Ext: &openrtb_ext.NonBidExt{
+			Prebid: openrtb_ext.ExtResponseNonBidPrebid{Bid: openrtb_ext.NonBidObject{
+				Price:   bid.Bid.Price,
+				ADomain: bid.Bid.ADomain,
+				CatTax:  bid.Bid.CatTax,
+				Cat:     bid.Bid.Cat,
+				DealID:  bid.Bid.DealID,
+				W:       bid.Bid.W,
+				H:       bid.Bid.H,
+				Dur:     bid.Bid.Dur,
+				MType:   bid.Bid.MType,
+			}},
+		},
+	}
+}
+
+func blockedMessage(bid *adapters.TypedBid, bidder, blockedCRID string, blockedADomains []string) string {
+	var blocked []string
+	if blockedCRID != "" {
+		blocked = append(blocked, "crid "+blockedCRID)
+	}
+	if len(blockedADomains) > 0 {
+		blocked = append(blocked, "adomain "+strings.Join(blockedADomains, ", "))
+	}
+	return fmt.Sprintf("Bid %s from bidder %s has been rejected, blocked %s", bid.Bid.ID, bidder, strings.Join(blocked, " and "))
+}
@@ -0,0 +1,199 @@
+package blocklist
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/exchange"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/lruutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig string
+		givenDeps   moduledeps.ModuleDeps
+		expectedErr string
+	}{
+		"File source": {
+			givenConfig: `{"source":{"file":"/tmp/blocklists.json"}}`,
+		},
+		"Stored request source": {
+			givenConfig: `{"source":{"stored_request_id":"blocklists"}}`,
+			givenDeps:   moduledeps.ModuleDeps{StoredRequestFetcher: mockFetcher{}},
+		},
+		"Stored request source without fetcher": {
+			givenConfig: `{"source":{"stored_request_id":"blocklists"}}`,
+			expectedErr: "source.stored_request_id requires the stored requests fetcher",
+		},
+		"Invalid config": {
+			givenConfig: `{"source":{}}`,
+			expectedErr: "source.file or source.stored_request_id is required",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			module, err := Builder(json.RawMessage(test.givenConfig), test.givenDeps)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.IsType(t, &Module{}, module)
+		})
+	}
+}
+
+func TestModuleStartReloadsBlocklists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklists.json")
+	module, err := Builder(json.RawMessage(`{"source":{"file":"`+path+`","refresh_interval_seconds":1}}`), moduledeps.ModuleDeps{})
+	require.NoError(t, err)
+	m := module.(*Module)
+
+	assert.ErrorContains(t, m.Start(context.Background()), "failed to load blocklists")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"crids":["crid-1"]}`), 0644))
+	require.NoError(t, m.Start(context.Background()))
+	defer m.Shutdown(context.Background())
+	assert.Equal(t, stringSet{"crid-1": {}}, m.lists.Load().crids)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"crids":["crid-2"]}`), 0644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	assert.Eventually(t, func() bool {
+		return m.lists.Load().crids.contains("crid-2")
+	}, 3*time.Second, 50*time.Millisecond, "Changed file should be reloaded.")
+}
+
+func TestReloadKeepsBlocklistsOnError(t *testing.T) {
+	m := &Module{loader: &storedRequestLoader{fetcher: mockFetcher{}, id: "blocklists"}}
+	lists := &blocklists{crids: stringSet{"crid-1": {}}}
+	m.lists.Store(lists)
+
+	assert.EqualError(t, m.reload(), "stored request blocklists not found")
+	assert.Same(t, lists, m.lists.Load())
+}
+
+func TestHandleRawBidderResponseHook(t *testing.T) {
+	allowedBid := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", CrID: "crid-3", ADomain: []string{"good.com"}}}
+	blockedCRIDBid := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "imp-1", CrID: "crid-1", Price: 1.5, W: 300, H: 250}}
+	blockedADomainBid := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-3", ImpID: "imp-2", ADomain: []string{"ads.evil.com"}, DealID: "deal-1"}, Seat: "altseat"}
+
+	lists, err := newBlocklists(json.RawMessage(`{"adomains":["evil.com"],"crids":["crid-1"]}`))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		givenAccountConfig string
+		expectedBids       []*adapters.TypedBid
+		expectedSeatNonBid []openrtb_ext.SeatNonBid
+		expectedResults    []hookanalytics.Result
+		expectedMessages   []string
+	}{
+		"Blocked bids are rejected": {
+			expectedBids: []*adapters.TypedBid{allowedBid},
+			expectedSeatNonBid: []openrtb_ext.SeatNonBid{
+				{Seat: "altseat", NonBid: []openrtb_ext.NonBid{{ImpId: "imp-2", StatusCode: int(exchange.ResponseRejectedGeneral), Ext: &openrtb_ext.NonBidExt{Prebid: openrtb_ext.ExtResponseNonBidPrebid{Bid: openrtb_ext.NonBidObject{ADomain: []string{"ads.evil.com"}, DealID: "deal-1"}}}}}},
+				{Seat: "appnexus", NonBid: []openrtb_ext.NonBid{{ImpId: "imp-1", StatusCode: int(exchange.ResponseRejectedGeneral), Ext: &openrtb_ext.NonBidExt{Prebid: openrtb_ext.ExtResponseNonBidPrebid{Bid: openrtb_ext.NonBidObject{Price: 1.5, W: 300, H: 250}}}}}},
+			},
+			expectedResults: []hookanalytics.Result{
+				newBlockedResult("appnexus", "bid-2", "imp-1", "crid-1", nil),
+				newBlockedResult("appnexus", "bid-3", "imp-2", "", []string{"ads.evil.com"}),
+			},
+			expectedMessages: []string{
+				"Bid bid-2 from bidder appnexus has been rejected, blocked crid crid-1",
+				"Bid bid-3 from bidder appnexus has been rejected, blocked adomain ads.evil.com",
+			},
+		},
+		"Deal exceptions of the account": {
+			givenAccountConfig: `{"deal_exceptions":{"deal-1":{"adomains":["evil.com"]}}}`,
+			expectedBids:       []*adapters.TypedBid{allowedBid, blockedADomainBid},
+			expectedSeatNonBid: []openrtb_ext.SeatNonBid{
+				{Seat: "appnexus", NonBid: []openrtb_ext.NonBid{{ImpId: "imp-1", StatusCode: int(exchange.ResponseRejectedGeneral), Ext: &openrtb_ext.NonBidExt{Prebid: openrtb_ext.ExtResponseNonBidPrebid{Bid: openrtb_ext.NonBidObject{Price: 1.5, W: 300, H: 250}}}}}},
+			},
+			expectedResults:  []hookanalytics.Result{newBlockedResult("appnexus", "bid-2", "imp-1", "crid-1", nil)},
+			expectedMessages: []string{"Bid bid-2 from bidder appnexus has been rejected, blocked crid crid-1"},
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			m := &Module{accountExceptions: lruutil.New[string, map[string]exceptions](maxCachedAccountConfigs)}
+			m.lists.Store(lists)
+			payload := hookstage.RawBidderResponsePayload{
+				Bidder:         "appnexus",
+				BidderResponse: &adapters.BidderResponse{Bids: []*adapters.TypedBid{allowedBid, blockedCRIDBid, blockedADomainBid}},
+			}
+			miCtx := hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(test.givenAccountConfig)}
+
+			result, err := m.HandleRawBidderResponseHook(context.Background(), miCtx, payload)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSeatNonBid, result.SeatNonBid)
+			assert.Equal(t, newEnforceBlocklistsTags(test.expectedResults), result.AnalyticsTags)
+			assert.Equal(t, test.expectedMessages, result.DebugMessages)
+
+			require.Len(t, result.ChangeSet.Mutations(), 1)
+			newPayload, err := result.ChangeSet.Mutations()[0].Apply(payload)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBids, newPayload.BidderResponse.Bids)
+		})
+	}
+}
+
+func TestHandleRawBidderResponseHookWithoutBlockedBids(t *testing.T) {
+	payload := hookstage.RawBidderResponsePayload{
+		Bidder:         "appnexus",
+		BidderResponse: &adapters.BidderResponse{Bids: []*adapters.TypedBid{{Bid: &openrtb2.Bid{ID: "bid-1", CrID: "crid-1"}}}},
+	}
+
+	result, err := (&Module{}).HandleRawBidderResponseHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, hookstage.HookResult[hookstage.RawBidderResponsePayload]{}, result, "Bids shouldn't be blocked before the blocklists are loaded.")
+
+	m := &Module{}
+	m.lists.Store(&blocklists{crids: stringSet{"crid-2": {}}})
+	result, err = m.HandleRawBidderResponseHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, hookstage.HookResult[hookstage.RawBidderResponsePayload]{}, result)
+}
+
+func TestDealExceptions(t *testing.T) {
+	m := &Module{accountExceptions: lruutil.New[string, map[string]exceptions](1)}
+
+	e, err := m.dealExceptions(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	accountConfig := json.RawMessage(`{"deal_exceptions":{"deal-1":{"adomains":["evil.com"]}}}`)
+	e, err = m.dealExceptions(accountConfig)
+	require.NoError(t, err)
+	assert.Equal(t, newDealExceptions(map[string]exceptionEntries{"deal-1": {ADomains: []string{"evil.com"}}}), e)
+
+	cached, ok := m.accountExceptions.Get(string(accountConfig))
+	require.True(t, ok, "The parsed account config should be cached.")
+	e, err = m.dealExceptions(accountConfig)
+	require.NoError(t, err)
+	assert.Equal(t, cached, e)
+
+	_, err = m.dealExceptions(json.RawMessage(`{"deal_exceptions":[]}`))
+	assert.ErrorContains(t, err, "failed to parse account config")
+	assert.Equal(t, 1, m.accountExceptions.Len(), "Invalid account configs shouldn't be cached.")
+
+	otherAccountConfig := json.RawMessage(`{"deal_exceptions":{"deal-2":{"crids":["crid-1"]}}}`)
+	_, err = m.dealExceptions(otherAccountConfig)
+	require.NoError(t, err)
+	_, ok = m.accountExceptions.Get(string(accountConfig))
+	assert.False(t, ok, "The least recently used account config should be evicted.")
+	_, ok = m.accountExceptions.Get(string(otherAccountConfig))
+	assert.True(t, ok)
+}
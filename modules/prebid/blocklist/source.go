@@ -0,0 +1,62 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/stored_requests"
+)
+
+// loader loads the blocklists from their source, returning nil blocklists when the source didn't change since the last load.
+type loader interface {
+	load(ctx context.Context) (*blocklists, error)
+}
+
+type fileLoader struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+func (l *fileLoader) load(_ context.Context) (*blocklists, error) {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return nil, err
+	}
+	if info.ModTime().Equal(l.modTime) && info.Size() == l.size {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := newBlocklists(data)
+	if err != nil {
+		return nil, err
+	}
+
+	l.modTime, l.size = info.ModTime(), info.Size()
+	return lists, nil
+}
+
+type storedRequestLoader struct {
+	fetcher stored_requests.Fetcher
+	id      string
+}
+
+func (l *storedRequestLoader) load(ctx context.Context) (*blocklists, error) {
+	requests, _, errs := l.fetcher.FetchRequests(ctx, []string{l.id}, nil)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	data, ok := requests[l.id]
+	if !ok {
+		return nil, fmt.Errorf("stored request %s not found", l.id)
+	}
+	return newBlocklists(data)
+}
@@ -0,0 +1,89 @@
+package blocklist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLoader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklists.json")
+	loader := &fileLoader{path: path}
+
+	_, err := loader.load(context.Background())
+	assert.Error(t, err, "Missing file should fail to load.")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"crids":["crid-1"]}`), 0644))
+	lists, err := loader.load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, stringSet{"crid-1": {}}, lists.crids)
+
+	lists, err = loader.load(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, lists, "Unchanged file shouldn't be reloaded.")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"crids":["crid-1","crid-2"]}`), 0644))
+	lists, err = loader.load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, stringSet{"crid-1": {}, "crid-2": {}}, lists.crids)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"crids":`), 0644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	_, err = loader.load(context.Background())
+	assert.ErrorContains(t, err, "failed to parse blocklists")
+}
+
+func TestStoredRequestLoader(t *testing.T) {
+	testCases := map[string]struct {
+		givenFetcher  mockFetcher
+		expectedCRIDs stringSet
+		expectedErr   string
+	}{
+		"Stored request found": {
+			givenFetcher:  mockFetcher{requests: map[string]json.RawMessage{"blocklists": json.RawMessage(`{"crids":["crid-1"]}`)}},
+			expectedCRIDs: stringSet{"crid-1": {}},
+		},
+		"Stored request not found": {
+			givenFetcher: mockFetcher{},
+			expectedErr:  "stored request blocklists not found",
+		},
+		"Fetch error": {
+			givenFetcher: mockFetcher{errs: []error{errors.New("connection refused")}},
+			expectedErr:  "connection refused",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			loader := &storedRequestLoader{fetcher: test.givenFetcher, id: "blocklists"}
+
+			lists, err := loader.load(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCRIDs, lists.crids)
+		})
+	}
+}
+
+type mockFetcher struct {
+	requests map[string]json.RawMessage
+	errs     []error
+}
+
+func (f mockFetcher) FetchRequests(_ context.Context, _ []string, _ []string) (map[string]json.RawMessage, map[string]json.RawMessage, []error) {
+	return f.requests, nil, f.errs
+}
+
+func (f mockFetcher) FetchResponses(_ context.Context, _ []string) (map[string]json.RawMessage, []error) {
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package creativescanner
+
+import (
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+)
+
+const scanCreativesTag = "scan_creatives"
+
+const (
+	ruleAnalyticKey    = "rule"
+	actionAnalyticKey  = "action"
+	matchesAnalyticKey = "matches"
+)
+
+// creativescanner module has only 1 activity: `scan_creatives`, with a result for every rule a bid failed
+func newScanCreativesTags(results []hookanalytics.Result) hookanalytics.Analytics {
+	return hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:    scanCreativesTag,
+				Status:  hookanalytics.ActivityStatusSuccess,
+				Results: results,
+			},
+		},
+	}
+}
+
+// newFindingResult blocks the bid for a rejecting rule, flagged bids are reported as allowed.
+func newFindingResult(f finding, bidder, bidID, impID string) hookanalytics.Result {
+	status := hookanalytics.ResultStatusAllow
+	if f.action == actionReject {
+		status = hookanalytics.ResultStatusBlock
+	}
+
+	return hookanalytics.Result{
+		Status: status,
+		Values: map[string]interface{}{
+			ruleAnalyticKey:    f.rule,
+			actionAnalyticKey:  f.action,
+			matchesAnalyticKey: f.matches,
+		},
+		AppliedTo: hookanalytics.AppliedTo{
+			Bidder: bidder,
+			BidIds: []string{bidID},
+			ImpIds: []string{impID},
+		},
+	}
+}
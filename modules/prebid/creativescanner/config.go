@@ -0,0 +1,159 @@
+package creativescanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const (
+	actionReject = "reject"
+	actionFlag   = "flag"
+)
+
+// defaultMalwareSignatures are used when the malware_signatures rule is enabled without patterns,
+// they match obfuscated script execution and well-known in-browser miners.
+var defaultMalwareSignatures = []string{
+	`(?i)eval\s*\(\s*(?:atob|unescape|decodeURIComponent)\s*\(`,
+	`(?i)eval\s*\(\s*String\.fromCharCode\s*\(`,
+	`(?i)document\.write\s*\(\s*unescape\s*\(`,
+	`(?i)\b(?:coinhive|cryptonight|coin-hive)\b`,
+}
+
+// defaultSignatures are the compiled defaultMalwareSignatures, shared by all the configs using them.
+var defaultSignatures = func() []*regexp.Regexp {
+	signatures := make([]*regexp.Regexp, 0, len(defaultMalwareSignatures))
+	for _, pattern := range defaultMalwareSignatures {
+		signatures = append(signatures, regexp.MustCompile(pattern))
+	}
+	return signatures
+}()
+
+// newConfig parses the config of every given level on top of the previous one,
+// so that the account config overrides the rules of the host config.
+func newConfig(levels ...json.RawMessage) (config, error) {
+	var cfg config
+	for _, data := range levels {
+		if len(data) == 0 {
+			continue
+		}
+		if err := jsonutil.UnmarshalValid(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to parse config: %s", err)
+		}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// withAccountConfig returns the config overridden by the account config. The config is left unchanged,
+// and its malware signatures are reused unless the account config changes the patterns.
+func (cfg config) withAccountConfig(accountConfig json.RawMessage) (config, error) {
+	if len(accountConfig) == 0 {
+		return cfg, nil
+	}
+
+	merged := cfg
+	merged.BannedDomains.Domains = slices.Clone(cfg.BannedDomains.Domains)
+	merged.MalwareSignatures.Patterns = slices.Clone(cfg.MalwareSignatures.Patterns)
+	if err := jsonutil.UnmarshalValid(accountConfig, &merged); err != nil {
+		return merged, fmt.Errorf("failed to parse config: %s", err)
+	}
+	if !slices.Equal(merged.MalwareSignatures.Patterns, cfg.MalwareSignatures.Patterns) {
+		merged.MalwareSignatures.signatures = nil
+	}
+
+	if err := merged.validate(); err != nil {
+		return merged, err
+	}
+	return merged, nil
+}
+
+type config struct {
+	BannedDomains     bannedDomainsRule     `json:"banned_domains"`
+	MalwareSignatures malwareSignaturesRule `json:"malware_signatures"`
+	AutoRedirect      ruleConfig            `json:"auto_redirect"`
+	InsecureResources ruleConfig            `json:"insecure_resources"`
+	MaxSize           maxSizeRule           `json:"max_size"`
+}
+
+// ruleConfig holds the settings shared by all rules. Bids failing an enabled rule
+// are rejected, unless the action of the rule is "flag".
+type ruleConfig struct {
+	Enabled bool   `json:"enabled"`
+	Action  string `json:"action"`
+}
+
+type bannedDomainsRule struct {
+	ruleConfig
+	Domains []string `json:"domains"`
+}
+
+type malwareSignaturesRule struct {
+	ruleConfig
+	Patterns []string `json:"patterns"`
+
+	signatures []*regexp.Regexp
+}
+
+type maxSizeRule struct {
+	ruleConfig
+	MaxBytes int `json:"max_bytes"`
+}
+
+func (cfg *config) validate() error {
+	for _, rule := range []struct {
+		name   string
+		config *ruleConfig
+	}{
+		{ruleBannedDomains, &cfg.BannedDomains.ruleConfig},
+		{ruleMalwareSignatures, &cfg.MalwareSignatures.ruleConfig},
+		{ruleAutoRedirect, &cfg.AutoRedirect},
+		{ruleInsecureResources, &cfg.InsecureResources},
+		{ruleMaxSize, &cfg.MaxSize.ruleConfig},
+	} {
+		switch rule.config.Action {
+		case "":
+			rule.config.Action = actionReject
+		case actionReject, actionFlag:
+		default:
+			return fmt.Errorf(`%s.action must be one of "%s" or "%s". Got "%s"`, rule.name, actionReject, actionFlag, rule.config.Action)
+		}
+	}
+
+	if cfg.BannedDomains.Enabled && len(cfg.BannedDomains.Domains) == 0 {
+		return fmt.Errorf("%s.domains are required", ruleBannedDomains)
+	}
+	for i, domain := range cfg.BannedDomains.Domains {
+		cfg.BannedDomains.Domains[i] = strings.ToLower(strings.TrimPrefix(domain, "."))
+	}
+
+	if cfg.MaxSize.Enabled && cfg.MaxSize.MaxBytes <= 0 {
+		return fmt.Errorf("%s.max_bytes must be > 0. Got %d", ruleMaxSize, cfg.MaxSize.MaxBytes)
+	}
+
+	if !cfg.MalwareSignatures.Enabled || cfg.MalwareSignatures.signatures != nil {
+		return nil
+	}
+	patterns := cfg.MalwareSignatures.Patterns
+	if len(patterns) == 0 {
+		cfg.MalwareSignatures.signatures = defaultSignatures
+		return nil
+	}
+	cfg.MalwareSignatures.signatures = make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		signature, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid %s pattern: %s", ruleMalwareSignatures, err)
+		}
+		cfg.MalwareSignatures.signatures = append(cfg.MalwareSignatures.signatures, signature)
+	}
+
+	return nil
+}
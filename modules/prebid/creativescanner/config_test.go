@@ -0,0 +1,100 @@
+package creativescanner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	testCases := map[string]struct {
+		givenHostConfig    string
+		givenAccountConfig string
+		expectedConfig     func(*config)
+		expectedErr        string
+	}{
+		"Empty configs": {
+			expectedConfig: func(cfg *config) {},
+		},
+		"Account config overrides host config": {
+			givenHostConfig:    `{"banned_domains":{"enabled":true,"domains":["Evil.com"]},"max_size":{"enabled":true,"max_bytes":100}}`,
+			givenAccountConfig: `{"max_size":{"enabled":false},"auto_redirect":{"enabled":true,"action":"flag"}}`,
+			expectedConfig: func(cfg *config) {
+				cfg.BannedDomains = bannedDomainsRule{ruleConfig: ruleConfig{Enabled: true, Action: actionReject}, Domains: []string{"evil.com"}}
+				cfg.MaxSize.MaxBytes = 100
+				cfg.AutoRedirect = ruleConfig{Enabled: true, Action: actionFlag}
+			},
+		},
+		"Malformed config": {
+			givenAccountConfig: `{"max_size":`,
+			expectedErr:        "failed to parse config",
+		},
+		"Unknown action": {
+			givenAccountConfig: `{"auto_redirect":{"enabled":true,"action":"drop"}}`,
+			expectedErr:        `auto_redirect.action must be one of "reject" or "flag". Got "drop"`,
+		},
+		"Banned domains without domains": {
+			givenAccountConfig: `{"banned_domains":{"enabled":true}}`,
+			expectedErr:        "banned_domains.domains are required",
+		},
+		"Max size without max bytes": {
+			givenAccountConfig: `{"max_size":{"enabled":true}}`,
+			expectedErr:        "max_size.max_bytes must be > 0. Got 0",
+		},
+		"Invalid malware pattern": {
+			givenAccountConfig: `{"malware_signatures":{"enabled":true,"patterns":["("]}}`,
+			expectedErr:        "invalid malware_signatures pattern",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := newConfig(json.RawMessage(test.givenHostConfig), json.RawMessage(test.givenAccountConfig))
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectedConfig := config{
+				BannedDomains:     bannedDomainsRule{ruleConfig: ruleConfig{Action: actionReject}},
+				MalwareSignatures: malwareSignaturesRule{ruleConfig: ruleConfig{Action: actionReject}},
+				AutoRedirect:      ruleConfig{Action: actionReject},
+				InsecureResources: ruleConfig{Action: actionReject},
+				MaxSize:           maxSizeRule{ruleConfig: ruleConfig{Action: actionReject}},
+			}
+			test.expectedConfig(&expectedConfig)
+			assert.Equal(t, expectedConfig, cfg)
+		})
+	}
+}
+
+func TestNewConfigDefaultMalwareSignatures(t *testing.T) {
+	cfg, err := newConfig(json.RawMessage(`{"malware_signatures":{"enabled":true}}`))
+	require.NoError(t, err)
+	assert.Len(t, cfg.MalwareSignatures.signatures, len(defaultMalwareSignatures))
+}
+
+func TestWithAccountConfig(t *testing.T) {
+	hostConfig, err := newConfig(json.RawMessage(`{"banned_domains":{"enabled":true,"domains":["evil.com"]},"malware_signatures":{"enabled":true,"patterns":["miner"]}}`))
+	require.NoError(t, err)
+	hostDomains := hostConfig.BannedDomains.Domains
+
+	cfg, err := hostConfig.withAccountConfig(json.RawMessage(`{"banned_domains":{"domains":["Bad.com"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bad.com"}, cfg.BannedDomains.Domains)
+	assert.Equal(t, []string{"evil.com"}, hostDomains, "The host config must not be modified.")
+	assert.Same(t, hostConfig.MalwareSignatures.signatures[0], cfg.MalwareSignatures.signatures[0], "The host signatures should be reused.")
+
+	cfg, err = hostConfig.withAccountConfig(json.RawMessage(`{"malware_signatures":{"patterns":["coinhive"]}}`))
+	require.NoError(t, err)
+	require.Len(t, cfg.MalwareSignatures.signatures, 1)
+	assert.Equal(t, "coinhive", cfg.MalwareSignatures.signatures[0].String())
+	assert.Equal(t, "miner", hostConfig.MalwareSignatures.signatures[0].String(), "The host config must not be modified.")
+
+	cfg, err = hostConfig.withAccountConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, hostConfig, cfg)
+}
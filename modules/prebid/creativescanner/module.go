@@ -0,0 +1,120 @@
+package creativescanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+)
+
+// secureImpsKey is the module context key of the IDs of the imps requiring secure creatives.
+const secureImpsKey = "secure_imps"
+
+// Builder parses the host config, which holds the default rules of the accounts.
+func Builder(cfg json.RawMessage, _ moduledeps.ModuleDeps) (interface{}, error) {
+	hostConfig, err := newConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return Module{hostConfig: hostConfig}, nil
+}
+
+type Module struct {
+	hostConfig config
+}
+
+// HandleProcessedAuctionHook saves the secure imps of the request for the insecure_resources rule.
+func (m Module) HandleProcessedAuctionHook(
+	_ context.Context,
+	_ hookstage.ModuleInvocationContext,
+	payload hookstage.ProcessedAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload], error) {
+	result := hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload]{}
+	if payload.Request == nil || payload.Request.BidRequest == nil {
+		return result, hookexecution.NewFailure("payload contains a nil bid request")
+	}
+
+	secureImps := make(map[string]struct{})
+	for _, imp := range payload.Request.Imp {
+		if imp.Secure != nil && *imp.Secure == 1 {
+			secureImps[imp.ID] = struct{}{}
+		}
+	}
+	result.ModuleContext = hookstage.ModuleContext{secureImpsKey: secureImps}
+
+	return result, nil
+}
+
+// HandleRawBidderResponseHook scans the markup of the bids, rejecting the bids failing a rule
+// with the reject action and reporting the bids failing a rule with the flag action.
+func (m Module) HandleRawBidderResponseHook(
+	_ context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawBidderResponsePayload,
+) (hookstage.HookResult[hookstage.RawBidderResponsePayload], error) {
+	result := hookstage.HookResult[hookstage.RawBidderResponsePayload]{}
+	if payload.BidderResponse == nil {
+		return result, nil
+	}
+
+	cfg, err := m.hostConfig.withAccountConfig(miCtx.AccountConfig)
+	if err != nil {
+		return result, err
+	}
+
+	// without the processed_auction_request hook in the execution plan, no imp is known to be secure
+	secureImps, _ := miCtx.ModuleContext[secureImpsKey].(map[string]struct{})
+
+	var results []hookanalytics.Result
+	allowedBids := make([]*adapters.TypedBid, 0, len(payload.BidderResponse.Bids))
+	for _, bid := range payload.BidderResponse.Bids {
+		if bid == nil || bid.Bid == nil {
+			continue
+		}
+
+		_, secure := secureImps[bid.Bid.ImpID]
+		findings := scanCreative(cfg, bid.Bid.AdM, secure)
+		for _, f := range findings {
+			results = append(results, newFindingResult(f, payload.Bidder, bid.Bid.ID, bid.Bid.ImpID))
+		}
+
+		if len(findings) == 0 {
+			allowedBids = append(allowedBids, bid)
+			continue
+		}
+
+		verb := "flagged"
+		if rejects(findings) {
+			verb = "rejected"
+		} else {
+			allowedBids = append(allowedBids, bid)
+		}
+		result.DebugMessages = append(
+			result.DebugMessages,
+			fmt.Sprintf("Bid %s from bidder %s has been %s, failed rules: %s", bid.Bid.ID, payload.Bidder, verb, strings.Join(findingRules(findings), ", ")),
+		)
+	}
+
+	if len(results) > 0 {
+		result.AnalyticsTags = newScanCreativesTags(results)
+	}
+	if len(allowedBids) != len(payload.BidderResponse.Bids) {
+		result.ChangeSet.RawBidderResponse().Bids().UpdateBids(allowedBids)
+	}
+
+	return result, nil
+}
+
+func findingRules(findings []finding) []string {
+	rules := make([]string, 0, len(findings))
+	for _, f := range findings {
+		rules = append(rules, f.rule)
+	}
+	return rules
+}
@@ -0,0 +1,140 @@
+package creativescanner
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	module, err := Builder(json.RawMessage(`{"max_size":{"enabled":true,"max_bytes":100}}`), moduledeps.ModuleDeps{})
+	assert.NoError(t, err)
+	assert.IsType(t, Module{}, module)
+
+	_, err = Builder(json.RawMessage(`{"max_size":{"enabled":true}}`), moduledeps.ModuleDeps{})
+	assert.EqualError(t, err, "max_size.max_bytes must be > 0. Got 0")
+}
+
+func TestHandleProcessedAuctionHook(t *testing.T) {
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+		Imp: []openrtb2.Imp{
+			{ID: "imp-1", Secure: ptrutil.ToPtr[int8](1)},
+			{ID: "imp-2", Secure: ptrutil.ToPtr[int8](0)},
+			{ID: "imp-3"},
+		},
+	}}
+
+	result, err := Module{}.HandleProcessedAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.ProcessedAuctionRequestPayload{Request: request})
+	assert.NoError(t, err)
+	assert.Equal(t, hookstage.ModuleContext{secureImpsKey: map[string]struct{}{"imp-1": {}}}, result.ModuleContext)
+
+	_, err = Module{}.HandleProcessedAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.ProcessedAuctionRequestPayload{})
+	assert.Equal(t, hookexecution.NewFailure("payload contains a nil bid request"), err)
+}
+
+func TestHandleRawBidderResponseHook(t *testing.T) {
+	cleanBid := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", AdM: `<img src="https://cdn.com/a.png">`}}
+	insecureBid := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "imp-1", AdM: `<img src="http://cdn.com/a.png">`}}
+	bannedBid := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-3", ImpID: "imp-2", AdM: `<img src="https://evil.com/a.png">`}}
+
+	testCases := map[string]struct {
+		givenHostConfig    string
+		givenAccountConfig string
+		givenModuleContext hookstage.ModuleContext
+		expectedBids       []*adapters.TypedBid
+		expectedResults    []hookanalytics.Result
+		expectedMessages   []string
+	}{
+		"Reject bids failing rules": {
+			givenHostConfig:    `{"banned_domains":{"enabled":true,"domains":["evil.com"]}}`,
+			givenAccountConfig: `{"insecure_resources":{"enabled":true}}`,
+			givenModuleContext: hookstage.ModuleContext{secureImpsKey: map[string]struct{}{"imp-1": {}}},
+			expectedBids:       []*adapters.TypedBid{cleanBid},
+			expectedResults: []hookanalytics.Result{
+				blockedResult(ruleInsecureResources, "bid-2", "imp-1", "http://cdn.com/a.png"),
+				blockedResult(ruleBannedDomains, "bid-3", "imp-2", "evil.com"),
+			},
+			expectedMessages: []string{
+				"Bid bid-2 from bidder appnexus has been rejected, failed rules: insecure_resources",
+				"Bid bid-3 from bidder appnexus has been rejected, failed rules: banned_domains",
+			},
+		},
+		"Flag bids failing rules": {
+			givenAccountConfig: `{"banned_domains":{"enabled":true,"action":"flag","domains":["evil.com"]}}`,
+			expectedResults: []hookanalytics.Result{
+				{
+					Status:    hookanalytics.ResultStatusAllow,
+					Values:    map[string]interface{}{ruleAnalyticKey: ruleBannedDomains, actionAnalyticKey: actionFlag, matchesAnalyticKey: []string{"evil.com"}},
+					AppliedTo: hookanalytics.AppliedTo{Bidder: "appnexus", BidIds: []string{"bid-3"}, ImpIds: []string{"imp-2"}},
+				},
+			},
+			expectedMessages: []string{"Bid bid-3 from bidder appnexus has been flagged, failed rules: banned_domains"},
+		},
+		"Insecure resources without secure imps": {
+			givenAccountConfig: `{"insecure_resources":{"enabled":true}}`,
+		},
+		"No rules enabled": {},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			payload := hookstage.RawBidderResponsePayload{
+				Bidder:         "appnexus",
+				BidderResponse: &adapters.BidderResponse{Bids: []*adapters.TypedBid{cleanBid, insecureBid, bannedBid}},
+			}
+			miCtx := hookstage.ModuleInvocationContext{
+				AccountConfig: json.RawMessage(test.givenAccountConfig),
+				ModuleContext: test.givenModuleContext,
+			}
+
+			module, err := Builder(json.RawMessage(test.givenHostConfig), moduledeps.ModuleDeps{})
+			require.NoError(t, err)
+
+			result, err := module.(Module).HandleRawBidderResponseHook(context.Background(), miCtx, payload)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedMessages, result.DebugMessages)
+
+			if test.expectedResults == nil {
+				assert.Empty(t, result.AnalyticsTags.Activities)
+			} else {
+				assert.Equal(t, newScanCreativesTags(test.expectedResults), result.AnalyticsTags)
+			}
+
+			if test.expectedBids == nil {
+				assert.Empty(t, result.ChangeSet.Mutations())
+				return
+			}
+			require.Len(t, result.ChangeSet.Mutations(), 1)
+			newPayload, err := result.ChangeSet.Mutations()[0].Apply(payload)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBids, newPayload.BidderResponse.Bids)
+		})
+	}
+}
+
+func TestHandleRawBidderResponseHookWithInvalidAccountConfig(t *testing.T) {
+	miCtx := hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(`{"auto_redirect":{"action":"drop"}}`)}
+	payload := hookstage.RawBidderResponsePayload{Bidder: "appnexus", BidderResponse: &adapters.BidderResponse{}}
+
+	_, err := Module{}.HandleRawBidderResponseHook(context.Background(), miCtx, payload)
+	assert.EqualError(t, err, `auto_redirect.action must be one of "reject" or "flag". Got "drop"`)
+}
+
+func blockedResult(rule, bidID, impID string, matches ...string) hookanalytics.Result {
+	return hookanalytics.Result{
+		Status:    hookanalytics.ResultStatusBlock,
+		Values:    map[string]interface{}{ruleAnalyticKey: rule, actionAnalyticKey: actionReject, matchesAnalyticKey: matches},
+		AppliedTo: hookanalytics.AppliedTo{Bidder: "appnexus", BidIds: []string{bidID}, ImpIds: []string{impID}},
+	}
+}
@@ -0,0 +1,116 @@
+package creativescanner
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ruleBannedDomains     = "banned_domains"
+	ruleMalwareSignatures = "malware_signatures"
+	ruleAutoRedirect      = "auto_redirect"
+	ruleInsecureResources = "insecure_resources"
+	ruleMaxSize           = "max_size"
+)
+
+var (
+	// urlHostRegexp matches the host of absolute and protocol-relative URLs.
+	urlHostRegexp = regexp.MustCompile(`(?i)(?:https?:)?//([a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)+)`)
+
+	autoRedirectRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:window|top|parent|self|document)\.location(?:\.href)?\s*=[^=]`),
+		regexp.MustCompile(`(?i)\blocation\.(?:replace|assign)\s*\(`),
+		regexp.MustCompile(`(?i)<meta[^>]+http-equiv\s*=\s*["']?refresh`),
+	}
+
+	// insecureResourceRegexp matches plain HTTP URLs loaded by the creative: in attributes, in CSS
+	// and in the text of elements, like the VAST media files, but not in namespace declarations.
+	insecureResourceRegexp = regexp.MustCompile(`(?i)(?:\b(?:src|href|srcset|data|poster|background)\s*=\s*["']?|url\(\s*["']?|<!\[CDATA\[\s*|>\s*)(http://[^\s"'<>)\]]+)`)
+)
+
+// finding describes an enabled rule a creative failed.
+type finding struct {
+	rule    string
+	action  string
+	matches []string
+}
+
+// scanCreative runs the enabled rules against the markup of a bid. The insecure_resources
+// rule only applies to bids of secure imps.
+func scanCreative(cfg config, adm string, secure bool) []finding {
+	var findings []finding
+	add := func(rule ruleConfig, name string, matches []string) {
+		if len(matches) > 0 {
+			findings = append(findings, finding{rule: name, action: rule.Action, matches: matches})
+		}
+	}
+
+	if cfg.MaxSize.Enabled && len(adm) > cfg.MaxSize.MaxBytes {
+		add(cfg.MaxSize.ruleConfig, ruleMaxSize, []string{strconv.Itoa(len(adm))})
+	}
+
+	// markup embedded in JSON, like native responses, escapes the slashes of its URLs
+	adm = strings.ReplaceAll(adm, `\/`, "/")
+
+	if cfg.BannedDomains.Enabled {
+		add(cfg.BannedDomains.ruleConfig, ruleBannedDomains, findBannedDomains(adm, cfg.BannedDomains.Domains))
+	}
+	if cfg.MalwareSignatures.Enabled {
+		add(cfg.MalwareSignatures.ruleConfig, ruleMalwareSignatures, findMatches(adm, cfg.MalwareSignatures.signatures))
+	}
+	if cfg.AutoRedirect.Enabled {
+		add(cfg.AutoRedirect, ruleAutoRedirect, findMatches(adm, autoRedirectRegexps))
+	}
+	if cfg.InsecureResources.Enabled && secure {
+		add(cfg.InsecureResources, ruleInsecureResources, findInsecureResources(adm))
+	}
+
+	return findings
+}
+
+// findBannedDomains returns the hosts referenced by the markup which are banned domains or their subdomains.
+func findBannedDomains(adm string, domains []string) []string {
+	var banned []string
+	for _, match := range urlHostRegexp.FindAllStringSubmatch(adm, -1) {
+		host := strings.ToLower(match[1])
+		if slices.Contains(banned, host) {
+			continue
+		}
+		for _, domain := range domains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				banned = append(banned, host)
+				break
+			}
+		}
+	}
+	return banned
+}
+
+func findMatches(adm string, regexps []*regexp.Regexp) []string {
+	var matches []string
+	for _, re := range regexps {
+		if match := re.FindString(adm); match != "" {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+func findInsecureResources(adm string) []string {
+	var resources []string
+	for _, match := range insecureResourceRegexp.FindAllStringSubmatch(adm, -1) {
+		if !slices.Contains(resources, match[1]) {
+			resources = append(resources, match[1])
+		}
+	}
+	return resources
+}
+
+// rejects reports whether any of the findings rejects the bid.
+func rejects(findings []finding) bool {
+	return slices.ContainsFunc(findings, func(f finding) bool {
+		return f.action == actionReject
+	})
+}
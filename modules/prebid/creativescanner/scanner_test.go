@@ -0,0 +1,95 @@
+package creativescanner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanCreative(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig      string
+		givenAdm         string
+		givenSecure      bool
+		expectedFindings []finding
+	}{
+		"Clean creative": {
+			givenConfig: `{"banned_domains":{"enabled":true,"domains":["evil.com"]},"malware_signatures":{"enabled":true},"auto_redirect":{"enabled":true},"insecure_resources":{"enabled":true},"max_size":{"enabled":true,"max_bytes":1000}}`,
+			givenAdm:    `<div><a href="https://good.com/click"><img src="https://cdn.good.com/ad.png"></a><script>var x = 1;</script></div>`,
+			givenSecure: true,
+		},
+		"Banned domains and subdomains": {
+			givenConfig: `{"banned_domains":{"enabled":true,"domains":[".evil.com","bad.net"]}}`,
+			givenAdm:    `<img src="https://cdn.EVIL.com/a.png"><img src="//evil.com/b.png"><a href="http://notbad.net">x</a><img src="https://cdn.evil.com/c.png">`,
+			expectedFindings: []finding{
+				{rule: ruleBannedDomains, action: actionReject, matches: []string{"cdn.evil.com", "evil.com"}},
+			},
+		},
+		"Banned domain in JSON escaped markup": {
+			givenConfig: `{"banned_domains":{"enabled":true,"action":"flag","domains":["evil.com"]}}`,
+			givenAdm:    `{"native":{"link":{"url":"https:\/\/evil.com\/click"}}}`,
+			expectedFindings: []finding{
+				{rule: ruleBannedDomains, action: actionFlag, matches: []string{"evil.com"}},
+			},
+		},
+		"Default malware signatures": {
+			givenConfig: `{"malware_signatures":{"enabled":true}}`,
+			givenAdm:    `<script>eval(atob("YWxlcnQoMSk="));</script>`,
+			expectedFindings: []finding{
+				{rule: ruleMalwareSignatures, action: actionReject, matches: []string{"eval(atob("}},
+			},
+		},
+		"Configured malware signatures": {
+			givenConfig: `{"malware_signatures":{"enabled":true,"patterns":["badscript\\.js"]}}`,
+			givenAdm:    `<script src="https://cdn.com/badscript.js"></script><script>eval(atob("x"))</script>`,
+			expectedFindings: []finding{
+				{rule: ruleMalwareSignatures, action: actionReject, matches: []string{"badscript.js"}},
+			},
+		},
+		"Auto redirects": {
+			givenConfig: `{"auto_redirect":{"enabled":true}}`,
+			givenAdm:    `<meta http-equiv="refresh" content="0;url=https://x.com"><script>top.location.href = "https://x.com"; if (window.location == x) {}</script>`,
+			expectedFindings: []finding{
+				{rule: ruleAutoRedirect, action: actionReject, matches: []string{`top.location.href = `, `<meta http-equiv="refresh`}},
+			},
+		},
+		"Location comparison is not a redirect": {
+			givenConfig: `{"auto_redirect":{"enabled":true}}`,
+			givenAdm:    `<script>if (window.location == "x") {}</script>`,
+		},
+		"Insecure resources of secure imp": {
+			givenConfig: `{"insecure_resources":{"enabled":true}}`,
+			givenAdm:    `<VAST xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><MediaFile><![CDATA[http://cdn.com/v.mp4]]></MediaFile><Impression>http://t.com/i</Impression></VAST><img src='http://cdn.com/a.png'><div style="background:url(http://cdn.com/b.png)">`,
+			givenSecure: true,
+			expectedFindings: []finding{
+				{rule: ruleInsecureResources, action: actionReject, matches: []string{"http://cdn.com/v.mp4", "http://t.com/i", "http://cdn.com/a.png", "http://cdn.com/b.png"}},
+			},
+		},
+		"Insecure resources of non secure imp": {
+			givenConfig: `{"insecure_resources":{"enabled":true}}`,
+			givenAdm:    `<img src="http://cdn.com/a.png">`,
+		},
+		"Oversized creative": {
+			givenConfig: `{"max_size":{"enabled":true,"action":"flag","max_bytes":10}}`,
+			givenAdm:    `<div>0123456789</div>`,
+			expectedFindings: []finding{
+				{rule: ruleMaxSize, action: actionFlag, matches: []string{"21"}},
+			},
+		},
+		"Disabled rules": {
+			givenConfig: `{"banned_domains":{"domains":["evil.com"]},"auto_redirect":{"enabled":false}}`,
+			givenAdm:    `<script>top.location = "https://evil.com";</script>`,
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := newConfig(json.RawMessage(test.givenConfig))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedFindings, scanCreative(cfg, test.givenAdm, test.givenSecure))
+		})
+	}
+}
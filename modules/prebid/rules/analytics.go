@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+)
+
+const applyRulesTag = "apply_rules"
+
+const (
+	ruleAnalyticKey    = "rule"
+	actionsAnalyticKey = "actions"
+)
+
+// rules module has only 1 activity: `apply_rules`, with a result for every rule applied
+func newApplyRulesTags(results []hookanalytics.Result) hookanalytics.Analytics {
+	return hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:    applyRulesTag,
+				Status:  hookanalytics.ActivityStatusSuccess,
+				Results: results,
+			},
+		},
+	}
+}
+
+func newRuleResult(r rule, bidder string, impIDs []string, excludedImps bool) hookanalytics.Result {
+	actions := make([]string, 0, len(r.Actions))
+	for _, a := range r.Actions {
+		actions = append(actions, a.Type)
+	}
+
+	status := hookanalytics.ResultStatusModify
+	if excludedImps {
+		status = hookanalytics.ResultStatusBlock
+	}
+
+	return hookanalytics.Result{
+		Status: status,
+		Values: map[string]interface{}{
+			ruleAnalyticKey:    r.Name,
+			actionsAnalyticKey: actions,
+		},
+		AppliedTo: hookanalytics.AppliedTo{
+			Bidder:  bidder,
+			ImpIds:  impIDs,
+			Request: true,
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/prebid/openrtb/v20/adcom1"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const (
+	actionSet           = "set"
+	actionRemove        = "remove"
+	actionAppend        = "append"
+	actionExcludeBidder = "exclude_bidder"
+	actionAddBidder     = "add_bidder"
+	actionSetFloor      = "set_floor"
+)
+
+// impPathPrefix marks the paths relative to the imps matched by a rule.
+const impPathPrefix = "imp."
+
+var (
+	stageProcessedAuctionRequest = hooks.StageProcessedAuctionRequest.String()
+	stageBidderRequest           = hooks.StageBidderRequest.String()
+)
+
+func newConfig(data json.RawMessage) (config, error) {
+	var cfg config
+	if err := jsonutil.UnmarshalValid(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config: %s", err)
+	}
+
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].validate(); err != nil {
+			return cfg, fmt.Errorf("invalid rules[%d]: %s", i, err)
+		}
+	}
+	return cfg, nil
+}
+
+type config struct {
+	Rules []rule `json:"rules"`
+}
+
+// hasStage reports whether some of the rules apply at the stage.
+func (c config) hasStage(stage string) bool {
+	return slices.ContainsFunc(c.Rules, func(r rule) bool {
+		return r.Stage == stage
+	})
+}
+
+// rule applies its actions to the requests and the imps it matches, in the order the rules are listed.
+type rule struct {
+	Name string `json:"name"`
+	// Stage is either processed_auction_request, the default, or bidder_request.
+	Stage   string   `json:"stage"`
+	Match   match    `json:"match"`
+	Actions []action `json:"actions"`
+}
+
+// match holds the conditions a request has to meet, all of them are required.
+type match struct {
+	// Request conditions apply to the bid request.
+	Request []fieldCondition `json:"request"`
+	// Imp conditions select the imps the actions apply to, by paths relative to the imp.
+	Imp []fieldCondition `json:"imp"`
+	// Countries are matched against device.geo.country.
+	Countries []string `json:"countries"`
+	// DeviceTypes are matched against device.devicetype.
+	DeviceTypes []adcom1.DeviceType `json:"device_types"`
+	// Bidders are matched against the bidder of the bidder_request stage,
+	// and select the imps bidding with one of them at the processed_auction_request stage.
+	Bidders []string `json:"bidders"`
+}
+
+// fieldCondition tests the value at a path, with every operator it sets.
+type fieldCondition struct {
+	Path    string        `json:"path"`
+	Exists  *bool         `json:"exists"`
+	Equals  interface{}   `json:"equals"`
+	In      []interface{} `json:"in"`
+	Matches string        `json:"matches"`
+
+	matchesRegexp *regexp.Regexp
+}
+
+type action struct {
+	Type string `json:"type"`
+	// Path and Value of the set, remove and append actions,
+	// paths starting with "imp." apply to every matched imp.
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+	// Bidder of the exclude_bidder and add_bidder actions, with the Params of the added bidder.
+	// It defaults to the bidder of the request at the bidder_request stage.
+	Bidder string          `json:"bidder"`
+	Params json.RawMessage `json:"params"`
+	// Floor and Currency of the set_floor action.
+	Floor    float64 `json:"floor"`
+	Currency string  `json:"currency"`
+}
+
+func (r *rule) validate() error {
+	if r.Stage == "" {
+		r.Stage = stageProcessedAuctionRequest
+	}
+	if r.Stage != stageProcessedAuctionRequest && r.Stage != stageBidderRequest {
+		return fmt.Errorf(`stage must be one of "%s" or "%s". Got "%s"`, stageProcessedAuctionRequest, stageBidderRequest, r.Stage)
+	}
+	if len(r.Actions) == 0 {
+		return errors.New("actions are required")
+	}
+
+	for _, conditions := range [][]fieldCondition{r.Match.Request, r.Match.Imp} {
+		for i := range conditions {
+			if err := conditions[i].validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, action := range r.Actions {
+		if err := action.validate(r.Stage); err != nil {
+			return fmt.Errorf("actions[%d]: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *fieldCondition) validate() error {
+	if c.Path == "" {
+		return errors.New("condition path is required")
+	}
+	if c.Matches != "" {
+		matchesRegexp, err := regexp.Compile(c.Matches)
+		if err != nil {
+			return fmt.Errorf("invalid %s condition regexp: %s", c.Path, err)
+		}
+		c.matchesRegexp = matchesRegexp
+	}
+	return nil
+}
+
+func (a action) validate(stage string) error {
+	switch a.Type {
+	case actionSet, actionAppend:
+		if len(a.Value) == 0 {
+			return fmt.Errorf("%s action requires a value", a.Type)
+		}
+		fallthrough
+	case actionRemove:
+		if a.Path == "" || a.Path == impPathPrefix {
+			return fmt.Errorf("%s action requires a path", a.Type)
+		}
+	case actionExcludeBidder:
+		if a.Bidder == "" && stage == stageProcessedAuctionRequest {
+			return fmt.Errorf("%s action requires a bidder at the %s stage", a.Type, stage)
+		}
+	case actionAddBidder:
+		if stage != stageProcessedAuctionRequest {
+			return fmt.Errorf("%s action is only supported at the %s stage", a.Type, stageProcessedAuctionRequest)
+		}
+		if a.Bidder == "" {
+			return fmt.Errorf("%s action requires a bidder", a.Type)
+		}
+	case actionSetFloor:
+		if a.Floor < 0 {
+			return fmt.Errorf("%s action floor must be >= 0. Got %f", a.Type, a.Floor)
+		}
+	default:
+		return fmt.Errorf(`unsupported action type "%s"`, a.Type)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig string
+		expectedErr string
+	}{
+		"Valid config": {
+			givenConfig: `{"rules":[
+				{"name":"secure","match":{"imp":[{"path":"tagid","matches":"^top-"}]},"actions":[{"type":"set","path":"imp.secure","value":1}]},
+				{"name":"no-appnexus","stage":"bidder_request","match":{"bidders":["appnexus"]},"actions":[{"type":"exclude_bidder"}]}
+			]}`,
+		},
+		"Malformed config": {
+			givenConfig: `{"rules":`,
+			expectedErr: "failed to parse config",
+		},
+		"Unknown stage": {
+			givenConfig: `{"rules":[{"stage":"auction_response","actions":[{"type":"remove","path":"site"}]}]}`,
+			expectedErr: `invalid rules[0]: stage must be one of "processed_auction_request" or "bidder_request". Got "auction_response"`,
+		},
+		"No actions": {
+			givenConfig: `{"rules":[{"name":"empty"}]}`,
+			expectedErr: "invalid rules[0]: actions are required",
+		},
+		"Condition without path": {
+			givenConfig: `{"rules":[{"match":{"request":[{"exists":true}]},"actions":[{"type":"remove","path":"site"}]}]}`,
+			expectedErr: "invalid rules[0]: condition path is required",
+		},
+		"Invalid regexp": {
+			givenConfig: `{"rules":[{"match":{"imp":[{"path":"tagid","matches":"("}]},"actions":[{"type":"remove","path":"site"}]}]}`,
+			expectedErr: "invalid rules[0]: invalid tagid condition regexp",
+		},
+		"Unknown action": {
+			givenConfig: `{"rules":[{"actions":[{"type":"replace","path":"site"}]}]}`,
+			expectedErr: `invalid rules[0]: actions[0]: unsupported action type "replace"`,
+		},
+		"Set without value": {
+			givenConfig: `{"rules":[{"actions":[{"type":"set","path":"imp.secure"}]}]}`,
+			expectedErr: "invalid rules[0]: actions[0]: set action requires a value",
+		},
+		"Remove without path": {
+			givenConfig: `{"rules":[{"actions":[{"type":"remove","path":"imp."}]}]}`,
+			expectedErr: "invalid rules[0]: actions[0]: remove action requires a path",
+		},
+		"Exclude without bidder": {
+			givenConfig: `{"rules":[{"actions":[{"type":"exclude_bidder"}]}]}`,
+			expectedErr: "invalid rules[0]: actions[0]: exclude_bidder action requires a bidder at the processed_auction_request stage",
+		},
+		"Add bidder at bidder request stage": {
+			givenConfig: `{"rules":[{"stage":"bidder_request","actions":[{"type":"add_bidder","bidder":"appnexus"}]}]}`,
+			expectedErr: "invalid rules[0]: actions[0]: add_bidder action is only supported at the processed_auction_request stage",
+		},
+		"Negative floor": {
+			givenConfig: `{"rules":[{"actions":[{"type":"set_floor","floor":-1}]}]}`,
+			expectedErr: "invalid rules[0]: actions[0]: set_floor action floor must be >= 0. Got -1.000000",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := newConfig(json.RawMessage(test.givenConfig))
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, cfg.Rules, 2)
+			assert.Equal(t, stageProcessedAuctionRequest, cfg.Rules[0].Stage, "Stage should default to processed_auction_request.")
+			assert.NotNil(t, cfg.Rules[0].Match.Imp[0].matchesRegexp)
+		})
+	}
+}
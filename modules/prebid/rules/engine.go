@@ -0,0 +1,208 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/prebid/openrtb/v20/adcom1"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// evaluation is the outcome of the rules of a stage applied to a bid request.
+type evaluation struct {
+	request []byte
+	changed bool
+	// noImps is set when the bidder_request stage excluded the bidder from all the imps.
+	noImps  bool
+	results []hookanalytics.Result
+}
+
+// evaluate applies the rules of the stage to the JSON bid request.
+// The bidder is empty at the processed_auction_request stage.
+func evaluate(cfg config, stage, bidder string, request []byte) (evaluation, error) {
+	e := evaluation{request: request}
+	for _, r := range cfg.Rules {
+		if r.Stage != stage || !r.Match.matchesRequest(e.request, bidder) {
+			continue
+		}
+
+		imps := r.Match.matchingImps(e.request, stage)
+		if len(imps) == 0 {
+			continue
+		}
+
+		impIDs := make([]string, 0, len(imps))
+		for _, i := range imps {
+			impIDs = append(impIDs, gjson.GetBytes(e.request, impPath(i, "id")).String())
+		}
+
+		var err error
+		var excluded []int
+		for _, a := range r.Actions {
+			if excluded, err = a.apply(&e, imps, bidder, excluded); err != nil {
+				return e, fmt.Errorf("failed to apply %s action of rule %s: %s", a.Type, r.Name, err)
+			}
+		}
+
+		// the imps are removed last, so that the indexes of the other actions remain valid
+		slices.Sort(excluded)
+		for _, i := range slices.Backward(slices.Compact(excluded)) {
+			if e.request, err = sjson.DeleteBytes(e.request, fmt.Sprintf("imp.%d", i)); err != nil {
+				return e, fmt.Errorf("failed to exclude imp of rule %s: %s", r.Name, err)
+			}
+		}
+
+		e.changed = true
+		e.results = append(e.results, newRuleResult(r, bidder, impIDs, len(excluded) > 0))
+	}
+
+	e.noImps = stage == stageBidderRequest && e.changed && gjson.GetBytes(e.request, "imp.#").Int() == 0
+	return e, nil
+}
+
+func (m match) matchesRequest(request []byte, bidder string) bool {
+	for _, c := range m.Request {
+		if !c.matches(request, "") {
+			return false
+		}
+	}
+	if len(m.Countries) > 0 && !slices.Contains(m.Countries, gjson.GetBytes(request, "device.geo.country").String()) {
+		return false
+	}
+	if len(m.DeviceTypes) > 0 {
+		deviceType := gjson.GetBytes(request, "device.devicetype")
+		if !deviceType.Exists() || !slices.ContainsFunc(m.DeviceTypes, func(t adcom1.DeviceType) bool { return int64(t) == deviceType.Int() }) {
+			return false
+		}
+	}
+	if bidder != "" && len(m.Bidders) > 0 && !slices.Contains(m.Bidders, bidder) {
+		return false
+	}
+	return true
+}
+
+// matchingImps returns the indexes of the imps meeting the imp conditions.
+func (m match) matchingImps(request []byte, stage string) []int {
+	var imps []int
+	count := int(gjson.GetBytes(request, "imp.#").Int())
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("imp.%d.", i)
+		matched := true
+		for _, c := range m.Imp {
+			if !c.matches(request, prefix) {
+				matched = false
+				break
+			}
+		}
+		if matched && stage == stageProcessedAuctionRequest && len(m.Bidders) > 0 {
+			matched = slices.ContainsFunc(m.Bidders, func(bidder string) bool {
+				return gjson.GetBytes(request, prefix+"ext.prebid.bidder."+escapePathKey(bidder)).Exists()
+			})
+		}
+		if matched {
+			imps = append(imps, i)
+		}
+	}
+	return imps
+}
+
+func (c fieldCondition) matches(request []byte, prefix string) bool {
+	value := gjson.GetBytes(request, prefix+c.Path)
+	if c.Exists != nil && value.Exists() != *c.Exists {
+		return false
+	}
+	if c.Equals != nil && !equalValue(value, c.Equals) {
+		return false
+	}
+	if len(c.In) > 0 && !slices.ContainsFunc(c.In, func(expected interface{}) bool { return equalValue(value, expected) }) {
+		return false
+	}
+	if c.matchesRegexp != nil && (!value.Exists() || !c.matchesRegexp.MatchString(value.String())) {
+		return false
+	}
+	return true
+}
+
+// equalValue compares the values as decoded from JSON, numbers being float64.
+func equalValue(value gjson.Result, expected interface{}) bool {
+	return value.Exists() && reflect.DeepEqual(value.Value(), expected)
+}
+
+// apply applies the action to the request, returning the imps to exclude from the bidder request.
+func (a action) apply(e *evaluation, imps []int, bidder string, excluded []int) ([]int, error) {
+	var err error
+	switch a.Type {
+	case actionSet, actionRemove, actionAppend:
+		relPath, isImpPath := strings.CutPrefix(a.Path, impPathPrefix)
+		if !isImpPath {
+			return excluded, a.applyField(e, a.Path)
+		}
+		for _, i := range imps {
+			if err = a.applyField(e, impPath(i, relPath)); err != nil {
+				return excluded, err
+			}
+		}
+	case actionExcludeBidder:
+		if bidder != "" {
+			if a.Bidder == "" || a.Bidder == bidder {
+				excluded = append(excluded, imps...)
+			}
+			return excluded, nil
+		}
+		for _, i := range imps {
+			if e.request, err = sjson.DeleteBytes(e.request, impPath(i, "ext.prebid.bidder."+escapePathKey(a.Bidder))); err != nil {
+				return excluded, err
+			}
+		}
+	case actionAddBidder:
+		params := a.Params
+		if len(params) == 0 {
+			params = []byte(`{}`)
+		}
+		for _, i := range imps {
+			if e.request, err = sjson.SetRawBytes(e.request, impPath(i, "ext.prebid.bidder."+escapePathKey(a.Bidder)), params); err != nil {
+				return excluded, err
+			}
+		}
+	case actionSetFloor:
+		for _, i := range imps {
+			if e.request, err = sjson.SetBytes(e.request, impPath(i, "bidfloor"), a.Floor); err != nil {
+				return excluded, err
+			}
+			if a.Currency != "" {
+				if e.request, err = sjson.SetBytes(e.request, impPath(i, "bidfloorcur"), a.Currency); err != nil {
+					return excluded, err
+				}
+			}
+		}
+	}
+	return excluded, nil
+}
+
+func (a action) applyField(e *evaluation, path string) error {
+	var err error
+	switch a.Type {
+	case actionSet:
+		e.request, err = sjson.SetRawBytes(e.request, path, a.Value)
+	case actionRemove:
+		e.request, err = sjson.DeleteBytes(e.request, path)
+	case actionAppend:
+		e.request, err = sjson.SetRawBytes(e.request, path+".-1", a.Value)
+	}
+	return err
+}
+
+func impPath(i int, path string) string {
+	return fmt.Sprintf("imp.%d.%s", i, path)
+}
+
+// escapePathKey escapes the characters with a meaning in paths, bidder names being used as keys.
+func escapePathKey(key string) string {
+	return pathKeyEscaper.Replace(key)
+}
+
+var pathKeyEscaper = strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`, "|", `\|`, "#", `\#`, "@", `\@`)
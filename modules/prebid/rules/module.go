@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/openrtb/v20/openrtb3"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/prebid/prebid-server/v3/util/lruutil"
+)
+
+// maxCachedAccountConfigs bounds the parsed account configs kept by the module,
+// the least recently used ones are parsed again when they're needed.
+const maxCachedAccountConfigs = 10000
+
+func Builder(_ json.RawMessage, _ moduledeps.ModuleDeps) (interface{}, error) {
+	return newModule(), nil
+}
+
+func newModule() Module {
+	return Module{configs: lruutil.New[string, config](maxCachedAccountConfigs)}
+}
+
+type Module struct {
+	// configs are the parsed account configs, keyed by the account config
+	configs *lruutil.Cache[string, config]
+}
+
+// HandleProcessedAuctionHook applies the processed_auction_request rules of the account to the bid request.
+func (m Module) HandleProcessedAuctionHook(
+	_ context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.ProcessedAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload], error) {
+	result := hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload]{}
+	if len(miCtx.AccountConfig) == 0 {
+		return result, nil
+	}
+
+	cfg, err := m.accountConfig(miCtx.AccountConfig)
+	if err != nil {
+		return result, err
+	}
+
+	e, err := evaluateRequest(cfg, stageProcessedAuctionRequest, "", payload.Request)
+	if err != nil || !e.changed {
+		return result, err
+	}
+
+	result.AnalyticsTags = newApplyRulesTags(e.results)
+	result.ChangeSet.AddMutation(func(p hookstage.ProcessedAuctionRequestPayload) (hookstage.ProcessedAuctionRequestPayload, error) {
+		return p, setRequest(p.Request, e.request)
+	}, hookstage.MutationUpdate, "bidrequest")
+
+	return result, nil
+}
+
+// HandleBidderRequestHook applies the bidder_request rules of the account to the bidder request,
+// rejecting it if the bidder is excluded from all of its imps.
+func (m Module) HandleBidderRequestHook(
+	_ context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.BidderRequestPayload,
+) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
+	result := hookstage.HookResult[hookstage.BidderRequestPayload]{}
+	if len(miCtx.AccountConfig) == 0 {
+		return result, nil
+	}
+
+	cfg, err := m.accountConfig(miCtx.AccountConfig)
+	if err != nil {
+		return result, err
+	}
+
+	e, err := evaluateRequest(cfg, stageBidderRequest, payload.Bidder, payload.Request)
+	if err != nil || !e.changed {
+		return result, err
+	}
+
+	result.AnalyticsTags = newApplyRulesTags(e.results)
+	if e.noImps {
+		result.Reject = true
+		result.NbrCode = int(openrtb3.NoBidInvalidRequest)
+		result.Message = fmt.Sprintf("bidder %s excluded from all imps", payload.Bidder)
+		return result, nil
+	}
+
+	result.ChangeSet.AddMutation(func(p hookstage.BidderRequestPayload) (hookstage.BidderRequestPayload, error) {
+		return p, setRequest(p.Request, e.request)
+	}, hookstage.MutationUpdate, "bidrequest")
+
+	return result, nil
+}
+
+// accountConfig returns the parsed account config, which is only parsed when it isn't cached.
+func (m Module) accountConfig(data json.RawMessage) (config, error) {
+	if cfg, ok := m.configs.Get(string(data)); ok {
+		return cfg, nil
+	}
+
+	cfg, err := newConfig(data)
+	if err != nil {
+		return cfg, err
+	}
+	m.configs.Add(string(data), cfg)
+	return cfg, nil
+}
+
+// evaluateRequest serializes the request for the rules only when some of them apply at the stage.
+func evaluateRequest(cfg config, stage, bidder string, request *openrtb_ext.RequestWrapper) (evaluation, error) {
+	if !cfg.hasStage(stage) {
+		return evaluation{}, nil
+	}
+	if request == nil || request.BidRequest == nil {
+		return evaluation{}, errors.New("payload contains a nil bid request")
+	}
+	if err := request.RebuildRequest(); err != nil {
+		return evaluation{}, err
+	}
+
+	data, err := jsonutil.Marshal(request.BidRequest)
+	if err != nil {
+		return evaluation{}, err
+	}
+	return evaluate(cfg, stage, bidder, data)
+}
+
+// setRequest replaces the bid request in place, the wrapper is reset so that
+// the extensions it cached are parsed again from the new request.
+func setRequest(request *openrtb_ext.RequestWrapper, data []byte) error {
+	var bidRequest openrtb2.BidRequest
+	if err := jsonutil.UnmarshalValid(data, &bidRequest); err != nil {
+		return fmt.Errorf("failed to apply rules: %s", err)
+	}
+	*request.BidRequest = bidRequest
+	*request = openrtb_ext.RequestWrapper{BidRequest: request.BidRequest}
+	return nil
+}
@@ -0,0 +1,270 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/openrtb/v20/openrtb3"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/lruutil"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRequest() *openrtb2.BidRequest {
+	return &openrtb2.BidRequest{
+		ID: "req-id",
+		Imp: []openrtb2.Imp{
+			{ID: "imp-1", TagID: "top-banner", Ext: json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":1},"rubicon":{"zoneId":2}}}}`)},
+			{ID: "imp-2", TagID: "sidebar", Ext: json.RawMessage(`{"prebid":{"bidder":{"rubicon":{"zoneId":3}}}}`)},
+		},
+		Site:   &openrtb2.Site{Domain: "example.com"},
+		Device: &openrtb2.Device{DeviceType: 4, Geo: &openrtb2.Geo{Country: "USA"}},
+	}
+}
+
+func TestHandleProcessedAuctionHook(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig     string
+		expectedRequest func(*openrtb2.BidRequest)
+		expectedResults []hookanalytics.Result
+	}{
+		"Set field on matched imps": {
+			givenConfig: `{"rules":[{"name":"secure","match":{"imp":[{"path":"tagid","matches":"^top-"}]},"actions":[{"type":"set","path":"imp.secure","value":1}]}]}`,
+			expectedRequest: func(r *openrtb2.BidRequest) {
+				r.Imp[0].Secure = ptrutil.ToPtr[int8](1)
+			},
+			expectedResults: []hookanalytics.Result{ruleResult("secure", "", []string{"imp-1"}, "set")},
+		},
+		"Set and append request fields": {
+			givenConfig: `{"rules":[{"name":"site-data","match":{"request":[{"path":"site.domain","in":["example.com","example.org"]}]},"actions":[
+				{"type":"set","path":"site.ext.data.section","value":"news"},
+				{"type":"append","path":"bcat","value":"IAB25"}
+			]}]}`,
+			expectedRequest: func(r *openrtb2.BidRequest) {
+				r.Site.Ext = json.RawMessage(`{"data":{"section":"news"}}`)
+				r.BCat = []string{"IAB25"}
+			},
+			expectedResults: []hookanalytics.Result{ruleResult("site-data", "", []string{"imp-1", "imp-2"}, "set", "append")},
+		},
+		"Rewrite tag ID and set floor of imps of a bidder": {
+			givenConfig: `{"rules":[{"name":"appnexus","match":{"bidders":["appnexus"]},"actions":[
+				{"type":"set","path":"imp.tagid","value":"appnexus-top"},
+				{"type":"set_floor","floor":1.5,"currency":"EUR"}
+			]}]}`,
+			expectedRequest: func(r *openrtb2.BidRequest) {
+				r.Imp[0].TagID = "appnexus-top"
+				r.Imp[0].BidFloor = 1.5
+				r.Imp[0].BidFloorCur = "EUR"
+			},
+			expectedResults: []hookanalytics.Result{ruleResult("appnexus", "", []string{"imp-1"}, "set", "set_floor")},
+		},
+		"Exclude and add bidders on device and country": {
+			givenConfig: `{"rules":[{"name":"swap","match":{"countries":["USA"],"device_types":[4,5]},"actions":[
+				{"type":"exclude_bidder","bidder":"rubicon"},
+				{"type":"add_bidder","bidder":"openx","params":{"unit":"1"}}
+			]}]}`,
+			expectedRequest: func(r *openrtb2.BidRequest) {
+				r.Imp[0].Ext = json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":1},"openx":{"unit":"1"}}}}`)
+				r.Imp[1].Ext = json.RawMessage(`{"prebid":{"bidder":{"openx":{"unit":"1"}}}}`)
+			},
+			expectedResults: []hookanalytics.Result{ruleResult("swap", "", []string{"imp-1", "imp-2"}, "exclude_bidder", "add_bidder")},
+		},
+		"Remove field": {
+			givenConfig: `{"rules":[{"name":"no-site-domain","match":{"request":[{"path":"site.domain","exists":true}]},"actions":[{"type":"remove","path":"site.domain"}]}]}`,
+			expectedRequest: func(r *openrtb2.BidRequest) {
+				r.Site.Domain = ""
+			},
+			expectedResults: []hookanalytics.Result{ruleResult("no-site-domain", "", []string{"imp-1", "imp-2"}, "remove")},
+		},
+		"Rules of other stage are skipped": {
+			givenConfig: `{"rules":[{"stage":"bidder_request","actions":[{"type":"remove","path":"site"}]}]}`,
+		},
+		"Unmatched request conditions": {
+			givenConfig: `{"rules":[
+				{"match":{"request":[{"path":"site.domain","equals":"other.com"}]},"actions":[{"type":"remove","path":"site"}]},
+				{"match":{"countries":["CAN"]},"actions":[{"type":"remove","path":"site"}]},
+				{"match":{"device_types":[1]},"actions":[{"type":"remove","path":"site"}]},
+				{"match":{"request":[{"path":"app","exists":true}]},"actions":[{"type":"remove","path":"site"}]}
+			]}`,
+		},
+		"No matched imps": {
+			givenConfig: `{"rules":[{"match":{"imp":[{"path":"tagid","equals":"footer"}]},"actions":[{"type":"remove","path":"site"}]}]}`,
+		},
+		"No rules": {
+			givenConfig: `{"rules":[]}`,
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			request := &openrtb_ext.RequestWrapper{BidRequest: newTestRequest()}
+			payload := hookstage.ProcessedAuctionRequestPayload{Request: request}
+			miCtx := hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(test.givenConfig)}
+
+			result, err := newModule().HandleProcessedAuctionHook(context.Background(), miCtx, payload)
+			require.NoError(t, err)
+
+			if test.expectedRequest == nil {
+				assert.Empty(t, result.ChangeSet.Mutations())
+				assert.Empty(t, result.AnalyticsTags.Activities)
+				return
+			}
+
+			require.Len(t, result.ChangeSet.Mutations(), 1)
+			_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+			require.NoError(t, err)
+
+			expectedRequest := newTestRequest()
+			test.expectedRequest(expectedRequest)
+			assertEqualRequests(t, expectedRequest, request.BidRequest)
+			assert.Equal(t, newApplyRulesTags(test.expectedResults), result.AnalyticsTags)
+		})
+	}
+}
+
+func TestHandleBidderRequestHook(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig     string
+		givenBidder     string
+		expectedReject  bool
+		expectedImpIDs  []string
+		expectedResults []hookanalytics.Result
+	}{
+		"Exclude bidder from matched imps": {
+			givenConfig:     `{"rules":[{"name":"no-sidebar","stage":"bidder_request","match":{"bidders":["rubicon"],"imp":[{"path":"tagid","equals":"sidebar"}]},"actions":[{"type":"exclude_bidder"}]}]}`,
+			givenBidder:     "rubicon",
+			expectedImpIDs:  []string{"imp-1"},
+			expectedResults: []hookanalytics.Result{blockedRuleResult("no-sidebar", "rubicon", []string{"imp-2"})},
+		},
+		"Reject bidder excluded from all imps": {
+			givenConfig:     `{"rules":[{"name":"no-rubicon","stage":"bidder_request","actions":[{"type":"exclude_bidder","bidder":"rubicon"}]}]}`,
+			givenBidder:     "rubicon",
+			expectedReject:  true,
+			expectedResults: []hookanalytics.Result{blockedRuleResult("no-rubicon", "rubicon", []string{"imp-1", "imp-2"})},
+		},
+		"Other bidder is not excluded": {
+			givenConfig:     `{"rules":[{"name":"no-rubicon","stage":"bidder_request","actions":[{"type":"exclude_bidder","bidder":"rubicon"}]}]}`,
+			givenBidder:     "appnexus",
+			expectedImpIDs:  []string{"imp-1", "imp-2"},
+			expectedResults: []hookanalytics.Result{ruleResult("no-rubicon", "appnexus", []string{"imp-1", "imp-2"}, "exclude_bidder")},
+		},
+		"Unmatched bidder": {
+			givenConfig: `{"rules":[{"stage":"bidder_request","match":{"bidders":["rubicon"]},"actions":[{"type":"exclude_bidder"}]}]}`,
+			givenBidder: "appnexus",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			request := &openrtb_ext.RequestWrapper{BidRequest: newTestRequest()}
+			payload := hookstage.BidderRequestPayload{Request: request, Bidder: test.givenBidder}
+			miCtx := hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(test.givenConfig)}
+
+			result, err := newModule().HandleBidderRequestHook(context.Background(), miCtx, payload)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedReject, result.Reject)
+
+			if test.expectedReject {
+				assert.Equal(t, int(openrtb3.NoBidInvalidRequest), result.NbrCode)
+				assert.Empty(t, result.ChangeSet.Mutations())
+			} else if test.expectedImpIDs != nil {
+				require.Len(t, result.ChangeSet.Mutations(), 1)
+				_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+				require.NoError(t, err)
+
+				var impIDs []string
+				for _, imp := range request.Imp {
+					impIDs = append(impIDs, imp.ID)
+				}
+				assert.Equal(t, test.expectedImpIDs, impIDs)
+			} else {
+				assert.Empty(t, result.ChangeSet.Mutations())
+			}
+
+			if test.expectedResults != nil {
+				assert.Equal(t, newApplyRulesTags(test.expectedResults), result.AnalyticsTags)
+			}
+		})
+	}
+}
+
+func TestHandleHookWithoutAccountConfig(t *testing.T) {
+	request := &openrtb_ext.RequestWrapper{BidRequest: newTestRequest()}
+
+	result, err := newModule().HandleProcessedAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.ProcessedAuctionRequestPayload{Request: request})
+	assert.NoError(t, err)
+	assert.Equal(t, hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload]{}, result)
+}
+
+func TestHandleHookWithInvalidAccountConfig(t *testing.T) {
+	request := &openrtb_ext.RequestWrapper{BidRequest: newTestRequest()}
+	miCtx := hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(`{"rules":[{"actions":[]}]}`)}
+
+	_, err := newModule().HandleBidderRequestHook(context.Background(), miCtx, hookstage.BidderRequestPayload{Request: request, Bidder: "appnexus"})
+	assert.EqualError(t, err, "invalid rules[0]: actions are required")
+}
+
+func ruleResult(name, bidder string, impIDs []string, actions ...string) hookanalytics.Result {
+	return hookanalytics.Result{
+		Status:    hookanalytics.ResultStatusModify,
+		Values:    map[string]interface{}{ruleAnalyticKey: name, actionsAnalyticKey: actions},
+		AppliedTo: hookanalytics.AppliedTo{Bidder: bidder, ImpIds: impIDs, Request: true},
+	}
+}
+
+func blockedRuleResult(name, bidder string, impIDs []string) hookanalytics.Result {
+	result := ruleResult(name, bidder, impIDs, actionExcludeBidder)
+	result.Status = hookanalytics.ResultStatusBlock
+	return result
+}
+
+// assertEqualRequests compares the requests as JSON, the rules changing the formatting of the extensions.
+func assertEqualRequests(t *testing.T, expected, actual *openrtb2.BidRequest) {
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestHandleHookWithoutRulesOfStage(t *testing.T) {
+	miCtx := hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(`{"rules":[{"stage":"bidder_request","actions":[{"type":"exclude_bidder"}]}]}`)}
+
+	// the request isn't read, it would fail otherwise
+	result, err := newModule().HandleProcessedAuctionHook(context.Background(), miCtx, hookstage.ProcessedAuctionRequestPayload{Request: &openrtb_ext.RequestWrapper{}})
+	assert.NoError(t, err)
+	assert.Equal(t, hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload]{}, result)
+}
+
+func TestAccountConfig(t *testing.T) {
+	m := Module{configs: lruutil.New[string, config](1)}
+
+	data := json.RawMessage(`{"rules":[{"name":"rule","match":{"request":[{"path":"site.domain","matches":"^example"}]},"actions":[{"type":"remove","path":"site"}]}]}`)
+	cfg, err := m.accountConfig(data)
+	require.NoError(t, err)
+	require.Len(t, cfg.Rules, 1)
+
+	cached, ok := m.configs.Get(string(data))
+	require.True(t, ok, "The parsed account config should be cached.")
+	assert.Same(t, cfg.Rules[0].Match.Request[0].matchesRegexp, cached.Rules[0].Match.Request[0].matchesRegexp)
+
+	cfg, err = m.accountConfig(data)
+	require.NoError(t, err)
+	assert.Same(t, cached.Rules[0].Match.Request[0].matchesRegexp, cfg.Rules[0].Match.Request[0].matchesRegexp, "The regexps shouldn't be compiled again.")
+
+	_, err = m.accountConfig(json.RawMessage(`{"rules":[{"actions":[]}]}`))
+	assert.EqualError(t, err, "invalid rules[0]: actions are required")
+	assert.Equal(t, 1, m.configs.Len(), "Invalid account configs shouldn't be cached.")
+
+	otherData := json.RawMessage(`{"rules":[]}`)
+	_, err = m.accountConfig(otherData)
+	require.NoError(t, err)
+	_, ok = m.configs.Get(string(data))
+	assert.False(t, ok, "The least recently used account config should be evicted.")
+}
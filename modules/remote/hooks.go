@@ -0,0 +1,145 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+func (m *Module) HandleEntrypointHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.EntrypointPayload,
+) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	return invoke(ctx, m, hooks.StageEntrypoint.String(), "", miCtx, payload, document[hookstage.EntrypointPayload]{
+		get: func(p hookstage.EntrypointPayload) ([]byte, error) {
+			return bodyDocument(p.Body)
+		},
+		set: func(p hookstage.EntrypointPayload, data []byte) (hookstage.EntrypointPayload, error) {
+			p.Body = data
+			return p, nil
+		},
+	})
+}
+
+func (m *Module) HandleRawAuctionHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.RawAuctionRequestPayload], error) {
+	return invoke(ctx, m, hooks.StageRawAuctionRequest.String(), "", miCtx, payload, document[hookstage.RawAuctionRequestPayload]{
+		get: func(p hookstage.RawAuctionRequestPayload) ([]byte, error) {
+			return bodyDocument(p)
+		},
+		set: func(_ hookstage.RawAuctionRequestPayload, data []byte) (hookstage.RawAuctionRequestPayload, error) {
+			return data, nil
+		},
+	})
+}
+
+func (m *Module) HandleProcessedAuctionHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.ProcessedAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload], error) {
+	return invoke(ctx, m, hooks.StageProcessedAuctionRequest.String(), "", miCtx, payload, document[hookstage.ProcessedAuctionRequestPayload]{
+		get: func(p hookstage.ProcessedAuctionRequestPayload) ([]byte, error) {
+			return requestDocument(p.Request)
+		},
+		set: func(p hookstage.ProcessedAuctionRequestPayload, data []byte) (hookstage.ProcessedAuctionRequestPayload, error) {
+			return p, setRequestDocument(p.Request, data)
+		},
+	})
+}
+
+func (m *Module) HandleBidderRequestHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.BidderRequestPayload,
+) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
+	return invoke(ctx, m, hooks.StageBidderRequest.String(), payload.Bidder, miCtx, payload, document[hookstage.BidderRequestPayload]{
+		get: func(p hookstage.BidderRequestPayload) ([]byte, error) {
+			return requestDocument(p.Request)
+		},
+		set: func(p hookstage.BidderRequestPayload, data []byte) (hookstage.BidderRequestPayload, error) {
+			return p, setRequestDocument(p.Request, data)
+		},
+	})
+}
+
+func (m *Module) HandleAuctionResponseHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.AuctionResponsePayload,
+) (hookstage.HookResult[hookstage.AuctionResponsePayload], error) {
+	return invoke(ctx, m, hooks.StageAuctionResponse.String(), "", miCtx, payload, document[hookstage.AuctionResponsePayload]{
+		get: func(p hookstage.AuctionResponsePayload) ([]byte, error) {
+			if p.BidResponse == nil {
+				return nil, errors.New("payload contains a nil bid response")
+			}
+			return jsonutil.Marshal(p.BidResponse)
+		},
+		set: func(p hookstage.AuctionResponsePayload, data []byte) (hookstage.AuctionResponsePayload, error) {
+			var response openrtb2.BidResponse
+			if err := jsonutil.UnmarshalValid(data, &response); err != nil {
+				return p, err
+			}
+			// the payload is discarded by the executor, the response is changed in place
+			*p.BidResponse = response
+			return p, nil
+		},
+	})
+}
+
+func (m *Module) HandleExitpointHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.ExitpointPayload,
+) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+	return invoke(ctx, m, hooks.StageExitpoint.String(), "", miCtx, payload, document[hookstage.ExitpointPayload]{
+		get: func(p hookstage.ExitpointPayload) ([]byte, error) {
+			return bodyDocument(p.Body)
+		},
+		set: func(p hookstage.ExitpointPayload, data []byte) (hookstage.ExitpointPayload, error) {
+			p.Body = data
+			return p, nil
+		},
+	})
+}
+
+// bodyDocument returns the body as is when it's a JSON document and as a JSON string otherwise,
+// like the VAST XML of the video endpoint. Mutations can't be applied to a string.
+func bodyDocument(body []byte) ([]byte, error) {
+	if len(body) == 0 || json.Valid(body) {
+		return body, nil
+	}
+	return jsonutil.Marshal(string(body))
+}
+
+func requestDocument(request *openrtb_ext.RequestWrapper) ([]byte, error) {
+	if request == nil || request.BidRequest == nil {
+		return nil, errors.New("payload contains a nil bid request")
+	}
+	if err := request.RebuildRequest(); err != nil {
+		return nil, err
+	}
+	return jsonutil.Marshal(request.BidRequest)
+}
+
+// setRequestDocument replaces the bid request in place, keeping the pointers held by the caller,
+// and resets the wrapper so that the cached extensions are parsed again from the new request.
+func setRequestDocument(request *openrtb_ext.RequestWrapper, data []byte) error {
+	var bidRequest openrtb2.BidRequest
+	if err := jsonutil.UnmarshalValid(data, &bidRequest); err != nil {
+		return err
+	}
+	*request.BidRequest = bidRequest
+	*request = openrtb_ext.RequestWrapper{BidRequest: request.BidRequest}
+	return nil
+}
@@ -0,0 +1,37 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/buger/jsonparser"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+)
+
+func mutationType(mutation Mutation) (hookstage.MutationType, error) {
+	if len(mutation.Path) == 0 {
+		return 0, errors.New("mutation path is required")
+	}
+
+	switch mutation.Type {
+	case hookstage.MutationAdd.String():
+		return hookstage.MutationAdd, nil
+	case hookstage.MutationUpdate.String():
+		return hookstage.MutationUpdate, nil
+	case hookstage.MutationDelete.String():
+		return hookstage.MutationDelete, nil
+	}
+	return 0, fmt.Errorf(`unsupported mutation type "%s"`, mutation.Type)
+}
+
+func applyMutation(data []byte, mutation Mutation) ([]byte, error) {
+	if mutation.Type == hookstage.MutationDelete.String() {
+		return jsonparser.Delete(data, mutation.Path...), nil
+	}
+
+	if !json.Valid(mutation.Value) {
+		return nil, fmt.Errorf("invalid %s mutation value at %v", mutation.Type, mutation.Path)
+	}
+	return jsonparser.Set(data, mutation.Value, mutation.Path...)
+}
@@ -0,0 +1,203 @@
+// Package remote provides hook modules running out of process.
+//
+// A remote module is configured like any other module, under hooks.modules, with a "remote" object
+// instead of a compiled module package:
+//
+//	{"enabled": true, "remote": {"endpoint": "http://localhost:8080/hooks"}}
+//
+// Every hook invocation is sent to the endpoint as a [Request] holding the stage payload,
+// the service answers with a [Response] whose mutations are applied through the hook change set.
+// Endpoints with the "grpc://" scheme are called over gRPC with JSON encoded messages,
+// other endpoints are called with an HTTP POST.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// Request is the message sent to the remote service for a hook invocation.
+type Request struct {
+	Stage         string                  `json:"stage"`
+	Module        string                  `json:"module"`
+	Endpoint      string                  `json:"endpoint"`
+	Bidder        string                  `json:"bidder,omitempty"`
+	AccountConfig json.RawMessage         `json:"account_config,omitempty"`
+	ModuleContext hookstage.ModuleContext `json:"module_context,omitempty"`
+	// Payload is the JSON document the stage works on: the request body for the entrypoint,
+	// raw_auction_request and exitpoint stages, the bid request for the processed_auction_request and
+	// bidder_request stages and the bid response for the auction_response stage.
+	// A body which isn't a JSON document is sent as a JSON string.
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Response is the message the remote service answers a hook invocation with.
+type Response struct {
+	Reject         bool                    `json:"reject,omitempty"`
+	NbrCode        int                     `json:"nbr_code,omitempty"`
+	RejectResponse *RejectResponse         `json:"reject_response,omitempty"`
+	Message        string                  `json:"message,omitempty"`
+	Mutations      []Mutation              `json:"mutations,omitempty"`
+	Errors         []string                `json:"errors,omitempty"`
+	Warnings       []string                `json:"warnings,omitempty"`
+	DebugMessages  []string                `json:"debug_messages,omitempty"`
+	AnalyticsTags  hookanalytics.Analytics `json:"analytics_tags,omitempty"`
+	ModuleContext  hookstage.ModuleContext `json:"module_context,omitempty"`
+}
+
+// RejectResponse is the HTTP response the request is rejected with, instead of the no-bid response.
+type RejectResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+// Mutation is a change of the request payload, the value at the path is set by the "add" and "update"
+// mutations and removed by the "delete" ones. Array elements are addressed with the "[index]" path key.
+type Mutation struct {
+	Type  string          `json:"type"`
+	Path  []string        `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type config struct {
+	Remote struct {
+		Endpoint string `json:"endpoint"`
+	} `json:"remote"`
+}
+
+// transport calls the remote service.
+type transport interface {
+	call(ctx context.Context, req *Request) (*Response, error)
+	close() error
+}
+
+// Module forwards the hook invocations of the supported stages to a remote service.
+// The raw_bidder_response and all_processed_bid_responses stages aren't supported,
+// their payloads have no JSON representation.
+type Module struct {
+	id        string
+	transport transport
+}
+
+// NewModule returns the remote module with the given "vendor.module_name" ID.
+func NewModule(id string, cfg json.RawMessage, deps moduledeps.ModuleDeps) (*Module, error) {
+	var c config
+	if err := jsonutil.UnmarshalValid(cfg, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %s", err)
+	}
+	if c.Remote.Endpoint == "" {
+		return nil, errors.New("remote.endpoint is required")
+	}
+
+	endpoint, err := url.Parse(c.Remote.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote.endpoint: %s", err)
+	}
+
+	var t transport
+	switch endpoint.Scheme {
+	case "grpc":
+		t, err = newGRPCTransport(endpoint.Host)
+	case "http", "https":
+		t, err = newHTTPTransport(c.Remote.Endpoint, deps.HTTPClient)
+	default:
+		err = fmt.Errorf(`remote.endpoint scheme must be one of "http", "https" or "grpc". Got "%s"`, endpoint.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Module{id: id, transport: t}, nil
+}
+
+// Start does nothing, connections to the remote service are opened on demand.
+func (m *Module) Start(_ context.Context) error {
+	return nil
+}
+
+// Shutdown closes the connections to the remote service.
+func (m *Module) Shutdown(_ context.Context) error {
+	return m.transport.close()
+}
+
+// document converts a stage payload to the JSON document sent to the remote service and back.
+type document[T any] struct {
+	get func(T) ([]byte, error)
+	set func(T, []byte) (T, error)
+}
+
+func invoke[T any](
+	ctx context.Context,
+	m *Module,
+	stage string,
+	bidder string,
+	miCtx hookstage.ModuleInvocationContext,
+	payload T,
+	doc document[T],
+) (hookstage.HookResult[T], error) {
+	result := hookstage.HookResult[T]{}
+
+	data, err := doc.get(payload)
+	if err != nil {
+		return result, err
+	}
+
+	resp, err := m.transport.call(ctx, &Request{
+		Stage:         stage,
+		Module:        m.id,
+		Endpoint:      miCtx.Endpoint,
+		Bidder:        bidder,
+		AccountConfig: miCtx.AccountConfig,
+		ModuleContext: miCtx.ModuleContext,
+		Payload:       data,
+	})
+	if err != nil {
+		return result, fmt.Errorf("remote module call failed: %s", err)
+	}
+
+	for _, mutation := range resp.Mutations {
+		mutType, err := mutationType(mutation)
+		if err != nil {
+			return result, err
+		}
+
+		mutation := mutation
+		result.ChangeSet.AddMutation(func(p T) (T, error) {
+			data, err := doc.get(p)
+			if err != nil {
+				return p, err
+			}
+			if data, err = applyMutation(data, mutation); err != nil {
+				return p, err
+			}
+			return doc.set(p, data)
+		}, mutType, mutation.Path...)
+	}
+
+	result.Reject = resp.Reject
+	result.NbrCode = resp.NbrCode
+	if resp.RejectResponse != nil {
+		result.RejectResponse = &hookstage.HTTPResponse{
+			StatusCode: resp.RejectResponse.StatusCode,
+			Header:     resp.RejectResponse.Headers,
+			Body:       []byte(resp.RejectResponse.Body),
+		}
+	}
+	result.Message = resp.Message
+	result.Errors = resp.Errors
+	result.Warnings = resp.Warnings
+	result.DebugMessages = resp.DebugMessages
+	result.AnalyticsTags = resp.AnalyticsTags
+	result.ModuleContext = resp.ModuleContext
+
+	return result, nil
+}
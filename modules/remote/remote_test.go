@@ -0,0 +1,261 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestNewModule(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig string
+		expectedErr string
+	}{
+		"HTTP endpoint":   {givenConfig: `{"remote":{"endpoint":"http://localhost/hooks"}}`},
+		"HTTPS endpoint":  {givenConfig: `{"remote":{"endpoint":"https://localhost/hooks"}}`},
+		"gRPC endpoint":   {givenConfig: `{"remote":{"endpoint":"grpc://localhost:9000"}}`},
+		"Missing remote":  {givenConfig: `{"enabled":true}`, expectedErr: "remote.endpoint is required"},
+		"Unknown scheme":  {givenConfig: `{"remote":{"endpoint":"ftp://localhost"}}`, expectedErr: `remote.endpoint scheme must be one of "http", "https" or "grpc". Got "ftp"`},
+		"Malformed":       {givenConfig: `{"remote":`, expectedErr: "failed to parse config"},
+		"Invalid address": {givenConfig: `{"remote":{"endpoint":"http://local host"}}`, expectedErr: "invalid remote.endpoint"},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			module, err := NewModule("acme.remote", json.RawMessage(test.givenConfig), moduledeps.ModuleDeps{})
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, module.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestHandleProcessedAuctionHook(t *testing.T) {
+	var received Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte(`{
+			"mutations": [
+				{"type": "update", "path": ["site", "domain"], "value": "example.com"},
+				{"type": "delete", "path": ["imp", "[0]", "ext"]}
+			],
+			"warnings": ["changed domain"],
+			"analytics_tags": {"activities": [{"name": "domain", "status": "success"}]},
+			"module_context": {"seen": true}
+		}`))
+	}))
+	defer server.Close()
+
+	module := newTestModule(t, server.URL)
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+		ID:   "req-id",
+		Site: &openrtb2.Site{Domain: "old.com"},
+		Imp:  []openrtb2.Imp{{ID: "imp-id", Ext: json.RawMessage(`{"foo":"bar"}`)}},
+	}}
+	payload := hookstage.ProcessedAuctionRequestPayload{Request: request}
+	miCtx := hookstage.ModuleInvocationContext{
+		Endpoint:      "/openrtb2/auction",
+		AccountConfig: json.RawMessage(`{"enabled":true}`),
+		ModuleContext: hookstage.ModuleContext{"from": "entrypoint"},
+	}
+
+	result, err := module.HandleProcessedAuctionHook(context.Background(), miCtx, payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, "processed_auction_request", received.Stage)
+	assert.Equal(t, "acme.remote", received.Module)
+	assert.Equal(t, "/openrtb2/auction", received.Endpoint)
+	assert.JSONEq(t, `{"enabled":true}`, string(received.AccountConfig))
+	assert.Equal(t, hookstage.ModuleContext{"from": "entrypoint"}, received.ModuleContext)
+	assert.JSONEq(t, `{"id":"req-id","site":{"domain":"old.com"},"imp":[{"id":"imp-id","ext":{"foo":"bar"}}]}`, string(received.Payload))
+
+	assert.Equal(t, []string{"changed domain"}, result.Warnings)
+	assert.Equal(t, hookanalytics.Analytics{Activities: []hookanalytics.Activity{{Name: "domain", Status: hookanalytics.ActivityStatusSuccess}}}, result.AnalyticsTags)
+	assert.Equal(t, hookstage.ModuleContext{"seen": true}, result.ModuleContext)
+
+	mutations := result.ChangeSet.Mutations()
+	require.Len(t, mutations, 2)
+	assert.Equal(t, hookstage.MutationUpdate, mutations[0].Type())
+	assert.Equal(t, []string{"site", "domain"}, mutations[0].Key())
+	for _, mutation := range mutations {
+		_, err := mutation.Apply(payload)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "example.com", request.Site.Domain, "Bid request should be changed in place.")
+	assert.Empty(t, request.Imp[0].Ext)
+}
+
+func TestHandleEntrypointHookReject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"reject": true, "nbr_code": 12, "message": "blocked"}`))
+	}))
+	defer server.Close()
+
+	module := newTestModule(t, server.URL)
+	result, err := module.HandleEntrypointHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.EntrypointPayload{Body: []byte(`{}`)})
+	require.NoError(t, err)
+
+	assert.True(t, result.Reject)
+	assert.Equal(t, 12, result.NbrCode)
+	assert.Equal(t, "blocked", result.Message)
+	assert.Nil(t, result.RejectResponse)
+}
+
+func TestHandleEntrypointHookRejectResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"reject": true, "reject_response": {"status_code": 429, "headers": {"Retry-After": ["30"]}, "body": "rate limit exceeded"}}`))
+	}))
+	defer server.Close()
+
+	module := newTestModule(t, server.URL)
+	result, err := module.HandleEntrypointHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.EntrypointPayload{Body: []byte(`{}`)})
+	require.NoError(t, err)
+
+	assert.True(t, result.Reject)
+	expected := &hookstage.HTTPResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"30"}},
+		Body:       []byte("rate limit exceeded"),
+	}
+	assert.Equal(t, expected, result.RejectResponse)
+}
+
+func TestHandleExitpointHookNonJSONBody(t *testing.T) {
+	var received Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte(`{"mutations": [{"type": "update", "path": ["vast"], "value": "1"}]}`))
+	}))
+	defer server.Close()
+
+	module := newTestModule(t, server.URL)
+	payload := hookstage.ExitpointPayload{Body: []byte(`<VAST version="3.0"></VAST>`)}
+	result, err := module.HandleExitpointHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+
+	var body string
+	require.NoError(t, json.Unmarshal(received.Payload, &body), "Body should be sent as a JSON string.")
+	assert.Equal(t, `<VAST version="3.0"></VAST>`, body)
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+	_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+	assert.Error(t, err, "Mutations can't be applied to a non JSON body.")
+}
+
+func TestHandleAuctionResponseHookErrors(t *testing.T) {
+	testCases := map[string]struct {
+		givenHandler http.HandlerFunc
+		givenTimeout time.Duration
+		expectedErr  string
+	}{
+		"Unexpected status": {
+			givenHandler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) },
+			expectedErr:  "remote module call failed: unexpected status code 500",
+		},
+		"Malformed response": {
+			givenHandler: func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"reject":`)) },
+			expectedErr:  "remote module call failed",
+		},
+		"Unsupported mutation": {
+			givenHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"mutations": [{"type": "replace", "path": ["id"]}]}`))
+			},
+			expectedErr: `unsupported mutation type "replace"`,
+		},
+		"Mutation without path": {
+			givenHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"mutations": [{"type": "delete"}]}`))
+			},
+			expectedErr: "mutation path is required",
+		},
+		"Timeout": {
+			givenHandler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(50 * time.Millisecond)
+			},
+			givenTimeout: 10 * time.Millisecond,
+			expectedErr:  "context deadline exceeded",
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(test.givenHandler)
+			defer server.Close()
+
+			ctx := context.Background()
+			if test.givenTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.givenTimeout)
+				defer cancel()
+			}
+
+			module := newTestModule(t, server.URL)
+			payload := hookstage.AuctionResponsePayload{BidResponse: &openrtb2.BidResponse{ID: "resp-id"}}
+			_, err := module.HandleAuctionResponseHook(ctx, hookstage.ModuleInvocationContext{}, payload)
+			assert.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestHandleBidderRequestHookOverGRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var received Request
+	server := grpc.NewServer(grpc.ForceServerCodec(JSONCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "prebid.hooks.RemoteModule",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Invoke",
+			Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				if err := dec(&received); err != nil {
+					return nil, err
+				}
+				return &Response{Mutations: []Mutation{{Type: "add", Path: []string{"bcat"}, Value: json.RawMessage(`["IAB1"]`)}}}, nil
+			},
+		}},
+	}, struct{}{})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	module := newTestModule(t, "grpc://"+listener.Addr().String())
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "req-id"}}
+	payload := hookstage.BidderRequestPayload{Request: request, Bidder: "appnexus"}
+
+	result, err := module.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+	assert.Equal(t, "bidder_request", received.Stage)
+	assert.Equal(t, "appnexus", received.Bidder)
+
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+	_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"IAB1"}, request.BCat)
+}
+
+func newTestModule(t *testing.T, endpoint string) *Module {
+	cfg, err := json.Marshal(map[string]interface{}{"remote": map[string]string{"endpoint": endpoint}})
+	require.NoError(t, err)
+
+	module, err := NewModule("acme.remote", cfg, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+	t.Cleanup(func() { module.Shutdown(context.Background()) })
+	return module
+}
@@ -0,0 +1,110 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GRPCMethod is the method invoked on the remote service for every hook invocation.
+// The service has to register it with a codec named "json" decoding a [Request] and encoding a [Response].
+const GRPCMethod = "/prebid.hooks.RemoteModule/Invoke"
+
+type httpTransport struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newHTTPTransport(endpoint string, client *http.Client) (*httpTransport, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpTransport{endpoint: endpoint, client: client}, nil
+}
+
+// call relies on the context to stop waiting for the service once the hook group timeout is reached.
+func (t *httpTransport) call(ctx context.Context, req *Request) (*Response, error) {
+	body, err := jsonutil.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", httpResp.StatusCode)
+	}
+
+	var resp Response
+	if err := jsonutil.UnmarshalValid(respBody, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (t *httpTransport) close() error {
+	return nil
+}
+
+type grpcTransport struct {
+	conn *grpc.ClientConn
+}
+
+// newGRPCTransport doesn't wait for the connection, which is established on the first call.
+func newGRPCTransport(target string) (*grpcTransport, error) {
+	conn, err := grpc.Dial(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(JSONCodec{})),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcTransport{conn: conn}, nil
+}
+
+func (t *grpcTransport) call(ctx context.Context, req *Request) (*Response, error) {
+	var resp Response
+	if err := t.conn.Invoke(ctx, GRPCMethod, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (t *grpcTransport) close() error {
+	return t.conn.Close()
+}
+
+// JSONCodec encodes the gRPC messages as JSON, so that the remote service doesn't need generated code.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return jsonutil.Marshal(v)
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return jsonutil.UnmarshalValid(data, v)
+}
+
+func (JSONCodec) Name() string {
+	return "json"
+}
@@ -0,0 +1,217 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// bidderRequestDocument is the payload of the bidder_request stage handler.
+type bidderRequestDocument struct {
+	Bidder  string               `json:"bidder"`
+	Request *openrtb2.BidRequest `json:"request"`
+}
+
+// bidderResponseDocument is the payload of the raw_bidder_response stage handler.
+// Bids can be changed or removed, but not added.
+type bidderResponseDocument struct {
+	Bidder string         `json:"bidder"`
+	Bids   []openrtb2.Bid `json:"bids"`
+}
+
+// HandleRawAuctionHook passes the request body to the "handle_raw_auction_request" function.
+func (m *Module) HandleRawAuctionHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.RawAuctionRequestPayload], error) {
+	stage := hooks.StageRawAuctionRequest.String()
+	if !m.runsAt(stage, miCtx) {
+		return hookstage.HookResult[hookstage.RawAuctionRequestPayload]{}, nil
+	}
+
+	inv, err := m.run(ctx, stage, miCtx, payload)
+	if err != nil {
+		return hookstage.HookResult[hookstage.RawAuctionRequestPayload]{}, err
+	}
+
+	result := newHookResult[hookstage.RawAuctionRequestPayload](inv)
+	if inv.modified {
+		result.ChangeSet.AddMutation(func(_ hookstage.RawAuctionRequestPayload) (hookstage.RawAuctionRequestPayload, error) {
+			return inv.payload, nil
+		}, hookstage.MutationUpdate, "body")
+	}
+	return result, nil
+}
+
+// HandleBidderRequestHook passes the bidder and its request to the "handle_bidder_request" function.
+func (m *Module) HandleBidderRequestHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.BidderRequestPayload,
+) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
+	result := hookstage.HookResult[hookstage.BidderRequestPayload]{}
+	stage := hooks.StageBidderRequest.String()
+	if !m.runsAt(stage, miCtx) {
+		return result, nil
+	}
+	if payload.Request == nil || payload.Request.BidRequest == nil {
+		return result, errors.New("payload contains a nil bid request")
+	}
+	if err := payload.Request.RebuildRequest(); err != nil {
+		return result, err
+	}
+	data, err := jsonutil.Marshal(bidderRequestDocument{Bidder: payload.Bidder, Request: payload.Request.BidRequest})
+	if err != nil {
+		return result, err
+	}
+
+	inv, err := m.run(ctx, stage, miCtx, data)
+	if err != nil {
+		return result, err
+	}
+
+	result = newHookResult[hookstage.BidderRequestPayload](inv)
+	if inv.modified {
+		result.ChangeSet.AddMutation(func(p hookstage.BidderRequestPayload) (hookstage.BidderRequestPayload, error) {
+			var doc bidderRequestDocument
+			if err := jsonutil.UnmarshalValid(inv.payload, &doc); err != nil {
+				return p, err
+			}
+			if doc.Request == nil {
+				return p, errors.New("bidder request document has no request")
+			}
+			// the request is changed in place, its extensions are parsed again by a new wrapper
+			*p.Request.BidRequest = *doc.Request
+			*p.Request = openrtb_ext.RequestWrapper{BidRequest: p.Request.BidRequest}
+			return p, nil
+		}, hookstage.MutationUpdate, "bidrequest")
+	}
+	return result, nil
+}
+
+// HandleRawBidderResponseHook passes the bidder and its bids to the "handle_raw_bidder_response" function.
+func (m *Module) HandleRawBidderResponseHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawBidderResponsePayload,
+) (hookstage.HookResult[hookstage.RawBidderResponsePayload], error) {
+	result := hookstage.HookResult[hookstage.RawBidderResponsePayload]{}
+	stage := hooks.StageRawBidderResponse.String()
+	if !m.runsAt(stage, miCtx) {
+		return result, nil
+	}
+	if payload.BidderResponse == nil {
+		return result, errors.New("payload contains a nil bidder response")
+	}
+
+	doc := bidderResponseDocument{Bidder: payload.Bidder, Bids: make([]openrtb2.Bid, 0, len(payload.BidderResponse.Bids))}
+	for _, typedBid := range payload.BidderResponse.Bids {
+		if typedBid != nil && typedBid.Bid != nil {
+			doc.Bids = append(doc.Bids, *typedBid.Bid)
+		}
+	}
+	data, err := jsonutil.Marshal(doc)
+	if err != nil {
+		return result, err
+	}
+
+	inv, err := m.run(ctx, stage, miCtx, data)
+	if err != nil {
+		return result, err
+	}
+
+	result = newHookResult[hookstage.RawBidderResponsePayload](inv)
+	if inv.modified {
+		result.ChangeSet.AddMutation(func(p hookstage.RawBidderResponsePayload) (hookstage.RawBidderResponsePayload, error) {
+			var doc bidderResponseDocument
+			if err := jsonutil.UnmarshalValid(inv.payload, &doc); err != nil {
+				return p, err
+			}
+			bids, err := updateBids(p.BidderResponse.Bids, doc.Bids)
+			if err != nil {
+				return p, err
+			}
+			p.BidderResponse.Bids = bids
+			return p, nil
+		}, hookstage.MutationUpdate, "bidderresponse", "bids")
+	}
+	return result, nil
+}
+
+// HandleAuctionResponseHook passes the bid response to the "handle_auction_response" function.
+func (m *Module) HandleAuctionResponseHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.AuctionResponsePayload,
+) (hookstage.HookResult[hookstage.AuctionResponsePayload], error) {
+	result := hookstage.HookResult[hookstage.AuctionResponsePayload]{}
+	stage := hooks.StageAuctionResponse.String()
+	if !m.runsAt(stage, miCtx) {
+		return result, nil
+	}
+	if payload.BidResponse == nil {
+		return result, errors.New("payload contains a nil bid response")
+	}
+	data, err := jsonutil.Marshal(payload.BidResponse)
+	if err != nil {
+		return result, err
+	}
+
+	inv, err := m.run(ctx, stage, miCtx, data)
+	if err != nil {
+		return result, err
+	}
+
+	result = newHookResult[hookstage.AuctionResponsePayload](inv)
+	if inv.modified {
+		result.ChangeSet.AddMutation(func(p hookstage.AuctionResponsePayload) (hookstage.AuctionResponsePayload, error) {
+			var response openrtb2.BidResponse
+			if err := jsonutil.UnmarshalValid(inv.payload, &response); err != nil {
+				return p, err
+			}
+			// the payload is discarded by the executor, the response is changed in place
+			*p.BidResponse = response
+			return p, nil
+		}, hookstage.MutationUpdate, "bidresponse")
+	}
+	return result, nil
+}
+
+func newHookResult[T any](inv *invocation) hookstage.HookResult[T] {
+	return hookstage.HookResult[T]{
+		AnalyticsTags: inv.analyticsTags,
+		DebugMessages: inv.debugMessages,
+		Warnings:      inv.warnings,
+		Errors:        inv.errors,
+	}
+}
+
+// updateBids replaces the bids with the changed ones, matched by ID, dropping the bids which aren't listed.
+func updateBids(typedBids []*adapters.TypedBid, changed []openrtb2.Bid) ([]*adapters.TypedBid, error) {
+	byID := make(map[string]*adapters.TypedBid, len(typedBids))
+	for _, typedBid := range typedBids {
+		if typedBid != nil && typedBid.Bid != nil {
+			byID[typedBid.Bid.ID] = typedBid
+		}
+	}
+
+	updated := make([]*adapters.TypedBid, 0, len(changed))
+	for i := range changed {
+		typedBid, ok := byID[changed[i].ID]
+		if !ok {
+			return nil, fmt.Errorf(`bid "%s" is not in the bidder response`, changed[i].ID)
+		}
+		updatedBid := *typedBid
+		updatedBid.Bid = &changed[i]
+		updated = append(updated, &updatedBid)
+	}
+	return updated, nil
+}
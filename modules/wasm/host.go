@@ -0,0 +1,157 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// Status codes returned by the host functions.
+const (
+	StatusOK          uint32 = 0
+	StatusOutOfBounds uint32 = 1
+	StatusInvalidJSON uint32 = 2
+)
+
+// Levels of the messages logged with the "log" host function.
+const (
+	LogDebug   uint32 = 0
+	LogWarning uint32 = 1
+	LogError   uint32 = 2
+)
+
+// invocation is the state of a hook invocation shared with the host functions.
+type invocation struct {
+	payload       []byte
+	accountConfig json.RawMessage
+	modified      bool
+
+	analyticsTags hookanalytics.Analytics
+	debugMessages []string
+	warnings      []string
+	errors        []string
+}
+
+type invocationKey struct{}
+
+func withInvocation(ctx context.Context, inv *invocation) context.Context {
+	return context.WithValue(ctx, invocationKey{}, inv)
+}
+
+func getInvocation(ctx context.Context) *invocation {
+	inv, _ := ctx.Value(invocationKey{}).(*invocation)
+	if inv == nil {
+		// functions called when instantiating the module, outside of an invocation
+		return &invocation{}
+	}
+	return inv
+}
+
+// instantiateHostModule provides the functions binaries import from the "prebid" module:
+//
+//	payload_len() i32: length of the JSON payload of the stage
+//	payload_read(ptr i32) i32: copies the payload to the memory at ptr
+//	payload_write(ptr i32, len i32) i32: replaces the payload with the JSON document at ptr
+//	account_config_len() i32: length of the account config of the module
+//	account_config_read(ptr i32) i32: copies the account config to the memory at ptr
+//	analytics_tag(ptr i32, len i32) i32: adds the JSON encoded analytics activity at ptr to the hook result
+//	log(level i32, ptr i32, len i32): adds the message at ptr to the debug messages, warnings or errors of the hook result
+//
+// Functions returning an i32 return one of the status codes.
+func instantiateHostModule(ctx context.Context, runtime wazero.Runtime) error {
+	_, err := runtime.NewHostModuleBuilder(hostModuleName).
+		NewFunctionBuilder().WithFunc(payloadLen).Export("payload_len").
+		NewFunctionBuilder().WithFunc(payloadRead).Export("payload_read").
+		NewFunctionBuilder().WithFunc(payloadWrite).Export("payload_write").
+		NewFunctionBuilder().WithFunc(accountConfigLen).Export("account_config_len").
+		NewFunctionBuilder().WithFunc(accountConfigRead).Export("account_config_read").
+		NewFunctionBuilder().WithFunc(analyticsTag).Export("analytics_tag").
+		NewFunctionBuilder().WithFunc(logMessage).Export("log").
+		Instantiate(ctx)
+	return err
+}
+
+func payloadLen(ctx context.Context) uint32 {
+	return uint32(len(getInvocation(ctx).payload))
+}
+
+func payloadRead(ctx context.Context, m api.Module, ptr uint32) uint32 {
+	return write(m, ptr, getInvocation(ctx).payload)
+}
+
+func payloadWrite(ctx context.Context, m api.Module, ptr, size uint32) uint32 {
+	data, status := read(m, ptr, size)
+	if status != StatusOK {
+		return status
+	}
+
+	inv := getInvocation(ctx)
+	inv.payload = data
+	inv.modified = true
+	return StatusOK
+}
+
+func accountConfigLen(ctx context.Context) uint32 {
+	return uint32(len(getInvocation(ctx).accountConfig))
+}
+
+func accountConfigRead(ctx context.Context, m api.Module, ptr uint32) uint32 {
+	return write(m, ptr, getInvocation(ctx).accountConfig)
+}
+
+func analyticsTag(ctx context.Context, m api.Module, ptr, size uint32) uint32 {
+	data, status := read(m, ptr, size)
+	if status != StatusOK {
+		return status
+	}
+
+	var activity hookanalytics.Activity
+	if err := jsonutil.UnmarshalValid(data, &activity); err != nil {
+		return StatusInvalidJSON
+	}
+
+	inv := getInvocation(ctx)
+	inv.analyticsTags.Activities = append(inv.analyticsTags.Activities, activity)
+	return StatusOK
+}
+
+func logMessage(ctx context.Context, m api.Module, level, ptr, size uint32) {
+	message, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		return
+	}
+
+	inv := getInvocation(ctx)
+	switch level {
+	case LogWarning:
+		inv.warnings = append(inv.warnings, string(message))
+	case LogError:
+		inv.errors = append(inv.errors, string(message))
+	default:
+		inv.debugMessages = append(inv.debugMessages, string(message))
+	}
+}
+
+func write(m api.Module, ptr uint32, data []byte) uint32 {
+	if !m.Memory().Write(ptr, data) {
+		return StatusOutOfBounds
+	}
+	return StatusOK
+}
+
+// read returns a copy of the JSON document at ptr, the memory of the instance is released with it.
+func read(m api.Module, ptr, size uint32) ([]byte, uint32) {
+	data, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		return nil, StatusOutOfBounds
+	}
+	if !json.Valid(data) {
+		return nil, StatusInvalidJSON
+	}
+	return bytes.Clone(data), StatusOK
+}
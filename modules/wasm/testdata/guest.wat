@@ -0,0 +1,34 @@
+;; Guest module of the tests, guest.wasm is its binary.
+(module
+  (import "prebid" "payload_len" (func $payload_len (result i32)))
+  (import "prebid" "payload_read" (func $payload_read (param i32) (result i32)))
+  (import "prebid" "payload_write" (func $payload_write (param i32 i32) (result i32)))
+  (import "prebid" "analytics_tag" (func $analytics_tag (param i32 i32) (result i32)))
+  (import "prebid" "log" (func $log (param i32 i32 i32)))
+  (memory (export "memory") 1)
+
+  ;; never returns, to be stopped by the max execution time
+  (func (export "handle_raw_auction_request")
+    (loop $forever (br $forever)))
+
+  ;; replaces the request and logs a warning
+  (func (export "handle_bidder_request")
+    (drop (call $payload_write (i32.const 1024) (i32.const 53)))
+    (call $log (i32.const 1) (i32.const 4096) (i32.const 8)))
+
+  ;; drops bid-1 and changes the price of bid-2
+  (func (export "handle_raw_bidder_response")
+    (drop (call $payload_write (i32.const 2048) (i32.const 69))))
+
+  ;; echoes the response, tags it and logs a debug message
+  (func (export "handle_auction_response")
+    (drop (call $payload_read (i32.const 8192)))
+    (drop (call $payload_write (i32.const 8192) (call $payload_len)))
+    (drop (call $analytics_tag (i32.const 3072) (i32.const 36)))
+    (call $log (i32.const 0) (i32.const 4352) (i32.const 5)))
+
+  (data (i32.const 1024) "{\"bidder\":\"appnexus\",\"request\":{\"id\":\"wasm-request\"}}")
+  (data (i32.const 2048) "{\"bidder\":\"appnexus\",\"bids\":[{\"id\":\"bid-2\",\"impid\":\"imp\",\"price\":2}]}")
+  (data (i32.const 3072) "{\"name\":\"enrich\",\"status\":\"success\"}")
+  (data (i32.const 4096) "replaced")
+  (data (i32.const 4352) "hello"))
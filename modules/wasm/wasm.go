@@ -0,0 +1,182 @@
+// Package wasm provides hook modules compiled to WebAssembly and run in a sandbox.
+//
+// A WebAssembly module is configured like any other module, under hooks.modules, with a "wasm" object
+// instead of a compiled module package:
+//
+//	{"enabled": true, "wasm": {"path": "/etc/pbs/enrichment.wasm", "memory_limit_mb": 16, "max_execution_ms": 50}}
+//
+// The binary exports a function without parameters and results for each stage it provides a hook for:
+// "handle_raw_auction_request", "handle_bidder_request", "handle_raw_bidder_response" and
+// "handle_auction_response". The functions are run in a new instance of the binary for every invocation,
+// so that no state is shared between requests, and only for the accounts enabling the module in their
+// hooks.modules config. The binary may import WASI, without access to the filesystem or to the network,
+// and the host functions of the "prebid" module documented in host.go.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	defaultMemoryLimitMB  = 16
+	defaultMaxExecutionMs = 100
+
+	// pagesPerMB is the number of 64KiB WebAssembly memory pages in a megabyte
+	pagesPerMB     = 16
+	handlerPrefix  = "handle_"
+	startFunction  = "_initialize"
+	hostModuleName = "prebid"
+)
+
+type config struct {
+	Wasm struct {
+		Path           string `json:"path"`
+		MemoryLimitMB  int    `json:"memory_limit_mb"`
+		MaxExecutionMs int    `json:"max_execution_ms"`
+	} `json:"wasm"`
+}
+
+// Module runs the hooks exported by a WebAssembly binary.
+type Module struct {
+	id           string
+	runtime      wazero.Runtime
+	compiled     wazero.CompiledModule
+	stages       map[string]struct{}
+	maxExecution time.Duration
+}
+
+// NewModule compiles the binary of the WebAssembly module with the given "vendor.module_name" ID.
+func NewModule(id string, cfg json.RawMessage, _ moduledeps.ModuleDeps) (*Module, error) {
+	var c config
+	if err := jsonutil.UnmarshalValid(cfg, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %s", err)
+	}
+	if c.Wasm.Path == "" {
+		return nil, errors.New("wasm.path is required")
+	}
+	if c.Wasm.MemoryLimitMB < 0 {
+		return nil, fmt.Errorf("wasm.memory_limit_mb must be >= 0. Got %d", c.Wasm.MemoryLimitMB)
+	}
+	if c.Wasm.MaxExecutionMs < 0 {
+		return nil, fmt.Errorf("wasm.max_execution_ms must be >= 0. Got %d", c.Wasm.MaxExecutionMs)
+	}
+	if c.Wasm.MemoryLimitMB == 0 {
+		c.Wasm.MemoryLimitMB = defaultMemoryLimitMB
+	}
+	if c.Wasm.MaxExecutionMs == 0 {
+		c.Wasm.MaxExecutionMs = defaultMaxExecutionMs
+	}
+
+	binary, err := os.ReadFile(c.Wasm.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wasm binary: %s", err)
+	}
+
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(c.Wasm.MemoryLimitMB*pagesPerMB)).
+		WithCloseOnContextDone(true))
+
+	module, err := newModule(ctx, id, runtime, binary)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, err
+	}
+	module.maxExecution = time.Duration(c.Wasm.MaxExecutionMs) * time.Millisecond
+
+	return module, nil
+}
+
+func newModule(ctx context.Context, id string, runtime wazero.Runtime, binary []byte) (*Module, error) {
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return nil, fmt.Errorf("failed to instantiate WASI: %s", err)
+	}
+	if err := instantiateHostModule(ctx, runtime); err != nil {
+		return nil, fmt.Errorf("failed to instantiate host functions: %s", err)
+	}
+
+	compiled, err := runtime.CompileModule(ctx, binary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile wasm binary: %s", err)
+	}
+
+	stages := make(map[string]struct{})
+	for name := range compiled.ExportedFunctions() {
+		if stage, ok := strings.CutPrefix(name, handlerPrefix); ok {
+			stages[stage] = struct{}{}
+		}
+	}
+	if len(stages) == 0 {
+		return nil, fmt.Errorf(`wasm binary doesn't export any "%s{stage}" function`, handlerPrefix)
+	}
+
+	return &Module{id: id, runtime: runtime, compiled: compiled, stages: stages}, nil
+}
+
+// Start does nothing, the binary is compiled when the module is built.
+func (m *Module) Start(_ context.Context) error {
+	return nil
+}
+
+// Shutdown releases the compiled binary and the running instances.
+func (m *Module) Shutdown(ctx context.Context) error {
+	return m.runtime.Close(ctx)
+}
+
+// runsAt tells whether the binary exports the handler of the stage and the account enabled the module,
+// the hooks check it before building the payload of the handler.
+func (m *Module) runsAt(stage string, miCtx hookstage.ModuleInvocationContext) bool {
+	_, ok := m.stages[stage]
+	return ok && isAccountEnabled(miCtx.AccountConfig)
+}
+
+// run calls the handler of the stage in a new instance of the binary.
+func (m *Module) run(ctx context.Context, stage string, miCtx hookstage.ModuleInvocationContext, payload []byte) (*invocation, error) {
+	// the instance is closed once the context is done, which stops a handler exceeding
+	// either the max execution time of the module or the timeout of the hook group
+	ctx, cancel := context.WithTimeout(ctx, m.maxExecution)
+	defer cancel()
+
+	inv := &invocation{payload: payload, accountConfig: miCtx.AccountConfig}
+	ctx = withInvocation(ctx, inv)
+
+	instance, err := m.runtime.InstantiateModule(ctx, m.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions(startFunction))
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm module: %s", err)
+	}
+	defer instance.Close(context.Background())
+
+	if _, err := instance.ExportedFunction(handlerPrefix + stage).Call(ctx); err != nil {
+		return nil, fmt.Errorf("wasm %s handler failed: %s", stage, err)
+	}
+	return inv, nil
+}
+
+// isAccountEnabled tells whether the account config of the module enables it,
+// modules running third party code are disabled by default.
+func isAccountEnabled(cfg json.RawMessage) bool {
+	if len(cfg) == 0 {
+		return false
+	}
+	var c struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := jsonutil.UnmarshalValid(cfg, &c); err != nil {
+		return false
+	}
+	return c.Enabled
+}
@@ -0,0 +1,165 @@
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testdata/guest.wasm is built from testdata/guest.wat
+const guestPath = "testdata/guest.wasm"
+
+var enabledForAccount = hookstage.ModuleInvocationContext{AccountConfig: json.RawMessage(`{"enabled":true}`)}
+
+func TestNewModule(t *testing.T) {
+	testCases := map[string]struct {
+		givenConfig string
+		expectedErr string
+	}{
+		"Valid config":            {givenConfig: `{"wasm":{"path":"` + guestPath + `"}}`},
+		"Missing path":            {givenConfig: `{"enabled":true}`, expectedErr: "wasm.path is required"},
+		"Negative memory limit":   {givenConfig: `{"wasm":{"path":"` + guestPath + `","memory_limit_mb":-1}}`, expectedErr: "wasm.memory_limit_mb must be >= 0. Got -1"},
+		"Negative execution time": {givenConfig: `{"wasm":{"path":"` + guestPath + `","max_execution_ms":-1}}`, expectedErr: "wasm.max_execution_ms must be >= 0. Got -1"},
+		"Missing binary":          {givenConfig: `{"wasm":{"path":"testdata/missing.wasm"}}`, expectedErr: "failed to read wasm binary"},
+		"Invalid binary":          {givenConfig: `{"wasm":{"path":"testdata/guest.wat"}}`, expectedErr: "failed to compile wasm binary"},
+		"Malformed":               {givenConfig: `{"wasm":`, expectedErr: "failed to parse config"},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			module, err := NewModule("acme.wasm", json.RawMessage(test.givenConfig), moduledeps.ModuleDeps{})
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]struct{}{
+				"raw_auction_request": {},
+				"bidder_request":      {},
+				"raw_bidder_response": {},
+				"auction_response":    {},
+			}, module.stages)
+			assert.NoError(t, module.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestHandleBidderRequestHook(t *testing.T) {
+	module := newTestModule(t, 0)
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "req-id"}}
+	payload := hookstage.BidderRequestPayload{Request: request, Bidder: "appnexus"}
+
+	result, err := module.HandleBidderRequestHook(context.Background(), enabledForAccount, payload)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"replaced"}, result.Warnings)
+
+	mutations := result.ChangeSet.Mutations()
+	require.Len(t, mutations, 1)
+	assert.Equal(t, []string{"bidrequest"}, mutations[0].Key())
+	_, err = mutations[0].Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, "wasm-request", request.ID, "Bid request should be changed in place.")
+}
+
+func TestHandleRawBidderResponseHook(t *testing.T) {
+	module := newTestModule(t, 0)
+	bid1 := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp", Price: 1}, BidType: openrtb_ext.BidTypeBanner}
+	bid2 := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "imp", Price: 1}, BidType: openrtb_ext.BidTypeVideo}
+	payload := hookstage.RawBidderResponsePayload{
+		BidderResponse: &adapters.BidderResponse{Bids: []*adapters.TypedBid{bid1, bid2}},
+		Bidder:         "appnexus",
+	}
+
+	result, err := module.HandleRawBidderResponseHook(context.Background(), enabledForAccount, payload)
+	require.NoError(t, err)
+
+	mutations := result.ChangeSet.Mutations()
+	require.Len(t, mutations, 1)
+	payload, err = mutations[0].Apply(payload)
+	require.NoError(t, err)
+
+	require.Len(t, payload.BidderResponse.Bids, 1)
+	assert.Equal(t, "bid-2", payload.BidderResponse.Bids[0].Bid.ID)
+	assert.Equal(t, 2.0, payload.BidderResponse.Bids[0].Bid.Price)
+	assert.Equal(t, openrtb_ext.BidTypeVideo, payload.BidderResponse.Bids[0].BidType, "Bid metadata should be kept.")
+}
+
+func TestHandleAuctionResponseHook(t *testing.T) {
+	module := newTestModule(t, 0)
+	response := &openrtb2.BidResponse{ID: "resp-id", Cur: "USD"}
+	payload := hookstage.AuctionResponsePayload{BidResponse: response}
+
+	result, err := module.HandleAuctionResponseHook(context.Background(), enabledForAccount, payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, hookanalytics.Analytics{Activities: []hookanalytics.Activity{{Name: "enrich", Status: hookanalytics.ActivityStatusSuccess}}}, result.AnalyticsTags)
+	assert.Equal(t, []string{"hello"}, result.DebugMessages)
+
+	mutations := result.ChangeSet.Mutations()
+	require.Len(t, mutations, 1)
+	_, err = mutations[0].Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, &openrtb2.BidResponse{ID: "resp-id", Cur: "USD"}, response, "Echoed response should be unchanged.")
+}
+
+func TestHandleRawAuctionHookExceedsMaxExecution(t *testing.T) {
+	module := newTestModule(t, 10)
+
+	_, err := module.HandleRawAuctionHook(context.Background(), enabledForAccount, []byte(`{}`))
+	assert.ErrorContains(t, err, "wasm raw_auction_request handler failed")
+}
+
+func TestHookDisabledForAccount(t *testing.T) {
+	testCases := map[string]json.RawMessage{
+		"No account config":     nil,
+		"Disabled":              json.RawMessage(`{"enabled":false}`),
+		"Enabled not specified": json.RawMessage(`{"foo":"bar"}`),
+		"Malformed":             json.RawMessage(`{"enabled":`),
+	}
+
+	module := newTestModule(t, 0)
+	for name, accountConfig := range testCases {
+		t.Run(name, func(t *testing.T) {
+			miCtx := hookstage.ModuleInvocationContext{AccountConfig: accountConfig}
+			payload := hookstage.AuctionResponsePayload{BidResponse: &openrtb2.BidResponse{ID: "resp-id"}}
+
+			result, err := module.HandleAuctionResponseHook(context.Background(), miCtx, payload)
+			require.NoError(t, err)
+			assert.Equal(t, hookstage.HookResult[hookstage.AuctionResponsePayload]{}, result)
+
+			// the payload isn't serialized for the accounts which didn't enable the module, it would fail otherwise
+			bidderRequestResult, err := module.HandleBidderRequestHook(context.Background(), miCtx, hookstage.BidderRequestPayload{})
+			require.NoError(t, err)
+			assert.Equal(t, hookstage.HookResult[hookstage.BidderRequestPayload]{}, bidderRequestResult)
+
+			bidderResponseResult, err := module.HandleRawBidderResponseHook(context.Background(), miCtx, hookstage.RawBidderResponsePayload{})
+			require.NoError(t, err)
+			assert.Equal(t, hookstage.HookResult[hookstage.RawBidderResponsePayload]{}, bidderResponseResult)
+		})
+	}
+}
+
+func TestUpdateBidsUnknownBid(t *testing.T) {
+	typedBids := []*adapters.TypedBid{{Bid: &openrtb2.Bid{ID: "bid-1"}}}
+
+	_, err := updateBids(typedBids, []openrtb2.Bid{{ID: "bid-2"}})
+	assert.EqualError(t, err, `bid "bid-2" is not in the bidder response`)
+}
+
+func newTestModule(t *testing.T, maxExecutionMs int) *Module {
+	cfg := fmt.Sprintf(`{"wasm":{"path":"%s","max_execution_ms":%d}}`, guestPath, maxExecutionMs)
+	module, err := NewModule("acme.wasm", json.RawMessage(cfg), moduledeps.ModuleDeps{})
+	require.NoError(t, err)
+	t.Cleanup(func() { module.Shutdown(context.Background()) })
+	return module
+}
@@ -0,0 +1,114 @@
+package notifications
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+)
+
+// BidStore keeps the winning bids of the recent auctions to reconcile the /event notifications with them
+type BidStore interface {
+	Save(accountID string, bid *analytics.AuctionBid)
+	// Find returns the bid saved for the account, or nil if it's unknown or expired
+	Find(accountID, bidID string) *analytics.AuctionBid
+}
+
+// NewBidStore returns an in memory BidStore, or a store keeping nothing when the reconciliation is disabled
+func NewBidStore(cfg config.EventReconciliation, clock clock.Clock) BidStore {
+	if !cfg.Enabled {
+		return NilBidStore{}
+	}
+	return &memoryBidStore{
+		clock:      clock,
+		ttl:        time.Duration(cfg.TTLSeconds) * time.Second,
+		maxEntries: cfg.MaxEntries,
+		entries:    make(map[bidKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// NilBidStore is the BidStore used when the reconciliation is disabled
+type NilBidStore struct{}
+
+func (NilBidStore) Save(accountID string, bid *analytics.AuctionBid) {}
+
+func (NilBidStore) Find(accountID, bidID string) *analytics.AuctionBid {
+	return nil
+}
+
+type bidKey struct {
+	accountID string
+	bidID     string
+}
+
+type storedBid struct {
+	key       bidKey
+	bid       *analytics.AuctionBid
+	expiresAt time.Time
+}
+
+// memoryBidStore evicts the bids in the order they're saved, which is also the order they expire in since they
+// all have the same ttl
+type memoryBidStore struct {
+	mutex      sync.Mutex
+	clock      clock.Clock
+	ttl        time.Duration
+	maxEntries int
+	entries    map[bidKey]*list.Element
+	order      *list.List
+}
+
+func (s *memoryBidStore) Save(accountID string, bid *analytics.AuctionBid) {
+	key := bidKey{accountID: accountID, bidID: bid.BidID}
+	now := s.clock.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		s.remove(element)
+	}
+	s.evictExpired(now)
+	for s.order.Len() >= s.maxEntries {
+		s.remove(s.order.Front())
+	}
+
+	s.entries[key] = s.order.PushBack(&storedBid{
+		key:       key,
+		bid:       bid,
+		expiresAt: now.Add(s.ttl),
+	})
+}
+
+func (s *memoryBidStore) Find(accountID, bidID string) *analytics.AuctionBid {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	element, ok := s.entries[bidKey{accountID: accountID, bidID: bidID}]
+	if !ok {
+		return nil
+	}
+	stored := element.Value.(*storedBid)
+	if !s.clock.Now().Before(stored.expiresAt) {
+		return nil
+	}
+	return stored.bid
+}
+
+func (s *memoryBidStore) evictExpired(now time.Time) {
+	for element := s.order.Front(); element != nil; element = s.order.Front() {
+		if now.Before(element.Value.(*storedBid).expiresAt) {
+			return
+		}
+		s.remove(element)
+	}
+}
+
+func (s *memoryBidStore) remove(element *list.Element) {
+	delete(s.entries, element.Value.(*storedBid).key)
+	s.order.Remove(element)
+}
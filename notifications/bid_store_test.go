@@ -0,0 +1,65 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prebid/prebid-server/v3/analytics"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBidStoreDisabled(t *testing.T) {
+	store := NewBidStore(config.EventReconciliation{Enabled: false}, clock.NewMock())
+
+	store.Save("account", &analytics.AuctionBid{BidID: "bid"})
+
+	assert.Equal(t, NilBidStore{}, store)
+	assert.Nil(t, store.Find("account", "bid"))
+}
+
+func TestBidStoreFind(t *testing.T) {
+	clock := clock.NewMock()
+	store := NewBidStore(config.EventReconciliation{Enabled: true, TTLSeconds: 60, MaxEntries: 10}, clock)
+
+	bid := &analytics.AuctionBid{AuctionID: "auction", BidID: "bid", Price: 1.5}
+	store.Save("account", bid)
+
+	assert.Equal(t, bid, store.Find("account", "bid"), "saved bid")
+	assert.Nil(t, store.Find("other-account", "bid"), "bid of another account")
+	assert.Nil(t, store.Find("account", "other-bid"), "unknown bid")
+
+	clock.Add(60 * time.Second)
+	assert.Nil(t, store.Find("account", "bid"), "expired bid")
+}
+
+func TestBidStoreSaveOverwrites(t *testing.T) {
+	store := NewBidStore(config.EventReconciliation{Enabled: true, TTLSeconds: 60, MaxEntries: 10}, clock.NewMock())
+
+	store.Save("account", &analytics.AuctionBid{BidID: "bid", Price: 1})
+	store.Save("account", &analytics.AuctionBid{BidID: "bid", Price: 2})
+
+	assert.Equal(t, &analytics.AuctionBid{BidID: "bid", Price: 2}, store.Find("account", "bid"))
+	assert.Equal(t, 1, store.(*memoryBidStore).order.Len())
+}
+
+func TestBidStoreEviction(t *testing.T) {
+	clock := clock.NewMock()
+	store := NewBidStore(config.EventReconciliation{Enabled: true, TTLSeconds: 60, MaxEntries: 2}, clock)
+
+	store.Save("account", &analytics.AuctionBid{BidID: "bid-1"})
+	clock.Add(30 * time.Second)
+	store.Save("account", &analytics.AuctionBid{BidID: "bid-2"})
+	store.Save("account", &analytics.AuctionBid{BidID: "bid-3"})
+
+	assert.Nil(t, store.Find("account", "bid-1"), "oldest bid evicted above max entries")
+	assert.NotNil(t, store.Find("account", "bid-2"))
+	assert.NotNil(t, store.Find("account", "bid-3"))
+
+	clock.Add(60 * time.Second)
+	store.Save("account", &analytics.AuctionBid{BidID: "bid-4"})
+
+	assert.Equal(t, 1, store.(*memoryBidStore).order.Len(), "expired bids evicted on save")
+	assert.NotNil(t, store.Find("account", "bid-4"))
+}
@@ -0,0 +1,61 @@
+package notifications
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Notifier fires the win and loss notification urls of the bids
+type Notifier interface {
+	// Notify calls the url asynchronously, the outcome doesn't affect the auction
+	Notify(notificationURL string)
+}
+
+type httpNotifier struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewNotifier returns a Notifier sending GET requests with the client, cancelled after timeout unless it's 0
+func NewNotifier(client *http.Client, timeout time.Duration) Notifier {
+	return &httpNotifier{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+func (n *httpNotifier) Notify(notificationURL string) {
+	go n.notify(notificationURL)
+}
+
+func (n *httpNotifier) notify(notificationURL string) {
+	ctx := context.Background()
+	if n.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, notificationURL, nil)
+	if err != nil {
+		glog.V(2).Infof("Bid notification %s is invalid: %v", notificationURL, err)
+		return
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		glog.V(2).Infof("Bid notification %s failed: %v", notificationURL, err)
+		return
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection is reused
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		glog.V(2).Infof("Bid notification %s failed with status %d", notificationURL, resp.StatusCode)
+	}
+}
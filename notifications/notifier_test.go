@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(server.Client(), time.Second)
+	notifier.Notify(server.URL + "/win?price=1.25")
+
+	select {
+	case r := <-requests:
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/win", r.URL.Path)
+		assert.Equal(t, "1.25", r.URL.Query().Get("price"))
+	case <-time.After(time.Second):
+		t.Fatal("the notification wasn't sent")
+	}
+}
+
+func TestNotifyTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	notifier := &httpNotifier{client: server.Client(), timeout: 10 * time.Millisecond}
+
+	done := make(chan struct{})
+	go func() {
+		notifier.notify(server.URL)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the notification wasn't cancelled after the timeout")
+	}
+}
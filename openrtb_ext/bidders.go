@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -15,8 +19,29 @@ import (
 // BidderName refers to a core bidder id or an alias id.
 type BidderName string
 
-var aliasBidderToParent map[BidderName]BidderName = map[BidderName]BidderName{}
+// bidderNames holds the aliases and the bidder names including them. The aliases may be set again
+// when the bidder infos are reloaded at runtime, so a snapshot is replaced as a whole and never
+// modified once stored, which lets the readers load it without locking.
+type bidderNames struct {
+	aliasToParent map[BidderName]BidderName
+	names         []BidderName
+	lookup        map[string]BidderName // lower case version of the bidder name to the precise BidderName
+}
+
+var currentBidderNames atomic.Pointer[bidderNames]
+
+// aliasesUpdate serializes the writers of the bidder names, so that the aliases set concurrently aren't lost.
+var aliasesUpdate sync.Mutex
+
+func init() {
+	currentBidderNames.Store(&bidderNames{
+		aliasToParent: map[BidderName]BidderName{},
+		names:         coreBidderNames,
+		lookup:        newBidderNameLookup(coreBidderNames),
+	})
+}
 
+// coreBidderNames are the bidders compiled into the server, the aliases are added to them.
 var coreBidderNames []BidderName = []BidderName{
 	Bidder33Across,
 	BidderAax,
@@ -263,18 +288,43 @@ var coreBidderNames []BidderName = []BidderName{
 	BidderZmaticoo,
 }
 
+// GetAliasBidderToParent returns the parent of every alias. The map must not be modified.
 func GetAliasBidderToParent() map[BidderName]BidderName {
-	return aliasBidderToParent
+	return currentBidderNames.Load().aliasToParent
 }
 
 func SetAliasBidderName(aliasBidderName string, parentBidderName BidderName) error {
-	if IsBidderNameReserved(aliasBidderName) {
-		return fmt.Errorf("alias %s is a reserved bidder name and cannot be used", aliasBidderName)
+	return SetAliasBidderNames(map[string]BidderName{aliasBidderName: parentBidderName})
+}
+
+// SetAliasBidderNames adds the aliases, or changes their parent, at once. None of them is set if one
+// of the alias names is reserved.
+func SetAliasBidderNames(aliasToParent map[string]BidderName) error {
+	aliasNames := slices.Sorted(maps.Keys(aliasToParent))
+	for _, aliasBidderName := range aliasNames {
+		if IsBidderNameReserved(aliasBidderName) {
+			return fmt.Errorf("alias %s is a reserved bidder name and cannot be used", aliasBidderName)
+		}
 	}
-	aliasBidder := BidderName(aliasBidderName)
-	coreBidderNames = append(coreBidderNames, aliasBidder)
-	aliasBidderToParent[aliasBidder] = parentBidderName
-	bidderNameLookup[strings.ToLower(aliasBidderName)] = aliasBidder
+
+	aliasesUpdate.Lock()
+	defer aliasesUpdate.Unlock()
+
+	current := currentBidderNames.Load()
+	next := &bidderNames{
+		aliasToParent: maps.Clone(current.aliasToParent),
+		names:         slices.Clone(current.names),
+		lookup:        maps.Clone(current.lookup),
+	}
+	for _, aliasBidderName := range aliasNames {
+		aliasBidder := BidderName(aliasBidderName)
+		if _, ok := next.aliasToParent[aliasBidder]; !ok {
+			next.names = append(next.names, aliasBidder)
+		}
+		next.aliasToParent[aliasBidder] = aliasToParent[aliasBidderName]
+		next.lookup[strings.ToLower(aliasBidderName)] = aliasBidder
+	}
+	currentBidderNames.Store(next)
 	return nil
 }
 
@@ -621,7 +671,7 @@ const (
 
 // CoreBidderNames returns a slice of all core bidders.
 func CoreBidderNames() []BidderName {
-	return coreBidderNames
+	return slices.Clip(currentBidderNames.Load().names)
 }
 
 // BuildBidderMap builds a map of string to BidderName, to remain compatbile with the
@@ -652,21 +702,21 @@ func BuildBidderNameHashSet() map[string]struct{} {
 	return hashSet
 }
 
-// bidderNameLookup is a map of the lower case version of the bidder name to the precise BidderName value.
-var bidderNameLookup = func() map[string]BidderName {
+// newBidderNameLookup returns a map of the lower case version of the bidder names to the precise BidderName value.
+func newBidderNameLookup(names []BidderName) map[string]BidderName {
 	lookup := make(map[string]BidderName)
-	for _, name := range CoreBidderNames() {
+	for _, name := range names {
 		bidderNameLower := strings.ToLower(string(name))
 		lookup[bidderNameLower] = name
 	}
 	return lookup
-}()
+}
 
 type BidderNameNormalizer func(name string) (BidderName, bool)
 
 func NormalizeBidderName(name string) (BidderName, bool) {
 	nameLower := strings.ToLower(name)
-	bidderName, exists := bidderNameLookup[nameLower]
+	bidderName, exists := currentBidderNames.Load().lookup[nameLower]
 	return bidderName, exists
 }
 
@@ -758,7 +808,7 @@ func NewBidderParamsValidator(schemaDirectory string) (BidderParamValidator, err
 	}
 
 	// set alias bidder params schema to its parent
-	for alias, parent := range aliasBidderToParent {
+	for alias, parent := range GetAliasBidderToParent() {
 		parentSchema := schemas[parent]
 		schemas[alias] = parentSchema
 
@@ -132,7 +132,7 @@ func TestIsBidderNameReserved(t *testing.T) {
 
 func TestSetAliasBidderName(t *testing.T) {
 	parentBidder := BidderName("pBidder")
-	existingCoreBidderNames := coreBidderNames
+	existingBidderNames := currentBidderNames.Load()
 
 	testCases := []struct {
 		aliasBidderName string
@@ -148,14 +148,38 @@ func TestSetAliasBidderName(t *testing.T) {
 			assert.Equal(t, test.err, err)
 		} else {
 			assert.Contains(t, CoreBidderNames(), BidderName(test.aliasBidderName))
-			assert.Contains(t, aliasBidderToParent, BidderName(test.aliasBidderName))
-			assert.Contains(t, bidderNameLookup, strings.ToLower(test.aliasBidderName))
+			assert.Contains(t, GetAliasBidderToParent(), BidderName(test.aliasBidderName))
+			assert.Contains(t, currentBidderNames.Load().lookup, strings.ToLower(test.aliasBidderName))
 		}
 	}
 
 	//reset package variables to not interfere with other test cases. Example - TestBidderParamSchemas
-	coreBidderNames = existingCoreBidderNames
-	aliasBidderToParent = map[BidderName]BidderName{}
+	currentBidderNames.Store(existingBidderNames)
+}
+
+func TestSetAliasBidderNames(t *testing.T) {
+	existingBidderNames := currentBidderNames.Load()
+
+	err := SetAliasBidderNames(map[string]BidderName{"aBidder": "pBidder", "all": "pBidder"})
+	assert.EqualError(t, err, "alias all is a reserved bidder name and cannot be used")
+	assert.NotContains(t, GetAliasBidderToParent(), BidderName("aBidder"), "no alias is set if one of them is reserved")
+
+	err = SetAliasBidderNames(map[string]BidderName{"aBidder": "pBidder", "bBidder": "pBidder"})
+	assert.NoError(t, err)
+	previousAliases := GetAliasBidderToParent()
+
+	err = SetAliasBidderNames(map[string]BidderName{"aBidder": "otherBidder"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[BidderName]BidderName{"aBidder": "otherBidder", "bBidder": "pBidder"}, GetAliasBidderToParent())
+	assert.Equal(t, map[BidderName]BidderName{"aBidder": "pBidder", "bBidder": "pBidder"}, previousAliases, "the aliases returned before must not change")
+	assert.Len(t, CoreBidderNames(), len(existingBidderNames.names)+2, "an alias set again must not be added twice")
+
+	bidderName, found := NormalizeBidderName("ABIDDER")
+	assert.True(t, found)
+	assert.Equal(t, BidderName("aBidder"), bidderName)
+
+	//reset package variables to not interfere with other test cases. Example - TestBidderParamSchemas
+	currentBidderNames.Store(existingBidderNames)
 }
 
 type mockParamsHelper struct {
@@ -269,7 +293,13 @@ func TestNewBidderParamsValidator(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			aliasBidderToParent = map[BidderName]BidderName{"rubicon": "appnexus"}
+			existingBidderNames := currentBidderNames.Load()
+			defer currentBidderNames.Store(existingBidderNames)
+			currentBidderNames.Store(&bidderNames{
+				aliasToParent: map[BidderName]BidderName{"rubicon": "appnexus"},
+				names:         existingBidderNames.names,
+				lookup:        existingBidderNames.lookup,
+			})
 			paramsValidator = &test.paramsValidator
 			bidderValidator, err := NewBidderParamsValidator(test.dir)
 			if test.expectedErr == nil {
@@ -55,6 +55,7 @@ func NewClient(httpClient *http.Client, conf *config.Cache, extCache *config.Ext
 	return &clientImpl{
 		httpClient:          httpClient,
 		putUrl:              conf.GetBaseURL() + "/cache",
+		statusUrl:           conf.GetBaseURL() + "/status",
 		externalCacheScheme: extCache.Scheme,
 		externalCacheHost:   extCache.Host,
 		externalCachePath:   extCache.Path,
@@ -65,12 +66,33 @@ func NewClient(httpClient *http.Client, conf *config.Cache, extCache *config.Ext
 type clientImpl struct {
 	httpClient          *http.Client
 	putUrl              string
+	statusUrl           string
 	externalCacheScheme string
 	externalCacheHost   string
 	externalCachePath   string
 	metrics             metrics.MetricsEngine
 }
 
+// CheckHealth fails when the Prebid Cache status endpoint is unreachable or doesn't respond with a success
+func (c *clientImpl) CheckHealth(ctx context.Context) error {
+	httpReq, err := http.NewRequest("GET", c.statusUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("prebid cache status endpoint %s responded with status %d", c.statusUrl, resp.StatusCode)
+	}
+	return nil
+}
+
 func (c *clientImpl) GetExtCacheData() (string, string, string) {
 	path := c.externalCachePath
 	if path == "/" {
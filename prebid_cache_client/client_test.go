@@ -302,3 +302,43 @@ func newHandler(numResponses int) http.HandlerFunc {
 		w.Write(respBytes)
 	})
 }
+
+func TestCheckHealth(t *testing.T) {
+	testCases := []struct {
+		name          string
+		status        int
+		expectedError string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+		},
+		{
+			name:          "server-error",
+			status:        http.StatusInternalServerError,
+			expectedError: "responded with status 500",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/status", r.URL.Path)
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			client := &clientImpl{
+				httpClient: server.Client(),
+				statusUrl:  server.URL + "/status",
+			}
+
+			err := client.CheckHealth(context.Background())
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedError)
+			}
+		})
+	}
+}
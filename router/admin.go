@@ -1,16 +1,22 @@
 package router
 
 import (
+	"flag"
 	"net/http"
 	"net/http/pprof"
 	"time"
 
+	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/debugcapture"
 	"github.com/prebid/prebid-server/v3/endpoints"
+	"github.com/prebid/prebid-server/v3/exchange"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/modules"
 	"github.com/prebid/prebid-server/v3/version"
 )
 
-func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval time.Duration) *http.ServeMux {
+func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval time.Duration, bidderRegistry *exchange.BidderRegistry, loadBidderInfos func() (config.BidderInfos, error), debugCapturer *debugcapture.Capturer, hookPlanInspector *hookexecution.PlanInspector, moduleManager *modules.Manager) *http.ServeMux {
 	// Add endpoints to the admin server
 	// Making sure to add pprof routes
 	mux := http.NewServeMux()
@@ -22,6 +28,27 @@ func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	// Register prebid-server defined admin handlers
 	mux.HandleFunc("/currency/rates", endpoints.NewCurrencyRatesEndpoint(rateConverter, rateConverterFetchingInterval))
+	mux.HandleFunc("/currency/rates/history", endpoints.NewCurrencyRatesHistoryEndpoint(rateConverter.History()))
 	mux.HandleFunc("/version", endpoints.NewVersionEndpoint(version.Ver, version.Rev))
+	mux.HandleFunc("GET /log/level", endpoints.NewLogLevelEndpoint(flag.CommandLine))
+	mux.HandleFunc("PUT /log/level", endpoints.NewLogLevelUpdateEndpoint(flag.CommandLine))
+	if bidderRegistry != nil {
+		mux.HandleFunc("GET /bidders/config", endpoints.NewBidderConfigsEndpoint(bidderRegistry))
+		mux.HandleFunc("GET /bidders/config/{bidder}", endpoints.NewBidderConfigEndpoint(bidderRegistry))
+		mux.HandleFunc("PATCH /bidders/config/{bidder}", endpoints.NewBidderConfigUpdateEndpoint(bidderRegistry))
+		mux.HandleFunc("POST /bidders/config/reload", endpoints.NewBidderConfigReloadEndpoint(bidderRegistry, loadBidderInfos))
+	}
+	if debugCapturer != nil {
+		mux.HandleFunc("GET /debug/capture/accounts", endpoints.NewDebugCaptureSamplingEndpoint(debugCapturer))
+		mux.HandleFunc("PUT /debug/capture/accounts/{account}", endpoints.NewDebugCaptureSamplingUpdateEndpoint(debugCapturer))
+		mux.HandleFunc("GET /debug/capture/records", endpoints.NewDebugCaptureRecordsEndpoint(debugCapturer))
+	}
+	if hookPlanInspector != nil {
+		mux.HandleFunc("POST /hooks/execution_plan/validate", endpoints.NewHookExecutionPlanValidationEndpoint(hookPlanInspector))
+		mux.HandleFunc("POST /hooks/execution_plan/dry_run", endpoints.NewHookExecutionPlanDryRunEndpoint(hookPlanInspector))
+	}
+	if moduleManager != nil {
+		mux.HandleFunc("PUT /hooks/modules/{module}/config", endpoints.NewModuleConfigUpdateEndpoint(moduleManager))
+	}
 	return mux
 }
@@ -0,0 +1,60 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/health"
+	"github.com/prebid/prebid-server/v3/modules"
+)
+
+// healthChecks names the dependency checkers and flags the ones gating the readiness. Nil checkers are
+// skipped, they stand for dependencies disabled by the host.
+func healthChecks(cfg config.Health, checkers map[string]health.Checker) []health.Check {
+	checks := make([]health.Check, 0, len(checkers))
+	for _, name := range health.CheckNames() {
+		checker, ok := checkers[name]
+		if !ok || checker == nil {
+			continue
+		}
+		checks = append(checks, health.Check{
+			Name:           name,
+			Checker:        checker,
+			GatesReadiness: slices.Contains(cfg.ReadinessChecks, name),
+		})
+	}
+	return checks
+}
+
+// modulesHealth fails when a module enabled by the host config wasn't initialized, which happens when
+// the module isn't registered in this build of Prebid Server. The initialized modules are the map of
+// modules to stage names returned by modules.Builder.Build.
+func modulesHealth(cfg config.Modules, initializedModules map[string][]string) health.Checker {
+	var missing []string
+	for vendor, moduleConfigs := range cfg {
+		for moduleName, data := range moduleConfigs {
+			values, ok := data.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if enabled, _ := values["enabled"].(bool); !enabled {
+				continue
+			}
+			id := fmt.Sprintf("%s.%s", vendor, moduleName)
+			if _, ok := initializedModules[modules.StageNamesKey(id)]; !ok {
+				missing = append(missing, id)
+			}
+		}
+	}
+	sort.Strings(missing)
+
+	return health.CheckerFunc(func(ctx context.Context) error {
+		if len(missing) > 0 {
+			return fmt.Errorf("modules %v are enabled but were not initialized", missing)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/health"
+	"github.com/prebid/prebid-server/v3/modules"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecks(t *testing.T) {
+	checker := health.CheckerFunc(func(ctx context.Context) error { return nil })
+
+	cfg := config.Health{ReadinessChecks: []string{health.CheckStoredRequests, health.CheckModules}}
+	checkers := map[string]health.Checker{
+		health.CheckModules:        checker,
+		health.CheckPrebidCache:    checker,
+		health.CheckStoredRequests: checker,
+		health.CheckGVL:            nil,
+	}
+
+	checks := healthChecks(cfg, checkers)
+
+	type gating struct {
+		name           string
+		gatesReadiness bool
+	}
+	actual := make([]gating, 0, len(checks))
+	for _, check := range checks {
+		actual = append(actual, gating{check.Name, check.GatesReadiness})
+	}
+	expected := []gating{
+		{health.CheckStoredRequests, true},
+		{health.CheckPrebidCache, false},
+		{health.CheckModules, true},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestModulesHealth(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         config.Modules
+		expectedErr string
+	}{
+		{
+			name: "no-modules",
+		},
+		{
+			name: "all-enabled-modules-initialized",
+			cfg: config.Modules{
+				"prebid": {
+					"ortb2blocking": map[string]interface{}{"enabled": true},
+					"rules":         map[string]interface{}{"enabled": false},
+				},
+			},
+		},
+		{
+			name: "enabled-modules-not-initialized",
+			cfg: config.Modules{
+				"prebid": {
+					"ortb2blocking": map[string]interface{}{"enabled": true},
+				},
+				"acme": {
+					"foo":     map[string]interface{}{"enabled": true},
+					"bar-baz": map[string]interface{}{"enabled": true},
+					"qux":     map[string]interface{}{"enabled": false},
+				},
+			},
+			expectedErr: "modules [acme.bar-baz acme.foo] are enabled but were not initialized",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, moduleStageNames, _, err := modules.NewBuilder().Build(test.cfg, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient})
+			require.NoError(t, err)
+
+			err = modulesHealth(test.cfg, moduleStageNames).CheckHealth(context.Background())
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
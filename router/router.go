@@ -10,10 +10,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	openrtb2model "github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/account"
 	analyticsBuild "github.com/prebid/prebid-server/v3/analytics/build"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/debugcapture"
 	"github.com/prebid/prebid-server/v3/endpoints"
 	"github.com/prebid/prebid-server/v3/endpoints/events"
 	infoEndpoints "github.com/prebid/prebid-server/v3/endpoints/info"
@@ -23,18 +26,22 @@ import (
 	"github.com/prebid/prebid-server/v3/experiment/adscert"
 	"github.com/prebid/prebid-server/v3/floors"
 	"github.com/prebid/prebid-server/v3/gdpr"
+	"github.com/prebid/prebid-server/v3/health"
 	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
 	"github.com/prebid/prebid-server/v3/macros"
 	"github.com/prebid/prebid-server/v3/metrics"
 	metricsConf "github.com/prebid/prebid-server/v3/metrics/config"
 	"github.com/prebid/prebid-server/v3/modules"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/notifications"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/ortb"
 	"github.com/prebid/prebid-server/v3/pbs"
 	pbc "github.com/prebid/prebid-server/v3/prebid_cache_client"
 	"github.com/prebid/prebid-server/v3/router/aspects"
 	"github.com/prebid/prebid-server/v3/server/ssl"
+	"github.com/prebid/prebid-server/v3/stored_requests"
 	storedRequestsConf "github.com/prebid/prebid-server/v3/stored_requests/config"
 	"github.com/prebid/prebid-server/v3/usersync"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
@@ -116,6 +123,14 @@ type Router struct {
 	*httprouter.Router
 	MetricsEngine   *metricsConf.DetailedMetricsEngine
 	ParamsValidator openrtb_ext.BidderParamValidator
+	// BidderRegistry changes the bidders configuration of the exchange at runtime
+	BidderRegistry *exchange.BidderRegistry
+	// DebugCapturer captures the debug data of the account auctions sampled from the admin endpoints
+	DebugCapturer *debugcapture.Capturer
+	// HookPlanInspector validates and dry runs the hook execution plans from the admin endpoints
+	HookPlanInspector *hookexecution.PlanInspector
+	// ModuleManager passes the host config updates of the admin endpoints to the hook modules
+	ModuleManager *modules.Manager
 
 	shutdowns []func()
 }
@@ -185,17 +200,11 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 		syncerKeys = append(syncerKeys, k)
 	}
 
-	moduleDeps := moduledeps.ModuleDeps{HTTPClient: generalHttpClient, RateConvertor: rateConvertor}
-	repo, moduleStageNames, err := modules.NewBuilder().Build(cfg.Hooks.Modules, moduleDeps)
-	if err != nil {
-		glog.Fatalf("Failed to init hook modules: %v", err)
-	}
-
-	// Metrics engine
-	r.MetricsEngine = metricsConf.NewMetricsEngine(cfg, openrtb_ext.CoreBidderNames(), syncerKeys, moduleStageNames)
+	// Metrics engine, the metrics of hook modules are registered once the modules are built
+	r.MetricsEngine = metricsConf.NewMetricsEngine(cfg, openrtb_ext.CoreBidderNames(), syncerKeys, nil)
 	shutdown, fetcher, ampFetcher, accounts, categoriesFetcher, videoFetcher, storedRespFetcher := storedRequestsConf.NewStoredRequests(cfg, r.MetricsEngine, generalHttpClient, r.Router)
 
-	analyticsRunner := analyticsBuild.New(&cfg.Analytics)
+	analyticsRunner := analyticsBuild.New(&cfg.Analytics, r.MetricsEngine)
 
 	// register the analytics runner for shutdown
 	r.shutdowns = append(r.shutdowns, shutdown, analyticsRunner.Shutdown)
@@ -211,12 +220,36 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 	defReqJSON := readDefaultRequest(cfg.DefReqConfig)
 
 	gvlVendorIDs := cfg.BidderInfos.ToGVLVendorIDMap()
-	vendorListFetcher := gdpr.NewVendorListFetcher(context.Background(), cfg.GDPR, generalHttpClient, gdpr.VendorListURLMaker)
+	vendorListFetcher, vendorListHealth := gdpr.NewVendorListFetcherWithHealth(context.Background(), cfg.GDPR, generalHttpClient, gdpr.VendorListURLMaker)
 	gdprPermsBuilder := gdpr.NewPermissionsBuilder(cfg.GDPR, gvlVendorIDs, vendorListFetcher)
 	tcf2CfgBuilder := gdpr.NewTCF2Config
 
 	cacheClient := pbc.NewClient(cacheHttpClient, &cfg.CacheURL, &cfg.ExtCacheURL, r.MetricsEngine)
 
+	moduleDeps := moduledeps.ModuleDeps{
+		HTTPClient:           generalHttpClient,
+		RateConvertor:        rateConvertor,
+		MetricsEngine:        r.MetricsEngine,
+		Metrics:              moduledeps.NewMetrics(r.MetricsEngine),
+		AccountFetcher:       accounts,
+		StoredRequestFetcher: fetcher,
+		CacheClient:          cacheClient,
+		GDPRPermsBuilder:     gdprPermsBuilder,
+		Cache:                moduledeps.NewCache(cfg.Hooks.ModuleCacheSizeMB),
+	}
+	repo, moduleStageNames, moduleManager, err := modules.NewBuilder().Build(cfg.Hooks.Modules, moduleDeps)
+	if err != nil {
+		glog.Fatalf("Failed to init hook modules: %v", err)
+	}
+	r.MetricsEngine.RegisterModules(moduleStageNames)
+	if err := moduleManager.Start(context.Background()); err != nil {
+		glog.Fatalf("Failed to start hook modules: %v", err)
+	}
+	r.shutdowns = append(r.shutdowns, moduleManager.Shutdown)
+	r.ModuleManager = moduleManager
+	// the endpoints fetch the accounts through the manager, which passes account module config changes to the modules
+	accounts = moduleManager.WatchAccounts(accounts)
+
 	adapters, singleFormatAdapters, adaptersErrs := exchange.BuildAdapters(generalHttpClient, cfg, cfg.BidderInfos, r.MetricsEngine)
 	if len(adaptersErrs) > 0 {
 		errs := errortypes.NewAggregateError("Failed to initialize adapters", adaptersErrs)
@@ -232,8 +265,25 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 
 	tmaxAdjustments := exchange.ProcessTMaxAdjustments(cfg.TmaxAdjustments)
 	planBuilder := hooks.NewExecutionPlanBuilder(cfg.Hooks, repo)
+	r.HookPlanInspector = hookexecution.NewPlanInspector(cfg.Hooks, repo, func(ctx context.Context, accountID string) (*config.Account, []error) {
+		// admin lookups are left out of the account metrics
+		return account.GetAccount(ctx, cfg, accounts, accountID, &metricsConf.NilMetricsEngine{})
+	})
 	macroReplacer := macros.NewStringIndexBasedReplacer()
-	theExchange := exchange.NewExchange(adapters, cacheClient, cfg, requestValidator, syncersByBidder, r.MetricsEngine, cfg.BidderInfos, gdprPermsBuilder, rateConvertor, categoriesFetcher, adsCertSigner, macroReplacer, priceFloorFetcher, singleFormatAdapters)
+	bidStore := notifications.NewBidStore(cfg.Event.Reconciliation, clock.New())
+	debugCaptureSink, err := debugcapture.NewSink(cfg.Debug.Capture)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the debug capture sink: %v", err)
+	}
+	if fileSink, ok := debugCaptureSink.(*debugcapture.FileSink); ok {
+		r.shutdowns = append(r.shutdowns, fileSink.Close)
+	}
+	r.DebugCapturer = debugcapture.NewCapturer(debugCaptureSink)
+	bidNotifier := notifications.NewNotifier(generalHttpClient, time.Duration(cfg.Event.NotificationTimeoutMS)*time.Millisecond)
+	theExchange := exchange.NewExchange(adapters, cacheClient, cfg, requestValidator, syncersByBidder, r.MetricsEngine, cfg.BidderInfos, gdprPermsBuilder, rateConvertor, categoriesFetcher, adsCertSigner, macroReplacer, priceFloorFetcher, singleFormatAdapters, bidStore, bidNotifier, r.DebugCapturer)
+	if bidderUpdater, ok := theExchange.(exchange.BidderUpdater); ok {
+		r.BidderRegistry = exchange.NewBidderRegistry(generalHttpClient, cfg, r.MetricsEngine, bidderUpdater)
+	}
 	var uuidGenerator uuidutil.UUIDRandomGenerator
 	openrtbEndpoint, err := openrtb2.NewEndpoint(uuidGenerator, theExchange, requestValidator, fetcher, accounts, cfg, r.MetricsEngine, analyticsRunner, disabledBidders, defReqJSON, activeBidders, storedRespFetcher, planBuilder, tmaxAdjustments)
 	if err != nil {
@@ -245,7 +295,7 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 		glog.Fatalf("Failed to create the amp endpoint handler. %v", err)
 	}
 
-	videoEndpoint, err := openrtb2.NewVideoEndpoint(uuidGenerator, theExchange, requestValidator, fetcher, videoFetcher, accounts, cfg, r.MetricsEngine, analyticsRunner, disabledBidders, defReqJSON, activeBidders, cacheClient, tmaxAdjustments)
+	videoEndpoint, err := openrtb2.NewVideoEndpoint(uuidGenerator, theExchange, requestValidator, fetcher, videoFetcher, accounts, cfg, r.MetricsEngine, analyticsRunner, disabledBidders, defReqJSON, activeBidders, cacheClient, planBuilder, tmaxAdjustments)
 	if err != nil {
 		glog.Fatalf("Failed to create the video endpoint handler. %v", err)
 	}
@@ -263,6 +313,26 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 	r.GET("/bidders/params", NewJsonDirectoryServer(schemaDirectory, paramsValidator))
 	r.POST("/cookie_sync", endpoints.NewCookieSyncEndpoint(syncersByBidder, cfg, gdprPermsBuilder, tcf2CfgBuilder, r.MetricsEngine, analyticsRunner, accounts, activeBidders).Handle)
 	r.GET("/status", endpoints.NewStatusEndpoint(cfg.StatusResponse))
+	healthCheckers := map[string]health.Checker{
+		health.CheckStoredRequests: health.CheckerFunc(func(ctx context.Context) error {
+			return stored_requests.CheckHealth(ctx, fetcher, ampFetcher, accounts, categoriesFetcher, videoFetcher, storedRespFetcher)
+		}),
+		health.CheckGVL:     vendorListHealth,
+		health.CheckModules: modulesHealth(cfg.Hooks.Modules, moduleStageNames),
+	}
+	if rateConvertor != nil && cfg.CurrencyConverter.FetchIntervalSeconds > 0 {
+		healthCheckers[health.CheckCurrencyRates] = rateConvertor
+	}
+	if priceFloorFetcher != nil {
+		healthCheckers[health.CheckFloorsFetcher] = priceFloorFetcher
+	}
+	if cacheChecker, ok := cacheClient.(health.Checker); ok && cfg.CacheURL.Host != "" {
+		healthCheckers[health.CheckPrebidCache] = cacheChecker
+	}
+	healthChecks := healthChecks(cfg.Health, healthCheckers)
+	healthCheckTimeout := time.Duration(cfg.Health.CheckTimeoutMS) * time.Millisecond
+	r.GET("/healthz", endpoints.NewHealthzEndpoint(healthChecks, healthCheckTimeout))
+	r.GET("/readyz", endpoints.NewReadyzEndpoint(healthChecks, healthCheckTimeout))
 	r.GET("/", serveIndex)
 	r.Handler("GET", "/version", endpoints.NewVersionEndpoint(version.Ver, version.Rev))
 	r.ServeFiles("/static/*filepath", http.Dir("static"))
@@ -274,7 +344,7 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 	}
 
 	// event endpoint
-	eventEndpoint := events.NewEventEndpoint(cfg, accounts, analyticsRunner, r.MetricsEngine)
+	eventEndpoint := events.NewEventEndpoint(cfg, accounts, analyticsRunner, r.MetricsEngine, bidStore)
 	r.GET("/event", eventEndpoint)
 
 	userSyncDeps := &pbs.UserSyncDeps{
@@ -23,6 +23,8 @@ func newPrometheusServer(cfg *config.Configuration, metrics *metricsconfig.Detai
 			ErrorLog:            loggerForPrometheus{},
 			MaxRequestsInFlight: 5,
 			Timeout:             cfg.Metrics.Prometheus.Timeout(),
+			// exemplars are only part of the OpenMetrics exposition format
+			EnableOpenMetrics: cfg.Metrics.Prometheus.Exemplars,
 		}),
 	}
 }
@@ -40,6 +40,11 @@ type dbFetcher struct {
 	responseQueryTemplate string
 }
 
+// CheckHealth pings the database the Stored Requests are fetched from.
+func (fetcher *dbFetcher) CheckHealth(ctx context.Context) error {
+	return fetcher.provider.PingContext(ctx)
+}
+
 func (fetcher *dbFetcher) FetchRequests(ctx context.Context, requestIDs []string, impIDs []string) (map[string]json.RawMessage, map[string]json.RawMessage, []error) {
 	if len(requestIDs) < 1 && len(impIDs) < 1 {
 		return nil, nil, nil
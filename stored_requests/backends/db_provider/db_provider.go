@@ -14,6 +14,7 @@ type DbProvider interface {
 	Open() error
 	Close() error
 	Ping() error
+	PingContext(ctx context.Context) error
 	PrepareQuery(template string, params ...QueryParam) (query string, args []interface{})
 	QueryContext(ctx context.Context, template string, params ...QueryParam) (*sql.Rows, error)
 }
@@ -48,6 +48,10 @@ func (provider DbProviderMock) Ping() error {
 	return nil
 }
 
+func (provider DbProviderMock) PingContext(ctx context.Context) error {
+	return nil
+}
+
 func (provider DbProviderMock) PrepareQuery(template string, params ...QueryParam) (query string, args []interface{}) {
 	for _, param := range params {
 		if reflect.TypeOf(param.Value).Kind() == reflect.Slice {
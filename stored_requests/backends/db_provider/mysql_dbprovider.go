@@ -58,6 +58,10 @@ func (provider *MySqlDbProvider) Ping() error {
 	return provider.db.Ping()
 }
 
+func (provider *MySqlDbProvider) PingContext(ctx context.Context) error {
+	return provider.db.PingContext(ctx)
+}
+
 func (provider *MySqlDbProvider) ConnString() (string, error) {
 	buffer := bytes.NewBuffer(nil)
 
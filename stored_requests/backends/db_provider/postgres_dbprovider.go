@@ -51,6 +51,10 @@ func (provider *PostgresDbProvider) Ping() error {
 	return provider.db.Ping()
 }
 
+func (provider *PostgresDbProvider) PingContext(ctx context.Context) error {
+	return provider.db.PingContext(ctx)
+}
+
 func (provider *PostgresDbProvider) ConnString() (string, error) {
 	buffer := bytes.NewBuffer(nil)
 	buffer.WriteString("postgresql://")
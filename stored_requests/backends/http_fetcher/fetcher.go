@@ -97,6 +97,23 @@ func (fetcher *HttpFetcher) FetchRequests(ctx context.Context, requestIDs []stri
 	return
 }
 
+// CheckHealth checks that the endpoint is reachable and doesn't respond with a server error.
+func (fetcher *HttpFetcher) CheckHealth(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fetcher.Endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("stored requests endpoint %s is invalid: %v", fetcher.Endpoint, err)
+	}
+	httpResp, err := ctxhttp.Do(ctx, fetcher.client, httpReq)
+	if err != nil {
+		return fmt.Errorf("stored requests endpoint %s is unreachable: %v", fetcher.Endpoint, err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("stored requests endpoint %s responded with status %d", fetcher.Endpoint, httpResp.StatusCode)
+	}
+	return nil
+}
+
 func (fetcher *HttpFetcher) FetchResponses(ctx context.Context, ids []string) (data map[string]json.RawMessage, errs []error) {
 	return nil, nil
 }
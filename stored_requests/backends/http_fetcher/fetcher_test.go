@@ -352,3 +352,50 @@ func assertMapKeys(t *testing.T, m map[string]json.RawMessage, keys ...string) {
 		}
 	}
 }
+
+func TestCheckHealth(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      int
+		closed      bool
+		expectedErr string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+		},
+		{
+			name:   "client-error",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:        "server-error",
+			status:      http.StatusServiceUnavailable,
+			expectedErr: "responded with status 503",
+		},
+		{
+			name:        "unreachable",
+			closed:      true,
+			expectedErr: "is unreachable",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+			if test.closed {
+				server.Close()
+			}
+
+			err := NewFetcher(server.Client(), server.URL).CheckHealth(context.Background())
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
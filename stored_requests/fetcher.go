@@ -3,6 +3,7 @@ package stored_requests
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/prebid/prebid-server/v3/metrics"
@@ -145,6 +146,25 @@ func (c ComposedCache) Save(ctx context.Context, data map[string]json.RawMessage
 	}
 }
 
+// healthChecker is implemented by the fetchers able to report whether their backend is reachable.
+type healthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckHealth checks the backends of the given fetchers. Fetchers which don't depend on a remote
+// backend, like the file or empty fetchers, are always considered healthy.
+func CheckHealth(ctx context.Context, fetchers ...interface{}) error {
+	var errs []error
+	for _, fetcher := range fetchers {
+		if checker, ok := fetcher.(healthChecker); ok {
+			if err := checker.CheckHealth(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 type fetcherWithCache struct {
 	fetcher       AllFetcher
 	cache         Cache
@@ -163,6 +183,11 @@ func WithCache(fetcher AllFetcher, cache Cache, metricsEngine metrics.MetricsEng
 	}
 }
 
+// CheckHealth checks the backend of the fetcher behind the cache.
+func (f *fetcherWithCache) CheckHealth(ctx context.Context) error {
+	return CheckHealth(ctx, f.fetcher)
+}
+
 func (f *fetcherWithCache) FetchRequests(ctx context.Context, requestIDs []string, impIDs []string) (requestData map[string]json.RawMessage, impData map[string]json.RawMessage, errs []error) {
 
 	requestData = f.cache.Requests.Get(ctx, requestIDs)
@@ -372,3 +372,61 @@ func (c *mockCache) Save(ctx context.Context, data map[string]json.RawMessage) {
 func (c *mockCache) Invalidate(ctx context.Context, ids []string) {
 	c.Called(ctx, ids)
 }
+
+type healthCheckingFetcher struct {
+	mockFetcher
+	err error
+}
+
+func (f *healthCheckingFetcher) CheckHealth(ctx context.Context) error {
+	return f.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	failure := errors.New("backend unreachable")
+
+	testCases := []struct {
+		name        string
+		fetchers    []interface{}
+		expectedErr error
+	}{
+		{
+			name:     "no-fetchers",
+			fetchers: nil,
+		},
+		{
+			name:     "fetchers-without-backend",
+			fetchers: []interface{}{&mockFetcher{}, nil},
+		},
+		{
+			name:     "healthy-backend",
+			fetchers: []interface{}{&healthCheckingFetcher{}},
+		},
+		{
+			name:        "unhealthy-backend",
+			fetchers:    []interface{}{&mockFetcher{}, &healthCheckingFetcher{err: failure}},
+			expectedErr: failure,
+		},
+		{
+			name:        "unhealthy-backend-behind-cache",
+			fetchers:    []interface{}{WithCache(&healthCheckingFetcher{err: failure}, Cache{}, &metrics.MetricsEngineMock{})},
+			expectedErr: failure,
+		},
+		{
+			name:        "unhealthy-backend-in-multifetcher",
+			fetchers:    []interface{}{MultiFetcher{&mockFetcher{}, &healthCheckingFetcher{err: failure}}},
+			expectedErr: failure,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckHealth(context.Background(), test.fetchers...)
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -36,6 +36,15 @@ func (mf MultiFetcher) FetchRequests(ctx context.Context, requestIDs []string, i
 	return
 }
 
+// CheckHealth checks the backends of all the sub-Fetchers
+func (mf MultiFetcher) CheckHealth(ctx context.Context) error {
+	fetchers := make([]interface{}, 0, len(mf))
+	for _, f := range mf {
+		fetchers = append(fetchers, f)
+	}
+	return CheckHealth(ctx, fetchers...)
+}
+
 func (mf MultiFetcher) FetchResponses(ctx context.Context, ids []string) (data map[string]json.RawMessage, errs []error) {
 	return nil, nil
 }
@@ -0,0 +1,259 @@
+package fileutil
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/docker/go-units"
+	"github.com/golang/glog"
+)
+
+// rotatedTimeFormat is sortable so the oldest rotated files are found by name
+const rotatedTimeFormat = "20060102T150405.000"
+
+// RotatingWriter appends lines to <dir>/<name><ext> and rotates the file once it exceeds maxSize bytes or is
+// older than maxAge. Rotated files are renamed <name>-<timestamp><ext> and optionally gzipped. It isn't safe for
+// concurrent use, its owner writes from a single goroutine.
+type RotatingWriter struct {
+	dir        string
+	name       string
+	ext        string
+	maxSize    int64
+	maxAge     time.Duration
+	compress   bool
+	maxBackups int
+	clock      clock.Clock
+
+	file     *os.File
+	buffer   *bufio.Writer
+	size     int64
+	openedAt time.Time
+
+	// compressions tracks the rotated files being gzipped in the background
+	compressions sync.WaitGroup
+}
+
+// ParseRotationLimits parses the size, in SI standard eg. "100MB", and the age, eg. "1h", a file is rotated at.
+// Empty values disable the rotation on size or age.
+func ParseRotationLimits(maxSize, maxAge string) (int64, time.Duration, error) {
+	var size int64
+	if maxSize != "" {
+		parsed, err := units.FromHumanSize(maxSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		size = parsed
+	}
+
+	var age time.Duration
+	if maxAge != "" {
+		parsed, err := time.ParseDuration(maxAge)
+		if err != nil {
+			return 0, 0, err
+		}
+		age = parsed
+	}
+	return size, age, nil
+}
+
+// NewRotatingWriter opens the file at path, appending to it if it exists. A maxSize or maxAge of 0 disables the
+// rotation on size or age, and a maxBackups of 0 keeps all rotated files.
+func NewRotatingWriter(path string, maxSize int64, maxAge time.Duration, compress bool, maxBackups int, clock clock.Clock) (*RotatingWriter, error) {
+	ext := filepath.Ext(path)
+	w := &RotatingWriter{
+		dir:        filepath.Dir(path),
+		name:       strings.TrimSuffix(filepath.Base(path), ext),
+		ext:        ext,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		compress:   compress,
+		maxBackups: maxBackups,
+		clock:      clock,
+	}
+	return w, w.open()
+}
+
+func (w *RotatingWriter) path() string {
+	return filepath.Join(w.dir, w.name+w.ext)
+}
+
+func (w *RotatingWriter) open() error {
+	file, err := os.OpenFile(w.path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.buffer = bufio.NewWriter(file)
+	w.size = info.Size()
+	w.openedAt = w.clock.Now()
+	return nil
+}
+
+// WriteLine appends the line followed by a new line, rotating the file first if needed
+func (w *RotatingWriter) WriteLine(line []byte) error {
+	if w.shouldRotate(int64(len(line)) + 1) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.buffer.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if err := w.buffer.WriteByte('\n'); err != nil {
+		return err
+	}
+	w.size++
+	return nil
+}
+
+// Flush writes the buffered lines to the file
+func (w *RotatingWriter) Flush() error {
+	return w.buffer.Flush()
+}
+
+func (w *RotatingWriter) shouldRotate(length int64) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.maxSize > 0 && w.size+length > w.maxSize {
+		return true
+	}
+	return w.maxAge > 0 && w.clock.Since(w.openedAt) >= w.maxAge
+}
+
+func (w *RotatingWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	rotatedPath := filepath.Join(w.dir, fmt.Sprintf("%s-%s%s", w.name, w.clock.Now().UTC().Format(rotatedTimeFormat), w.ext))
+	if err := os.Rename(w.path(), rotatedPath); err != nil {
+		return err
+	}
+
+	if w.compress {
+		w.compressions.Add(1)
+		go func() {
+			defer w.compressions.Done()
+			if err := compressFile(rotatedPath); err != nil {
+				glog.Errorf("Failed to compress the rotated file %s: %v", rotatedPath, err)
+			}
+			w.removeOldBackups()
+		}()
+	} else {
+		w.removeOldBackups()
+	}
+
+	return w.open()
+}
+
+// removeOldBackups deletes the oldest rotated files beyond maxBackups
+func (w *RotatingWriter) removeOldBackups() {
+	if w.maxBackups <= 0 {
+		return
+	}
+
+	backups, err := filepath.Glob(filepath.Join(w.dir, w.name+"-*"+w.ext+"*"))
+	if err != nil {
+		glog.Errorf("Failed to list the rotated files of %s: %v", w.path(), err)
+		return
+	}
+
+	// a rotated file being compressed exists twice, count it once
+	names := make(map[string]struct{}, len(backups))
+	for _, backup := range backups {
+		if strings.HasSuffix(backup, ".tmp") {
+			continue
+		}
+		names[strings.TrimSuffix(backup, ".gz")] = struct{}{}
+	}
+	if len(names) <= w.maxBackups {
+		return
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted[:len(sorted)-w.maxBackups] {
+		os.Remove(name)
+		os.Remove(name + ".gz")
+	}
+}
+
+func (w *RotatingWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	if err := w.buffer.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// Close flushes and closes the current file, and waits for the rotated files to be compressed
+func (w *RotatingWriter) Close() error {
+	err := w.closeFile()
+	w.compressions.Wait()
+	return err
+}
+
+// compressFile replaces the file at path with its gzipped version at path.gz
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpPath := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path+".gz"); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,125 @@
+package fileutil
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRotatingWriterSizeRotation(t *testing.T) {
+	dir := t.TempDir()
+	clockMock := clock.NewMock()
+	clockMock.Set(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	w, err := NewRotatingWriter(filepath.Join(dir, "auction.log"), 10, 0, false, 0, clockMock)
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteLine([]byte("first")))
+	require.NoError(t, w.WriteLine([]byte("a")))
+	clockMock.Add(time.Second)
+	require.NoError(t, w.WriteLine([]byte("second")))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"auction-20240301T100001.000.log", "auction.log"}, listFiles(t, dir))
+	assert.Equal(t, "first\na\n", readFile(t, filepath.Join(dir, "auction-20240301T100001.000.log")))
+	assert.Equal(t, "second\n", readFile(t, filepath.Join(dir, "auction.log")))
+}
+
+func TestRotatingWriterAgeRotation(t *testing.T) {
+	dir := t.TempDir()
+	clockMock := clock.NewMock()
+	clockMock.Set(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	w, err := NewRotatingWriter(filepath.Join(dir, "amp.log"), 0, time.Hour, false, 0, clockMock)
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteLine([]byte("first")))
+	clockMock.Add(59 * time.Minute)
+	require.NoError(t, w.WriteLine([]byte("second")))
+	clockMock.Add(time.Minute)
+	require.NoError(t, w.WriteLine([]byte("third")))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"amp-20240301T110000.000.log", "amp.log"}, listFiles(t, dir))
+	assert.Equal(t, "first\nsecond\n", readFile(t, filepath.Join(dir, "amp-20240301T110000.000.log")))
+	assert.Equal(t, "third\n", readFile(t, filepath.Join(dir, "amp.log")))
+}
+
+func TestRotatingWriterCompression(t *testing.T) {
+	dir := t.TempDir()
+	clockMock := clock.NewMock()
+	clockMock.Set(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	w, err := NewRotatingWriter(filepath.Join(dir, "video.log"), 1, 0, true, 0, clockMock)
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteLine([]byte("first")))
+	require.NoError(t, w.WriteLine([]byte("second")))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"video-20240301T100000.000.log.gz", "video.log"}, listFiles(t, dir))
+
+	file, err := os.Open(filepath.Join(dir, "video-20240301T100000.000.log.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(content))
+}
+
+func TestRotatingWriterMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	clockMock := clock.NewMock()
+	clockMock.Set(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	w, err := NewRotatingWriter(filepath.Join(dir, "event.log"), 1, 0, false, 2, clockMock)
+	require.NoError(t, err)
+
+	for _, line := range []string{"1", "2", "3", "4"} {
+		require.NoError(t, w.WriteLine([]byte(line)))
+		clockMock.Add(time.Second)
+	}
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"event-20240301T100002.000.log", "event-20240301T100003.000.log", "event.log"}, listFiles(t, dir))
+	assert.Equal(t, "4\n", readFile(t, filepath.Join(dir, "event.log")))
+}
+
+func TestRotatingWriterAppendsToExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "setuid.log"), []byte("existing\n"), 0644))
+
+	w, err := NewRotatingWriter(filepath.Join(dir, "setuid.log"), 0, 0, false, 0, clock.NewMock())
+	require.NoError(t, err)
+	require.NoError(t, w.WriteLine([]byte("new")))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "existing\nnew\n", readFile(t, filepath.Join(dir, "setuid.log")))
+}
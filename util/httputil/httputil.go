@@ -85,3 +85,15 @@ func findRemoteAddr(r *http.Request, v iputil.IPValidator) (net.IP, iputil.IPVer
 	}
 	return nil, iputil.IPvUnknown
 }
+
+// StatusAllowsBody reports whether a response with the status code may have a body,
+// the informational, 204 (No Content) and 304 (Not Modified) responses can't.
+func StatusAllowsBody(statusCode int) bool {
+	switch {
+	case statusCode >= 100 && statusCode <= 199:
+		return false
+	case statusCode == http.StatusNoContent, statusCode == http.StatusNotModified:
+		return false
+	}
+	return true
+}
@@ -263,3 +263,17 @@ type hardcodedResponseIPValidator struct {
 func (v hardcodedResponseIPValidator) IsValid(net.IP, iputil.IPVersion) bool {
 	return v.response
 }
+
+func TestStatusAllowsBody(t *testing.T) {
+	testCases := map[int]bool{
+		http.StatusContinue:        false,
+		http.StatusOK:              true,
+		http.StatusNoContent:       false,
+		http.StatusNotModified:     false,
+		http.StatusTooManyRequests: true,
+	}
+
+	for statusCode, expected := range testCases {
+		assert.Equal(t, expected, StatusAllowsBody(statusCode), statusCode)
+	}
+}
@@ -0,0 +1,70 @@
+package lruutil
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache keeps the values of up to maxEntries keys, evicting the least recently used key when
+// a new one is added to a full cache. It's safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[K]*list.Element
+	order      *list.List // front is the most recently used entry
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New returns a Cache keeping the values of up to maxEntries keys, maxEntries must be > 0.
+func New[K comparable, V any](maxEntries int) *Cache[K, V] {
+	return &Cache[K, V]{
+		maxEntries: maxEntries,
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the value of the key and marks it as the most recently used one.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*entry[K, V]).value, true
+}
+
+// Add sets the value of the key, evicting the least recently used key if the cache is full.
+func (c *Cache[K, V]) Add(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry[K, V]).key)
+	}
+}
+
+// Len returns the number of keys in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
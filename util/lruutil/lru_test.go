@@ -0,0 +1,36 @@
+package lruutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	cache := New[string, int](2)
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	// b is the least recently used key since a was read
+	cache.Add("c", 3)
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "The least recently used key should be evicted.")
+
+	cache.Add("a", 4)
+	value, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+	assert.Equal(t, 2, cache.Len())
+
+	value, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+}